@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -11,13 +12,12 @@ import (
 )
 
 func NewGetCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "get <uuid>",
 		Short: "Get application details by UUID",
-		Long:  `Retrieve detailed information about a specific application.`,
+		Long:  `Retrieve detailed information about a specific application. Use --watch to keep it refreshed.`,
 		Args:  cli.ExactArgs(1, "<uuid>"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := cmd.Context()
 			uuid := args[0]
 
 			client, err := cli.GetAPIClient(cmd)
@@ -25,23 +25,19 @@ func NewGetCommand() *cobra.Command {
 				return fmt.Errorf("failed to get API client: %w", err)
 			}
 
-			appSvc := service.NewApplicationService(client)
-			app, err := appSvc.Get(ctx, uuid)
-			if err != nil {
-				return fmt.Errorf("failed to get application: %w", err)
-			}
-
-			format, _ := cmd.Flags().GetString("format")
 			showSensitive, _ := cmd.Flags().GetBool("show-sensitive")
+			appSvc := service.NewApplicationService(client)
 
-			formatter, err := output.NewFormatter(format, output.Options{
-				ShowSensitive: showSensitive,
+			return cli.RunWithWatch(cmd, output.Options{ShowSensitive: showSensitive}, func(ctx context.Context) (any, error) {
+				app, err := appSvc.Get(ctx, uuid)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get application: %w", err)
+				}
+				return app, nil
 			})
-			if err != nil {
-				return err
-			}
-
-			return formatter.Format(app)
 		},
 	}
+
+	cli.AddWatchFlags(cmd)
+	return cmd
 }
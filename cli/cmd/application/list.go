@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -12,59 +13,48 @@ import (
 )
 
 func NewListCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all applications",
-		Long:  `List all applications in Saturn.`,
+		Long:  `List all applications in Saturn. Use --watch to keep the list refreshed.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			ctx := cmd.Context()
-
 			client, err := cli.GetAPIClient(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to get API client: %w", err)
 			}
 
-			appSvc := service.NewApplicationService(client)
-			apps, err := appSvc.List(ctx)
-			if err != nil {
-				return fmt.Errorf("failed to list applications: %w", err)
-			}
-
 			format, _ := cmd.Flags().GetString("format")
 			showSensitive, _ := cmd.Flags().GetBool("show-sensitive")
+			appSvc := service.NewApplicationService(client)
 
-			// For JSON/pretty formats, return the full application structure
-			if format != output.FormatTable {
-				formatter, err := output.NewFormatter(format, output.Options{
-					ShowSensitive: showSensitive,
-				})
+			return cli.RunWithWatch(cmd, output.Options{ShowSensitive: showSensitive}, func(ctx context.Context) (any, error) {
+				apps, err := appSvc.List(ctx)
 				if err != nil {
-					return err
+					return nil, fmt.Errorf("failed to list applications: %w", err)
 				}
-				return formatter.Format(apps)
-			}
 
-			// For table format, convert to simplified rows
-			var rows []models.ApplicationListItem
-			for _, app := range apps {
-				rows = append(rows, models.ApplicationListItem{
-					UUID:        app.UUID,
-					Name:        app.Name,
-					Description: app.Description,
-					Status:      app.Status,
-					GitBranch:   app.GitBranch,
-					FQDN:        app.FQDN,
-				})
-			}
+				// For JSON/pretty formats, return the full application structure
+				if format != output.FormatTable {
+					return apps, nil
+				}
 
-			formatter, err := output.NewFormatter(format, output.Options{
-				ShowSensitive: showSensitive,
+				// For table format, convert to simplified rows
+				rows := make([]models.ApplicationListItem, 0, len(apps))
+				for _, app := range apps {
+					rows = append(rows, models.ApplicationListItem{
+						UUID:        app.UUID,
+						Name:        app.Name,
+						Description: app.Description,
+						Status:      app.Status,
+						GitBranch:   app.GitBranch,
+						FQDN:        app.FQDN,
+					})
+				}
+				return rows, nil
 			})
-			if err != nil {
-				return err
-			}
-
-			return formatter.Format(rows)
 		},
 	}
+
+	cli.AddWatchFlags(cmd)
+	return cmd
 }
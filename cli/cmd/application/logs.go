@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -11,15 +12,44 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
 	"github.com/saturn-platform/saturn-cli/internal/service"
 )
 
+const (
+	// logPollInterval is how often logs are fetched with --follow
+	logPollInterval = 2 * time.Second
+	// filteredLogLines is how many lines are searched when a log filter is set without --lines
+	filteredLogLines = 1000
+)
+
 func NewLogsCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "logs <uuid>",
 		Short: "Get application logs",
-		Long:  `Retrieve logs for an application. Use --follow to continuously stream new logs.`,
-		Args:  cli.ExactArgs(1, "<uuid>"),
+		Long: `Retrieve logs for an application. Use --follow to continuously stream new logs.
+
+With --follow, the logs endpoint is polled every two seconds.
+
+--since, --until, --grep and --level filter the lines locally. Timestamps and
+levels are detected in common formats (RFC 3339, JSON, logfmt, [ERROR] ...);
+lines without one, like stack traces, belong to the line before them, and lines
+before the first timestamp are kept by --since and --until. When a
+filter is set, the last 1000 lines are searched unless --lines says otherwise.
+
+Lines holding a JSON object or logfmt pairs are shown as time, level and message,
+followed by the error and trace_id fields and any fields picked with --log-fields.
+--raw prints them unchanged. With --format ndjson, each event carries the
+normalised fields in "data".
+
+When the output is not a terminal (a file, a pipe or a CI log), the values of
+the application's environment variables are replaced with ******** . Use --mask
+to mask on a terminal as well, or --show-sensitive to never mask.`,
+		Example: `  saturn app logs <uuid> --since 10m --level error
+  saturn app logs <uuid> --grep 'timeout|refused' -f
+  saturn app logs <uuid> --log-fields user_id,http.status`,
+		Args: cli.ExactArgs(1, "<uuid>"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			uuid := args[0]
@@ -33,48 +63,108 @@ func NewLogsCommand() *cobra.Command {
 			follow, _ := cmd.Flags().GetBool("follow")
 			appSvc := service.NewApplicationService(client)
 
+			// JSON and logfmt lines are rendered unless --raw is set. With --format ndjson each
+			// line is emitted as a log event carrying its normalised fields.
+			format, _ := cmd.Flags().GetString("format")
+			out := cmd.OutOrStdout()
+			renderer := cli.NewLogRenderer(cmd, out)
+			printLogs := func(logs string) {
+				if renderer == nil || logs == "" {
+					fmt.Fprint(out, logs)
+					return
+				}
+				for _, line := range strings.Split(strings.TrimSuffix(logs, "\n"), "\n") {
+					fmt.Fprintln(out, renderer.Text(line))
+				}
+			}
+			if format == output.FormatNDJSON {
+				events := output.NewEventWriter(out)
+				printLogs = func(logs string) {
+					for _, line := range strings.Split(strings.TrimRight(logs, "\n"), "\n") {
+						if line == "" {
+							continue
+						}
+						event := output.Event{Type: output.EventLog, Resource: uuid, Message: line}
+						if renderer != nil {
+							event.Data = renderer.Normalize(line)
+						}
+						_ = events.Emit(event)
+					}
+				}
+			}
+
+			// Filters are applied locally, so look further back when they are in use
+			filter, err := cli.NewLogFilter(cmd)
+			if err != nil {
+				return err
+			}
+			if filter.Active() {
+				if !cmd.Flags().Changed("lines") {
+					lines = filteredLogLines
+				}
+				printUnfiltered := printLogs
+				warned := false
+				printLogs = func(logs string) {
+					if logs = filter.Lines(logs); logs != "" {
+						printUnfiltered(logs)
+					}
+					if filter.Undated() && !warned {
+						cli.WarnUndatedLogs(cmd.ErrOrStderr())
+						warned = true
+					}
+				}
+			}
+
+			// Secrets are masked before anything else sees the lines
+			masker, err := cli.NewLogMasker(ctx, cmd, out, func(ctx context.Context) ([]string, error) {
+				return service.NewSecretService(client).ResourceSecrets(ctx, []models.EnvironmentResource{{UUID: uuid, Type: models.ResourceTypeApplication}})
+			})
+			if err != nil {
+				return err
+			}
+			if masker != nil {
+				printUnmasked := printLogs
+				printLogs = func(logs string) {
+					printUnmasked(masker.Mask(logs))
+				}
+			}
+
 			if !follow {
 				resp, err := appSvc.Logs(ctx, uuid, lines)
 				if err != nil {
 					return fmt.Errorf("failed to get logs: %w", err)
 				}
-				fmt.Print(resp.Logs)
+				printLogs(resp.Logs)
 				return nil
 			}
 
-			ticker := time.NewTicker(2 * time.Second)
-			defer ticker.Stop()
-
-			sigChan := make(chan os.Signal, 1)
-			signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-
-			lastLogs := ""
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
 
 			resp, err := appSvc.Logs(ctx, uuid, lines)
 			if err != nil {
 				return fmt.Errorf("failed to get logs: %w", err)
 			}
-			fmt.Print(resp.Logs)
-			lastLogs = resp.Logs
+			printLogs(resp.Logs)
+			lastLogs := resp.Logs
+
+			ticker := time.NewTicker(logPollInterval)
+			defer ticker.Stop()
 
 			for {
 				select {
-				case <-sigChan:
-					fmt.Println("\nStopping log follow...")
+				case <-ctx.Done():
+					fmt.Fprintln(cmd.ErrOrStderr(), "\nStopping log follow...")
 					return nil
 				case <-ticker.C:
 					resp, err := appSvc.Logs(ctx, uuid, lines)
 					if err != nil {
 						continue
 					}
-					if resp.Logs != lastLogs {
-						if len(resp.Logs) > len(lastLogs) && strings.HasPrefix(resp.Logs, lastLogs) {
-							fmt.Print(resp.Logs[len(lastLogs):])
-						} else {
-							fmt.Print(resp.Logs)
-						}
-						lastLogs = resp.Logs
+					if fresh := service.NewLogLines(lastLogs, resp.Logs); fresh != "" {
+						printLogs(fresh)
 					}
+					lastLogs = resp.Logs
 				}
 			}
 		},
@@ -82,5 +172,8 @@ func NewLogsCommand() *cobra.Command {
 
 	cmd.Flags().IntP("lines", "n", 100, "Number of log lines to retrieve")
 	cmd.Flags().BoolP("follow", "f", false, "Follow log output (like tail -f)")
+	cli.AddLogFilterFlags(cmd)
+	cli.AddLogRenderFlags(cmd)
+	cli.AddLogMaskFlags(cmd)
 	return cmd
 }
@@ -10,7 +10,7 @@ import (
 )
 
 func NewRestartCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "restart <uuid>",
 		Short: "Restart an application",
 		Long:  `Restart a running application.`,
@@ -19,6 +19,11 @@ func NewRestartCommand() *cobra.Command {
 			ctx := cmd.Context()
 			uuid := args[0]
 
+			until, err := cli.ResourceWaitUntil(cmd)
+			if err != nil {
+				return err
+			}
+
 			client, err := cli.GetAPIClient(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to get API client: %w", err)
@@ -31,7 +36,13 @@ func NewRestartCommand() *cobra.Command {
 			}
 
 			fmt.Println(resp.Message)
+			if cli.ResourceWaitRequested(cmd) {
+				return waitForApplication(cmd, client, uuid, until, resp.DeploymentUUID)
+			}
 			return nil
 		},
 	}
+
+	cli.AddResourceWaitFlags(cmd, service.ResourceRunningHealthy)
+	return cmd
 }
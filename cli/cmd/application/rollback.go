@@ -10,16 +10,17 @@ import (
 	"github.com/saturn-platform/saturn-cli/internal/service"
 )
 
-// NewRollbackCommand creates the rollback parent command with list and execute subcommands
+// NewRollbackCommand creates the rollback parent command with list, execute and to subcommands
 func NewRollbackCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "rollback",
 		Short: "Manage application rollbacks",
-		Long:  `List rollback events and execute rollbacks to previous deployments.`,
+		Long:  `List rollback events and roll applications back to previous deployments.`,
 	}
 
 	cmd.AddCommand(newRollbackListCommand())
 	cmd.AddCommand(newRollbackExecuteCommand())
+	cmd.AddCommand(newRollbackToCommand())
 
 	return cmd
 }
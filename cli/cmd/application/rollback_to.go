@@ -0,0 +1,229 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/cmd/deployment"
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+	"github.com/saturn-platform/saturn-cli/internal/tui"
+)
+
+// rollbackHistoryTake is how many rollback events are shown once a rollback completes
+const rollbackHistoryTake = 5
+
+func newRollbackToCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "to <app>",
+		Short: "Roll an application back to a previous successful deployment",
+		Long: `Roll an application, given by name or UUID, back to one of its previous successful deployments.
+
+Use --previous for the last successful deployment before the current one, or --commit with
+a commit SHA prefix. Without either, the previous deployments are listed to pick from.
+The command waits for the rollback deployment like deploy --wait, with the same flags and
+exit codes, and then shows the rollback history. With --format json or ndjson the prompt
+and progress go to stderr, so stdout only carries the history.`,
+		Example: `  saturn app rollback to my-app --previous
+  saturn app rollback to my-app --commit 3f2a9c1
+  saturn app rollback to my-app`,
+		Args: cli.ExactArgs(1, "<app>"),
+		RunE: runRollbackTo,
+	}
+
+	cmd.Flags().Bool("previous", false, "Roll back to the last successful deployment before the current one")
+	cmd.Flags().String("commit", "", "Roll back to the most recent successful deployment of this commit (SHA prefix)")
+	cmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	deployment.AddWaitFlags(cmd)
+	// A rollback is always waited for
+	wait := cmd.Flags().Lookup("wait")
+	_ = wait.Value.Set("true")
+	wait.DefValue = "true"
+	_ = cmd.Flags().MarkHidden("wait")
+	// Rolling back a failed rollback would redeploy the release being left
+	_ = cmd.Flags().MarkHidden("rollback-on-failure")
+	cmd.MarkFlagsMutuallyExclusive("previous", "commit")
+	return cmd
+}
+
+func runRollbackTo(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	stdout := cmd.OutOrStdout()
+	format, _ := cmd.Flags().GetString("format")
+	// Prompts and progress stay off stdout when it carries JSON
+	out := stdout
+	if format != output.FormatTable {
+		out = cmd.ErrOrStderr()
+	}
+
+	if cmd.Flags().Changed("rollback-on-failure") {
+		return fmt.Errorf("--rollback-on-failure does not apply to a rollback")
+	}
+	commit, _ := cmd.Flags().GetString("commit")
+	if cmd.Flags().Changed("commit") && len(commit) < 4 {
+		return fmt.Errorf("--commit needs at least 4 characters of the commit SHA")
+	}
+
+	client, err := cli.GetAPIClient(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get API client: %w", err)
+	}
+
+	app, err := service.NewApplicationService(client).Find(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	deploySvc := service.NewDeploymentService(client)
+	var target *models.Deployment
+	if previous, _ := cmd.Flags().GetBool("previous"); previous {
+		target, err = deploySvc.FindRollbackTarget(ctx, app.UUID)
+	} else if commit != "" {
+		target, err = deploySvc.FindRollbackTargetByCommit(ctx, app.UUID, commit)
+	} else {
+		target, err = pickRollbackTarget(ctx, deploySvc, app)
+	}
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		fmt.Fprintln(out, "Rollback aborted.")
+		return nil
+	}
+
+	fmt.Fprintf(out, "Rolling back %s to deployment %s\n", app.Name, target.UUID)
+	fmt.Fprintf(out, "  %s\n", rollbackChoiceLabel(*target, time.Now()))
+
+	if force, _ := cmd.Flags().GetBool("force"); !force {
+		fmt.Fprint(out, "\nProceed with rollback? [y/N] ")
+		var answer string
+		if _, err := fmt.Fscanln(cmd.InOrStdin(), &answer); err != nil {
+			answer = ""
+		}
+		if !strings.HasPrefix(strings.ToLower(answer), "y") {
+			fmt.Fprintln(out, "Rollback aborted.")
+			return nil
+		}
+	}
+
+	result, err := deploySvc.ExecuteRollback(ctx, app.UUID, target.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute rollback: %w", err)
+	}
+	fmt.Fprintln(out, result.Message)
+
+	waitErr := waitForRollback(cmd, deploySvc, app, result.DeploymentUUID, out)
+	if waitErr == nil && result.DeploymentUUID != "" {
+		fmt.Fprintln(out, "Rollback completed.")
+	}
+
+	events, err := deploySvc.GetRollbackEvents(ctx, app.UUID, rollbackHistoryTake)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+	} else if len(events) > 0 {
+		fmt.Fprintln(out, "\nRollback history:")
+		formatter, err := output.NewFormatter(format, output.Options{Writer: stdout})
+		if err != nil {
+			return fmt.Errorf("failed to create formatter: %w", err)
+		}
+		if err := formatter.Format(events); err != nil {
+			return err
+		}
+	}
+	return waitErr
+}
+
+// waitForRollback waits for the deployment started by a rollback through deploy --wait,
+// so pending approvals pause the timeout, --format ndjson emits events, --report and the
+// hooks apply, and errors carry the same exit codes. Progress lines are written to out.
+func waitForRollback(cmd *cobra.Command, deploySvc *service.DeploymentService, app *models.Application, deploymentUUID string, out io.Writer) error {
+	if deploymentUUID == "" {
+		return nil
+	}
+	format, _ := cmd.Flags().GetString("format")
+	if format != output.FormatNDJSON {
+		stdout := cmd.OutOrStdout()
+		cmd.SetOut(out)
+		defer cmd.SetOut(stdout)
+	}
+	return deployment.HandleWait(cmd, deploySvc, []deployment.WaitTarget{
+		{DeploymentUUID: deploymentUUID, Resource: app.Name, ResourceUUID: app.UUID},
+	})
+}
+
+// pickRollbackTarget lets the user choose among the previous successful deployments of app.
+// It returns nil when the user cancels.
+func pickRollbackTarget(ctx context.Context, deploySvc *service.DeploymentService, app *models.Application) (*models.Deployment, error) {
+	candidates, err := deploySvc.RollbackCandidates(ctx, app.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no previous successful deployment found for application %s", app.Name)
+	}
+
+	now := time.Now()
+	items := make([]string, len(candidates))
+	for i, d := range candidates {
+		items[i] = rollbackChoiceLabel(d, now)
+	}
+
+	term, err := tui.OpenTerminal(os.Stdin, os.Stdout)
+	if errors.Is(err, tui.ErrNotTerminal) {
+		return nil, fmt.Errorf("no deployment selected: use --previous or --commit when not running in a terminal")
+	}
+	if err != nil {
+		return nil, err
+	}
+	index, ok, err := tui.RunPicker(tui.NewPicker("Roll back "+app.Name+" to", items), term)
+	_ = term.Close()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &candidates[index], nil
+}
+
+// rollbackChoiceLabel describes a deployment by commit, age and the first line of its commit message
+func rollbackChoiceLabel(d models.Deployment, now time.Time) string {
+	commit := "-------"
+	if d.Commit != nil && *d.Commit != "" {
+		commit = models.ShortSHA(*d.Commit)
+	}
+
+	age := "-"
+	if created, ok := d.CreatedTime(); ok {
+		age = formatAge(now.Sub(created))
+	}
+
+	message := ""
+	if d.CommitMessage != nil {
+		message, _, _ = strings.Cut(strings.TrimSpace(*d.CommitMessage), "\n")
+	}
+	return fmt.Sprintf("%-7s  %8s  %s", commit, age, message)
+}
+
+// formatAge renders a duration as a short relative age such as "5m ago" or "3d ago"
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
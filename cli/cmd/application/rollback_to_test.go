@@ -0,0 +1,126 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestRollbackChoiceLabel(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	d := models.Deployment{
+		Commit:        strPtr("3f2a9c1e5b7d"),
+		CommitMessage: strPtr("Fix checkout totals\n\nRounding was off by one cent."),
+		CreatedAt:     strPtr("2026-03-08T09:00:00Z"),
+	}
+	assert.Equal(t, "3f2a9c1    2d ago  Fix checkout totals", rollbackChoiceLabel(d, now))
+
+	assert.Equal(t, "-------         -  ", rollbackChoiceLabel(models.Deployment{}, now))
+}
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "just now", formatAge(30*time.Second))
+	assert.Equal(t, "5m ago", formatAge(5*time.Minute))
+	assert.Equal(t, "3h ago", formatAge(3*time.Hour+20*time.Minute))
+	assert.Equal(t, "2d ago", formatAge(50*time.Hour))
+}
+
+func TestWaitForRollback_ExitCodes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/deployments/dep-finished":
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-finished","status":"finished"}`))
+		case "/api/v1/deployments/dep-failed":
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-failed","status":"failed"}`))
+		case "/api/v1/deployments/dep-cancelled":
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-cancelled","status":"cancelled-by-user"}`))
+		default:
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-slow","status":"in_progress"}`))
+		}
+	}))
+	defer server.Close()
+	deploySvc := service.NewDeploymentService(api.NewClient(server.URL, "test-token"))
+	app := &models.Application{UUID: "app-1", Name: "api"}
+
+	newCmd := func() *cobra.Command {
+		cmd := newRollbackToCommand()
+		cmd.Flags().String("format", output.FormatTable, "")
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		cmd.SetContext(context.Background())
+		_ = cmd.Flags().Set("timeout", "1")
+		_ = cmd.Flags().Set("poll-interval", "1")
+		return cmd
+	}
+	wait := func(deploymentUUID string) error {
+		cmd := newCmd()
+		return waitForRollback(cmd, deploySvc, app, deploymentUUID, cmd.OutOrStdout())
+	}
+
+	assert.NoError(t, wait("dep-finished"))
+
+	err := wait("dep-failed")
+	assert.Error(t, err)
+	assert.Equal(t, 1, cli.ExitCode(err))
+
+	err = wait("dep-cancelled")
+	assert.Equal(t, 1, cli.ExitCode(err))
+
+	err = wait("dep-slow")
+	assert.Contains(t, err.Error(), "wait timeout exceeded (1s)")
+	assert.Equal(t, cli.ExitCodeWaitTimeout, cli.ExitCode(err))
+}
+
+func TestWaitForRollback_JSONKeepsProgressOffStdout(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"deployment_uuid":"dep-1","status":"finished"}`))
+	}))
+	defer server.Close()
+	deploySvc := service.NewDeploymentService(api.NewClient(server.URL, "test-token"))
+
+	cmd := newRollbackToCommand()
+	cmd.Flags().String("format", output.FormatJSON, "")
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetContext(context.Background())
+	_ = cmd.Flags().Set("poll-interval", "1")
+
+	err := waitForRollback(cmd, deploySvc, &models.Application{UUID: "app-1", Name: "api"}, "dep-1", cmd.ErrOrStderr())
+	assert.NoError(t, err)
+	assert.Empty(t, stdout.String())
+	assert.Contains(t, stderr.String(), "Waiting for 1 deployment(s)")
+	assert.Equal(t, &stdout, cmd.OutOrStdout())
+}
+
+func TestRollbackTo_RejectsRollbackOnFailure(t *testing.T) {
+	cmd := newRollbackToCommand()
+	cmd.SetArgs([]string{"my-app", "--previous", "--rollback-on-failure"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--rollback-on-failure does not apply")
+}
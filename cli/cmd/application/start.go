@@ -20,6 +20,11 @@ func NewStartCommand() *cobra.Command {
 			ctx := cmd.Context()
 			uuid := args[0]
 
+			until, err := cli.ResourceWaitUntil(cmd)
+			if err != nil {
+				return err
+			}
+
 			client, err := cli.GetAPIClient(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to get API client: %w", err)
@@ -38,11 +43,15 @@ func NewStartCommand() *cobra.Command {
 			if resp.DeploymentUUID != nil && *resp.DeploymentUUID != "" {
 				fmt.Printf("Deployment UUID: %s\n", *resp.DeploymentUUID)
 			}
+			if cli.ResourceWaitRequested(cmd) {
+				return waitForApplication(cmd, client, uuid, until, resp.DeploymentUUID)
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().Bool("force", false, "Force rebuild")
 	cmd.Flags().Bool("instant-deploy", false, "Instant deploy (skip queuing)")
+	cli.AddResourceWaitFlags(cmd, service.ResourceRunningHealthy)
 	return cmd
 }
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -10,7 +11,7 @@ import (
 )
 
 func NewStopCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "stop <uuid>",
 		Short: "Stop an application",
 		Long:  `Stop a running application.`,
@@ -19,6 +20,11 @@ func NewStopCommand() *cobra.Command {
 			ctx := cmd.Context()
 			uuid := args[0]
 
+			until, err := cli.ResourceWaitUntil(cmd)
+			if err != nil {
+				return err
+			}
+
 			client, err := cli.GetAPIClient(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to get API client: %w", err)
@@ -31,7 +37,15 @@ func NewStopCommand() *cobra.Command {
 			}
 
 			fmt.Println(resp.Message)
+			if cli.ResourceWaitRequested(cmd) {
+				return cli.WaitForResource(cmd, "application", uuid, until, func(ctx context.Context) (service.ResourceStatus, error) {
+					return appSvc.Status(ctx, uuid)
+				}, 0)
+			}
 			return nil
 		},
 	}
+
+	cli.AddResourceWaitFlags(cmd, service.ResourceExited)
+	return cmd
 }
@@ -49,6 +49,11 @@ func NewUpdateCommand() *cobra.Command {
 				req.GitRepository = &repo
 				hasUpdates = true
 			}
+			if cmd.Flags().Changed("git-commit-sha") {
+				sha, _ := cmd.Flags().GetString("git-commit-sha")
+				req.GitCommitSHA = &sha
+				hasUpdates = true
+			}
 			if cmd.Flags().Changed("domains") {
 				domains, _ := cmd.Flags().GetString("domains")
 				req.Domains = &domains
@@ -143,6 +148,7 @@ func NewUpdateCommand() *cobra.Command {
 	cmd.Flags().String("description", "", "Application description")
 	cmd.Flags().String("git-branch", "", "Git branch")
 	cmd.Flags().String("git-repository", "", "Git repository URL")
+	cmd.Flags().String("git-commit-sha", "", "Git commit SHA to deploy (HEAD for the latest commit of the branch)")
 	cmd.Flags().String("domains", "", "Domains (comma-separated)")
 	cmd.Flags().String("build-command", "", "Build command")
 	cmd.Flags().String("start-command", "", "Start command")
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// waitForApplication waits for the deployment a start or restart queued, when there is
+// one, and then for the application to reach until, all within --timeout
+func waitForApplication(cmd *cobra.Command, client *api.Client, uuid, until string, deploymentUUID *string) error {
+	ctx, cancel, timeoutSec := cli.ResourceWaitContext(cmd)
+	defer cancel()
+
+	settle := time.Duration(0)
+	if deploymentUUID != nil && *deploymentUUID != "" {
+		if err := waitForDeployment(ctx, cmd, service.NewDeploymentService(client), *deploymentUUID, timeoutSec); err != nil {
+			return err
+		}
+	} else {
+		// Without a deployment to follow, the old status stays until the action is picked up
+		settle = cli.RestartSettle
+	}
+
+	appSvc := service.NewApplicationService(client)
+	return cli.WaitForResourceContext(ctx, cmd, "application", uuid, until, timeoutSec, func(ctx context.Context) (service.ResourceStatus, error) {
+		return appSvc.Status(ctx, uuid)
+	}, settle)
+}
+
+// waitForDeployment waits for a deployment queued by a lifecycle command to finish
+func waitForDeployment(ctx context.Context, cmd *cobra.Command, deploySvc *service.DeploymentService, deploymentUUID string, timeoutSec int) error {
+	out := cmd.OutOrStdout()
+	pollSec, _ := cmd.Flags().GetInt("poll-interval")
+
+	fmt.Fprintf(out, "Waiting for deployment %s (timeout: %ds)...\n", deploymentUUID, timeoutSec)
+	lastStatus := ""
+	result, err := deploySvc.WaitForCompletion(ctx, deploymentUUID, time.Duration(pollSec)*time.Second, func(_, status string) {
+		if status != lastStatus {
+			lastStatus = status
+			fmt.Fprintf(out, "  [%s] %s\n", deploymentUUID, status)
+		}
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		return cli.WithExitCode(fmt.Errorf("wait timeout exceeded (%ds) waiting for deployment %s", timeoutSec, deploymentUUID), cli.ExitCodeWaitTimeout)
+	}
+	if err != nil {
+		return err
+	}
+	if !result.Finished {
+		return fmt.Errorf("deployment %s ended with status %s", deploymentUUID, result.Status)
+	}
+	return nil
+}
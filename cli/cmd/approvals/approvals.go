@@ -0,0 +1,25 @@
+package approvals
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewApprovalsCommand creates the approvals parent command
+func NewApprovalsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "approvals",
+		Aliases: []string{"approval"},
+		Short:   "Deployment approval commands",
+		Long: `Review deployments to protected environments. Such deployments wait with status
+pending_approval until an owner or admin of the project or team approves them;
+rejecting one cancels the deployment.`,
+	}
+
+	cmd.AddCommand(NewListCommand())
+	cmd.AddCommand(NewStatusCommand())
+	cmd.AddCommand(NewRequestCommand())
+	cmd.AddCommand(NewApproveCommand())
+	cmd.AddCommand(NewRejectCommand())
+
+	return cmd
+}
@@ -0,0 +1,95 @@
+package approvals
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// NewRequestCommand requests approval of a deployment
+func NewRequestCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "request <deployment_uuid>",
+		Short: "Request approval of a deployment",
+		Long:  `Ask the owners and admins of the project to approve a deployment.`,
+		Args:  cli.ExactArgs(1, "<deployment_uuid>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cli.GetAPIClient(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to get API client: %w", err)
+			}
+
+			result, err := service.NewApprovalService(client).Request(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return formatResult(cmd, result)
+		},
+	}
+}
+
+// NewApproveCommand approves a deployment
+func NewApproveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approve <deployment_uuid>",
+		Short: "Approve a deployment",
+		Long:  `Approve a deployment waiting for approval, which lets it start. Requires owner or admin rights on the project or team.`,
+		Args:  cli.ExactArgs(1, "<deployment_uuid>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cli.GetAPIClient(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to get API client: %w", err)
+			}
+
+			comment, _ := cmd.Flags().GetString("comment")
+			result, err := service.NewApprovalService(client).Approve(cmd.Context(), args[0], comment)
+			if err != nil {
+				return err
+			}
+			return formatResult(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringP("comment", "m", "", "Comment to record with the approval")
+	return cmd
+}
+
+// NewRejectCommand rejects a deployment
+func NewRejectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reject <deployment_uuid>",
+		Short: "Reject a deployment",
+		Long:  `Reject a deployment waiting for approval. The deployment is cancelled. Requires owner or admin rights on the project or team.`,
+		Args:  cli.ExactArgs(1, "<deployment_uuid>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cli.GetAPIClient(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to get API client: %w", err)
+			}
+
+			reason, _ := cmd.Flags().GetString("reason")
+			result, err := service.NewApprovalService(client).Reject(cmd.Context(), args[0], reason)
+			if err != nil {
+				return err
+			}
+			return formatResult(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringP("reason", "r", "", "Reason for the rejection, shown to the requester")
+	return cmd
+}
+
+func formatResult(cmd *cobra.Command, result *models.ApprovalResponse) error {
+	format, _ := cmd.Flags().GetString("format")
+	formatter, err := output.NewFormatter(format, output.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to create formatter: %w", err)
+	}
+	return formatter.Format(result)
+}
@@ -0,0 +1,74 @@
+package approvals
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// NewListCommand lists pending approvals
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List deployments waiting for approval",
+		Long:  `List the deployments waiting for an approval you can give, optionally limited to one project.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			client, err := cli.GetAPIClient(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to get API client: %w", err)
+			}
+
+			approvalSvc := service.NewApprovalService(client)
+			var approvals []models.DeploymentApproval
+			if project, _ := cmd.Flags().GetString("project"); project != "" {
+				projectUUID, err := resolveProject(ctx, client, project)
+				if err != nil {
+					return err
+				}
+				approvals, err = approvalSvc.ListPendingForProject(ctx, projectUUID)
+				if err != nil {
+					return err
+				}
+			} else {
+				approvals, err = approvalSvc.ListPending(ctx)
+				if err != nil {
+					return err
+				}
+			}
+
+			format, _ := cmd.Flags().GetString("format")
+			formatter, err := output.NewFormatter(format, output.Options{})
+			if err != nil {
+				return fmt.Errorf("failed to create formatter: %w", err)
+			}
+
+			return formatter.Format(approvals)
+		},
+	}
+
+	cmd.Flags().String("project", "", "Only list approvals of this project (name or UUID)")
+	return cmd
+}
+
+// resolveProject returns the UUID of a project given by name or UUID
+func resolveProject(ctx context.Context, client *api.Client, ref string) (string, error) {
+	projects, err := service.NewProjectService(client).List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list projects: %w", err)
+	}
+	for _, p := range projects {
+		if p.UUID == ref || p.Name == ref {
+			return p.UUID, nil
+		}
+	}
+	return "", fmt.Errorf("project '%s' not found", ref)
+}
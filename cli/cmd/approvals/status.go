@@ -0,0 +1,42 @@
+package approvals
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// NewStatusCommand shows the approval of a deployment
+func NewStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <deployment_uuid>",
+		Short: "Show the approval status of a deployment",
+		Long:  `Show who requested approval of a deployment, whether it was approved or rejected, by whom and with which comment.`,
+		Args:  cli.ExactArgs(1, "<deployment_uuid>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			client, err := cli.GetAPIClient(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to get API client: %w", err)
+			}
+
+			approval, err := service.NewApprovalService(client).Status(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			format, _ := cmd.Flags().GetString("format")
+			formatter, err := output.NewFormatter(format, output.Options{})
+			if err != nil {
+				return fmt.Errorf("failed to create formatter: %w", err)
+			}
+
+			return formatter.Format(approval)
+		},
+	}
+}
@@ -0,0 +1,167 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+	"github.com/saturn-platform/saturn-cli/internal/tui"
+)
+
+// logLines is the number of log lines fetched for the log pane
+const logLines = 200
+
+// apiBackend feeds the dashboard from the Saturn API
+type apiBackend struct {
+	projectSvc  *service.ProjectService
+	deploySvc   *service.DeploymentService
+	appSvc      *service.ApplicationService
+	serviceSvc  *service.Service
+	databaseSvc *service.DatabaseService
+}
+
+func newAPIBackend(client *api.Client) *apiBackend {
+	return &apiBackend{
+		projectSvc:  service.NewProjectService(client),
+		deploySvc:   service.NewDeploymentService(client),
+		appSvc:      service.NewApplicationService(client),
+		serviceSvc:  service.NewService(client),
+		databaseSvc: service.NewDatabaseService(client),
+	}
+}
+
+func (b *apiBackend) Projects(ctx context.Context) ([]models.Project, error) {
+	return b.projectSvc.List(ctx)
+}
+
+func (b *apiBackend) Project(ctx context.Context, uuid string) (*models.Project, error) {
+	return b.projectSvc.Get(ctx, uuid)
+}
+
+func (b *apiBackend) Environment(ctx context.Context, projectUUID, environmentUUID string) (*models.EnvironmentResources, error) {
+	return b.projectSvc.GetEnvironment(ctx, projectUUID, environmentUUID)
+}
+
+func (b *apiBackend) Deployments(ctx context.Context) ([]models.Deployment, error) {
+	return b.deploySvc.List(ctx)
+}
+
+func (b *apiBackend) Logs(ctx context.Context, res models.EnvironmentResource) (string, error) {
+	switch res.Type {
+	case models.ResourceTypeApplication:
+		resp, err := b.appSvc.Logs(ctx, res.UUID, logLines)
+		if err != nil {
+			return "", err
+		}
+		return resp.Logs, nil
+	case models.ResourceTypeDatabase:
+		resp, err := b.databaseSvc.Logs(ctx, res.UUID, logLines)
+		if err != nil {
+			return "", err
+		}
+		return resp.Logs, nil
+	case models.ResourceTypeService:
+		resp, err := b.serviceSvc.Logs(ctx, res.UUID, logLines, "")
+		if err != nil {
+			return "", err
+		}
+		return joinServiceLogs(resp), nil
+	}
+	return "", fmt.Errorf("logs are not available for %s", res.Name)
+}
+
+func (b *apiBackend) Run(ctx context.Context, action tui.Action, res models.EnvironmentResource) (string, error) {
+	switch action {
+	case tui.ActionDeploy:
+		resp, err := b.deploySvc.Deploy(ctx, res.UUID, false)
+		if err != nil {
+			return "", err
+		}
+		if len(resp.Deployments) > 0 {
+			return resp.Deployments[0].Message, nil
+		}
+		return "", nil
+	case tui.ActionRollback:
+		target, err := b.deploySvc.FindRollbackTarget(ctx, res.UUID)
+		if err != nil {
+			return "", err
+		}
+		resp, err := b.deploySvc.ExecuteRollback(ctx, res.UUID, target.UUID)
+		if err != nil {
+			return "", err
+		}
+		return resp.Message, nil
+	case tui.ActionRestart, tui.ActionStop:
+		return b.lifecycle(ctx, action, res)
+	}
+	return "", fmt.Errorf("unknown action %s", action)
+}
+
+// lifecycle restarts or stops an application, service or database
+func (b *apiBackend) lifecycle(ctx context.Context, action tui.Action, res models.EnvironmentResource) (string, error) {
+	restart := action == tui.ActionRestart
+
+	switch res.Type {
+	case models.ResourceTypeApplication:
+		var resp *models.ApplicationLifecycleResponse
+		var err error
+		if restart {
+			resp, err = b.appSvc.Restart(ctx, res.UUID)
+		} else {
+			resp, err = b.appSvc.Stop(ctx, res.UUID)
+		}
+		if err != nil {
+			return "", err
+		}
+		return resp.Message, nil
+	case models.ResourceTypeService:
+		var resp *models.ServiceLifecycleResponse
+		var err error
+		if restart {
+			resp, err = b.serviceSvc.Restart(ctx, res.UUID)
+		} else {
+			resp, err = b.serviceSvc.Stop(ctx, res.UUID)
+		}
+		if err != nil {
+			return "", err
+		}
+		return resp.Message, nil
+	case models.ResourceTypeDatabase:
+		var resp *models.DatabaseLifecycleResponse
+		var err error
+		if restart {
+			resp, err = b.databaseSvc.Restart(ctx, res.UUID)
+		} else {
+			resp, err = b.databaseSvc.Stop(ctx, res.UUID)
+		}
+		if err != nil {
+			return "", err
+		}
+		return resp.Message, nil
+	}
+	return "", fmt.Errorf("cannot %s %s", action, res.Name)
+}
+
+// joinServiceLogs combines the logs of all service containers, each under a header line
+func joinServiceLogs(resp *models.ServiceLogsResponse) string {
+	names := make([]string, 0, len(resp.Containers))
+	for name := range resp.Containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		c := resp.Containers[name]
+		fmt.Fprintf(&b, "==> %s (%s) <==\n", c.Name, c.Status)
+		if c.Logs != nil {
+			b.WriteString(strings.TrimRight(*c.Logs, "\n"))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package dashboard
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/config"
+	"github.com/saturn-platform/saturn-cli/internal/tui"
+)
+
+// NewDashboardCommand creates the interactive dashboard command
+func NewDashboardCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "dashboard",
+		Aliases: []string{"ui"},
+		Short:   "Interactive terminal dashboard",
+		Long: `Open a full-screen dashboard for the current context.
+
+The left pane lists projects, environments and their resources. The right pane
+shows deployments in progress and the logs of the selected resource.
+
+Key bindings:
+  ↑/↓ or j/k     Move the selection
+  Enter or →     Expand a project/environment, or show the logs of a resource
+  ← or h         Collapse, or go back to the parent
+  d              Deploy the selected application
+  r              Restart the selected resource
+  s              Stop the selected resource
+  b              Roll the selected application back to its previous deployment
+  PgUp/PgDn      Scroll the logs
+  R              Refresh now
+  q or Ctrl+C    Quit
+
+Deploy, restart, stop and rollback ask for confirmation first.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := cli.GetAPIClient(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to get API client: %w", err)
+			}
+
+			refresh, _ := cmd.Flags().GetDuration("refresh")
+			if refresh < tui.MinRefreshInterval {
+				return fmt.Errorf("--refresh must be at least %s", tui.MinRefreshInterval)
+			}
+
+			term, err := tui.OpenTerminal(os.Stdin, os.Stdout)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = term.Close() }()
+
+			dashboard := tui.NewDashboard(newAPIBackend(client), contextTitle(cmd))
+			return tui.Run(cmd.Context(), dashboard, term, refresh)
+		},
+	}
+
+	cmd.Flags().Duration("refresh", tui.DefaultRefreshInterval, "How often live data is refreshed")
+	return cmd
+}
+
+// contextTitle returns the name of the context the dashboard is connected to
+func contextTitle(cmd *cobra.Command) string {
+	if name, _ := cmd.Flags().GetString("context"); name != "" {
+		return name
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return ""
+	}
+	instance, err := cfg.GetDefault()
+	if err != nil {
+		return ""
+	}
+	return instance.Name
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -12,30 +13,27 @@ import (
 
 // NewListCommand lists all databases
 func NewListCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all databases",
-		Long:  `List all databases in Saturn.`,
+		Long:  `List all databases in Saturn. Use --watch to keep the list refreshed.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			ctx := cmd.Context()
-
 			client, err := cli.GetAPIClient(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to get API client: %w", err)
 			}
 
 			dbService := service.NewDatabaseService(client)
-			databases, err := dbService.List(ctx)
-			if err != nil {
-				return fmt.Errorf("failed to list databases: %w", err)
-			}
-
-			formatter, err := output.NewFormatter("table", output.Options{})
-			if err != nil {
-				return fmt.Errorf("failed to create formatter: %w", err)
-			}
-
-			return formatter.Format(databases)
+			return cli.RunWithWatch(cmd, output.Options{}, func(ctx context.Context) (any, error) {
+				databases, err := dbService.List(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list databases: %w", err)
+				}
+				return databases, nil
+			})
 		},
 	}
+
+	cli.AddWatchFlags(cmd)
+	return cmd
 }
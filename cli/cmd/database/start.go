@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -11,7 +12,7 @@ import (
 
 // NewStartCommand starts a database
 func NewStartCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "start <uuid>",
 		Short: "Start a database",
 		Long:  `Start a database by UUID.`,
@@ -20,6 +21,11 @@ func NewStartCommand() *cobra.Command {
 			ctx := cmd.Context()
 			uuid := args[0]
 
+			until, err := cli.ResourceWaitUntil(cmd)
+			if err != nil {
+				return err
+			}
+
 			client, err := cli.GetAPIClient(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to get API client: %w", err)
@@ -32,7 +38,15 @@ func NewStartCommand() *cobra.Command {
 			}
 
 			fmt.Println(response.Message)
+			if cli.ResourceWaitRequested(cmd) {
+				return cli.WaitForResource(cmd, "database", uuid, until, func(ctx context.Context) (service.ResourceStatus, error) {
+					return dbService.Status(ctx, uuid)
+				}, 0)
+			}
 			return nil
 		},
 	}
+
+	cli.AddResourceWaitFlags(cmd, service.ResourceRunningHealthy)
+	return cmd
 }
@@ -0,0 +1,171 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// NewAnalyzeCommand runs an AI analysis of a failed deployment
+func NewAnalyzeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "analyze <deployment_uuid>",
+		Short: "Explain why a deployment failed using AI analysis",
+		Long: `Analyze the build log of a failed deployment with the AI provider configured on the
+Saturn instance and print the root cause, the suggested solution and how to prevent
+it. An existing analysis is shown as is; pass --reanalyze to request a new one.`,
+		Args: cli.ExactArgs(1, "<deployment_uuid>"),
+		RunE: runAnalyze,
+	}
+
+	cmd.Flags().Bool("reanalyze", false, "Request a new analysis even if one exists")
+	addAnalysisWaitFlags(cmd)
+	return cmd
+}
+
+// addAnalysisWaitFlags adds the flags bounding the wait for an analysis or code review
+func addAnalysisWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("timeout", 300, "Timeout in seconds when waiting for the result")
+	cmd.Flags().Int("poll-interval", 3, "Poll interval in seconds when waiting for the result")
+}
+
+// analysisWaitContext bounds ctx by --timeout and returns the --poll-interval
+func analysisWaitContext(cmd *cobra.Command) (context.Context, context.CancelFunc, time.Duration) {
+	timeoutSec, _ := cmd.Flags().GetInt("timeout")
+	pollSec, _ := cmd.Flags().GetInt("poll-interval")
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Duration(timeoutSec)*time.Second)
+	return ctx, cancel, time.Duration(pollSec) * time.Second
+}
+
+func runAnalyze(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	deploymentUUID := args[0]
+
+	client, err := cli.GetAPIClient(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get API client: %w", err)
+	}
+
+	deployment, err := service.NewDeploymentService(client).Get(ctx, deploymentUUID)
+	if err != nil {
+		return err
+	}
+	if deployment.Status != "failed" {
+		return fmt.Errorf("deployment %s has status %s; only failed deployments can be analyzed", deploymentUUID, deployment.Status)
+	}
+
+	analysisSvc := service.NewAnalysisService(client)
+	existing, err := analysisSvc.Get(ctx, deploymentUUID)
+	if err != nil {
+		return err
+	}
+
+	reanalyze, _ := cmd.Flags().GetBool("reanalyze")
+	analysis := existing.Analysis
+	var previous *models.DeploymentAnalysis
+	switch {
+	case analysis != nil && analysis.Status == models.AnalysisStatusCompleted && !reanalyze:
+		// Show the existing analysis
+	case analysis != nil && (analysis.Status == models.AnalysisStatusPending || analysis.Status == models.AnalysisStatusAnalyzing):
+		analysis = nil
+	default:
+		status, err := analysisSvc.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if !status.Enabled {
+			return fmt.Errorf("AI analysis is disabled on this Saturn instance (AI_ANALYSIS_ENABLED)")
+		}
+		if !status.Available {
+			return fmt.Errorf("no AI provider is configured on this Saturn instance")
+		}
+		if _, err := analysisSvc.Analyze(ctx, deploymentUUID); err != nil {
+			return err
+		}
+		previous, analysis = existing.Analysis, nil
+	}
+
+	if analysis == nil {
+		waitCtx, cancel, pollInterval := analysisWaitContext(cmd)
+		defer cancel()
+		fmt.Fprintf(cmd.ErrOrStderr(), "Analyzing deployment %s...\n", deploymentUUID)
+		analysis, err = analysisSvc.WaitForAnalysis(waitCtx, deploymentUUID, pollInterval, previous)
+		if err != nil {
+			if waitCtx.Err() == context.DeadlineExceeded {
+				return cli.WithExitCode(fmt.Errorf("timed out waiting for the analysis of deployment %s", deploymentUUID), ExitCodeWaitTimeout)
+			}
+			return err
+		}
+	}
+
+	if analysis.Status == models.AnalysisStatusFailed {
+		message := "unknown error"
+		if analysis.ErrorMessage != nil {
+			message = *analysis.ErrorMessage
+		}
+		return fmt.Errorf("analysis of deployment %s failed: %s", deploymentUUID, message)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != output.FormatTable {
+		formatter, err := output.NewFormatter(format, output.Options{})
+		if err != nil {
+			return fmt.Errorf("failed to create formatter: %w", err)
+		}
+		return formatter.Format(analysis)
+	}
+	printAnalysis(cmd.OutOrStdout(), analysis)
+	return nil
+}
+
+func printAnalysis(out io.Writer, a *models.DeploymentAnalysis) {
+	color := output.ColorEnabled(out)
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+
+	fmt.Fprintf(out, "%s %s\n", output.Colorize("Root cause:", output.ColorBold, color), deref(a.RootCause))
+	if details := deref(a.RootCauseDetails); details != "" {
+		fmt.Fprintf(out, "\n%s\n", details)
+	}
+
+	severity := deref(a.Severity)
+	fmt.Fprintf(out, "\nCategory: %s  Severity: %s  Confidence: %.0f%%\n",
+		deref(a.CategoryLabel), output.Colorize(severity, severityColor(severity), color), a.ConfidencePercent)
+
+	if len(a.Solution) > 0 {
+		fmt.Fprintf(out, "\n%s\n", output.Colorize("Solution:", output.ColorBold, color))
+		for i, step := range a.Solution {
+			fmt.Fprintf(out, "  %d. %s\n", i+1, step)
+		}
+	}
+	if len(a.Prevention) > 0 {
+		fmt.Fprintf(out, "\n%s\n", output.Colorize("Prevention:", output.ColorBold, color))
+		for _, tip := range a.Prevention {
+			fmt.Fprintf(out, "  - %s\n", tip)
+		}
+	}
+}
+
+// severityColor picks the colour of a severity label
+func severityColor(severity string) string {
+	switch severity {
+	case models.SeverityCritical, models.SeverityHigh:
+		return output.ColorRed
+	case models.SeverityMedium:
+		return output.ColorYellow
+	default:
+		return output.ColorGray
+	}
+}
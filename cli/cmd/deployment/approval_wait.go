@@ -0,0 +1,108 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// approvalDeadline is the --timeout of HandleWait. Its clock stops while any deployment
+// waits for approval, as that depends on a person rather than on the build.
+type approvalDeadline struct {
+	mu        sync.Mutex
+	cancel    context.CancelCauseFunc
+	timer     *time.Timer
+	remaining time.Duration
+	started   time.Time
+	pending   map[string]bool
+	ctx       context.Context
+}
+
+func newApprovalDeadline(parent context.Context, timeout time.Duration) (context.Context, *approvalDeadline) {
+	ctx, cancel := context.WithCancelCause(parent)
+	d := &approvalDeadline{
+		cancel:    cancel,
+		remaining: timeout,
+		started:   time.Now(),
+		pending:   make(map[string]bool),
+		ctx:       ctx,
+	}
+	d.timer = time.AfterFunc(timeout, func() { cancel(context.DeadlineExceeded) })
+	return ctx, d
+}
+
+// SetPending records whether a deployment waits for approval, pausing the clock while
+// any does and resuming it once none does
+func (d *approvalDeadline) SetPending(deploymentUUID string, pending bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	wasPaused := len(d.pending) > 0
+	if pending {
+		d.pending[deploymentUUID] = true
+	} else {
+		delete(d.pending, deploymentUUID)
+	}
+	paused := len(d.pending) > 0
+
+	switch {
+	case paused && !wasPaused:
+		if d.timer.Stop() {
+			d.remaining -= time.Since(d.started)
+		}
+	case !paused && wasPaused:
+		d.started = time.Now()
+		d.timer.Reset(max(d.remaining, 0))
+	}
+}
+
+// Exceeded reports whether the context ended because the timeout ran out
+func (d *approvalDeadline) Exceeded() bool {
+	return context.Cause(d.ctx) == context.DeadlineExceeded
+}
+
+// Stop releases the timer and the context
+func (d *approvalDeadline) Stop() {
+	d.timer.Stop()
+	d.cancel(context.Canceled)
+}
+
+// approvalStatus fetches the approval request of a deployment, or nil when it is unavailable
+func approvalStatus(ctx context.Context, cmd *cobra.Command, deploymentUUID string) *models.DeploymentApproval {
+	client, err := cli.GetAPIClient(cmd)
+	if err != nil {
+		return nil
+	}
+	approval, err := service.NewApprovalService(client).Status(ctx, deploymentUUID)
+	if err != nil {
+		return nil
+	}
+	return approval
+}
+
+// approvalWaitMessage explains who has to approve a deployment
+func approvalWaitMessage(approval *models.DeploymentApproval) string {
+	var b strings.Builder
+	b.WriteString("waiting for approval")
+	if approval != nil {
+		if approval.ApplicationName != nil {
+			fmt.Fprintf(&b, " of %s", *approval.ApplicationName)
+		}
+		if approval.EnvironmentName != nil {
+			fmt.Fprintf(&b, " in %s", *approval.EnvironmentName)
+		}
+		if approval.RequestedBy != nil {
+			fmt.Fprintf(&b, ", requested by %s", *approval.RequestedBy)
+		}
+	}
+	b.WriteString("; an owner or admin of the project or team must approve")
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package deployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+func TestApprovalDeadline_Expires(t *testing.T) {
+	ctx, d := newApprovalDeadline(context.Background(), 20*time.Millisecond)
+	defer d.Stop()
+
+	<-ctx.Done()
+	assert.True(t, d.Exceeded())
+}
+
+func TestApprovalDeadline_PausedWhilePending(t *testing.T) {
+	ctx, d := newApprovalDeadline(context.Background(), 50*time.Millisecond)
+	defer d.Stop()
+
+	d.SetPending("dep-1", true)
+	d.SetPending("dep-2", true)
+	select {
+	case <-ctx.Done():
+		t.Fatal("deadline expired while deployments were pending approval")
+	case <-time.After(150 * time.Millisecond):
+	}
+
+	// Still paused while one deployment waits
+	d.SetPending("dep-1", false)
+	select {
+	case <-ctx.Done():
+		t.Fatal("deadline expired while a deployment was pending approval")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	d.SetPending("dep-2", false)
+	select {
+	case <-ctx.Done():
+		assert.True(t, d.Exceeded())
+	case <-time.After(2 * time.Second):
+		t.Fatal("deadline did not resume after the approval")
+	}
+}
+
+func TestApprovalDeadline_StopIsNotExceeded(t *testing.T) {
+	ctx, d := newApprovalDeadline(context.Background(), time.Minute)
+	d.Stop()
+
+	<-ctx.Done()
+	assert.False(t, d.Exceeded())
+}
+
+func TestApprovalWaitMessage(t *testing.T) {
+	app, env, by := "api", "production", "dev@example.com"
+	assert.Contains(t, approvalWaitMessage(nil), "waiting for approval")
+	assert.Equal(t,
+		"waiting for approval of api in production, requested by dev@example.com; an owner or admin of the project or team must approve",
+		approvalWaitMessage(&models.DeploymentApproval{ApplicationName: &app, EnvironmentName: &env, RequestedBy: &by}))
+}
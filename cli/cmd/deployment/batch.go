@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/output"
 	"github.com/saturn-platform/saturn-cli/internal/service"
 )
 
@@ -78,11 +79,13 @@ Example: saturn deploy batch app1,app2,app3`,
 			}
 
 			results := make([]result, 0, len(names))
-			var allDeploymentUUIDs []string
+			var allTargets []WaitTarget
+			events := newEventWriter(cmd)
+			out := humanOut(cmd)
 
 			for _, name := range names {
 				uuid := nameToUUID[name]
-				fmt.Fprintf(cmd.OutOrStdout(), "Deploying %s...\n", name)
+				fmt.Fprintf(out, "Deploying %s...\n", name)
 
 				res, err := deploySvc.Deploy(ctx, uuid, force)
 				if err != nil {
@@ -93,6 +96,9 @@ Example: saturn deploy batch app1,app2,app3`,
 						Error:   err.Error(),
 					})
 					fmt.Fprintf(cmd.ErrOrStderr(), "  Failed: %v\n", err)
+					if events != nil {
+						_ = events.Emit(output.Event{Type: output.EventError, Resource: name, Message: err.Error()})
+					}
 				} else {
 					// Get first deployment message from the array
 					message := ""
@@ -105,8 +111,14 @@ Example: saturn deploy batch app1,app2,app3`,
 						Success: true,
 						Message: message,
 					})
-					fmt.Fprintf(cmd.OutOrStdout(), "  Success: %s\n", message)
-					allDeploymentUUIDs = append(allDeploymentUUIDs, CollectDeploymentUUIDs(res)...)
+					fmt.Fprintf(out, "  Success: %s\n", message)
+					targets := CollectWaitTargets(res, name)
+					if events != nil {
+						for _, t := range targets {
+							_ = events.Emit(output.Event{Type: output.EventDeployStarted, Resource: t.Resource, DeploymentUUID: t.DeploymentUUID, Message: message})
+						}
+					}
+					allTargets = append(allTargets, targets...)
 				}
 			}
 
@@ -118,14 +130,14 @@ Example: saturn deploy batch app1,app2,app3`,
 				}
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "\nBatch deployment complete: %d/%d succeeded\n", successCount, len(results))
+			fmt.Fprintf(out, "\nBatch deployment complete: %d/%d succeeded\n", successCount, len(results))
 
 			if successCount < len(results) {
 				return fmt.Errorf("some deployments failed")
 			}
 
 			// Handle --wait flag for all successful deployments
-			return HandleWait(cmd, deploySvc, allDeploymentUUIDs)
+			return HandleWait(cmd, deploySvc, allTargets)
 		},
 	}
 
@@ -0,0 +1,176 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// cancelTarget is a queued or running deployment selected for cancellation
+type cancelTarget struct {
+	DeploymentUUID string
+	Application    string
+	Status         string
+	Commit         string
+}
+
+// runBulkCancel cancels every queued or running deployment selected by --app, --project or --all-in-progress
+func runBulkCancel(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	format, _ := cmd.Flags().GetString("format")
+	out := cmd.OutOrStdout()
+	if format != output.FormatTable {
+		// Keep stdout for the results
+		out = cmd.ErrOrStderr()
+	}
+
+	client, err := cli.GetAPIClient(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get API client: %w", err)
+	}
+	deploySvc := service.NewDeploymentService(client)
+
+	scope, targets, err := bulkCancelTargets(ctx, cmd, client, deploySvc)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		fmt.Fprintf(out, "No queued or running deployments %s.\n", scope)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Queued and running deployments %s:\n", scope)
+	printCancelTargets(out, targets)
+
+	if force, _ := cmd.Flags().GetBool("force"); !force {
+		fmt.Fprintf(out, "\nCancel %d deployment(s)? [y/N] ", len(targets))
+		var answer string
+		if _, err := fmt.Fscanln(cmd.InOrStdin(), &answer); err != nil {
+			answer = ""
+		}
+		if !strings.HasPrefix(strings.ToLower(answer), "y") {
+			fmt.Fprintln(out, "Cancel aborted.")
+			return nil
+		}
+	}
+
+	uuids := make([]string, len(targets))
+	for i, t := range targets {
+		uuids[i] = t.DeploymentUUID
+	}
+	results := deploySvc.CancelMany(ctx, uuids)
+
+	if format != output.FormatTable {
+		formatter, err := output.NewFormatter(format, output.Options{})
+		if err != nil {
+			return fmt.Errorf("failed to create formatter: %w", err)
+		}
+		if err := formatter.Format(results); err != nil {
+			return err
+		}
+	} else {
+		printCancelResults(out, targets, results)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to cancel %d of %d deployments", failed, len(results))
+	}
+	return nil
+}
+
+// bulkCancelTargets finds the queued or running deployments selected by the flags and describes the selection
+func bulkCancelTargets(ctx context.Context, cmd *cobra.Command, client *api.Client, deploySvc *service.DeploymentService) (string, []cancelTarget, error) {
+	if ref, _ := cmd.Flags().GetString("app"); ref != "" {
+		app, err := service.NewApplicationService(client).Find(ctx, ref)
+		if err != nil {
+			return "", nil, err
+		}
+		targets, err := appCancelTargets(ctx, deploySvc, scopedApp{UUID: app.UUID, Name: app.Name})
+		return "of application " + app.Name, targets, err
+	}
+
+	if ref, _ := cmd.Flags().GetString("project"); ref != "" {
+		projectSvc := service.NewProjectService(client)
+		project, err := findProject(ctx, projectSvc, ref)
+		if err != nil {
+			return "", nil, err
+		}
+		if project == nil {
+			return "", nil, fmt.Errorf("project '%s' not found", ref)
+		}
+		apps, err := projectApplications(ctx, projectSvc, project.UUID)
+		if err != nil {
+			return "", nil, err
+		}
+		var targets []cancelTarget
+		for _, app := range apps {
+			appTargets, err := appCancelTargets(ctx, deploySvc, app)
+			if err != nil {
+				return "", nil, err
+			}
+			targets = append(targets, appTargets...)
+		}
+		return "in project " + project.Name, targets, nil
+	}
+
+	deployments, err := deploySvc.InProgress(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	targets := make([]cancelTarget, len(deployments))
+	for i, d := range deployments {
+		targets[i] = cancelTarget{DeploymentUUID: d.UUID, Application: "-", Status: d.Status, Commit: commitOf(d)}
+		if d.ApplicationName != nil {
+			targets[i].Application = *d.ApplicationName
+		}
+	}
+	return "in the team", targets, nil
+}
+
+func appCancelTargets(ctx context.Context, deploySvc *service.DeploymentService, app scopedApp) ([]cancelTarget, error) {
+	deployments, err := deploySvc.InProgressByApplication(ctx, app.UUID)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]cancelTarget, len(deployments))
+	for i, d := range deployments {
+		targets[i] = cancelTarget{DeploymentUUID: d.UUID, Application: app.Name, Status: d.Status, Commit: commitOf(d)}
+	}
+	return targets, nil
+}
+
+func printCancelTargets(out io.Writer, targets []cancelTarget) {
+	width := 0
+	for _, t := range targets {
+		width = max(width, len(t.Application))
+	}
+	for _, t := range targets {
+		fmt.Fprintf(out, "  %s  %-*s  %-16s %s\n", t.DeploymentUUID, width, t.Application, t.Status, models.ShortSHA(t.Commit))
+	}
+}
+
+func printCancelResults(out io.Writer, targets []cancelTarget, results []service.CancelResult) {
+	color := output.ColorEnabled(out)
+	for i, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(out, "  %s %s (%s): %s\n", output.Colorize("✗", output.ColorRed, color), r.DeploymentUUID, targets[i].Application, r.Error)
+			continue
+		}
+		fmt.Fprintf(out, "  %s %s (%s) cancelled\n", output.Colorize("✓", output.ColorGreen, color), r.DeploymentUUID, targets[i].Application)
+	}
+}
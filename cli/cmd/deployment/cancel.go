@@ -13,11 +13,23 @@ import (
 // NewCancelCommand cancels a deployment
 func NewCancelCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "cancel <uuid>",
-		Short: "Cancel a deployment by UUID",
-		Long:  `Cancel an in-progress deployment. This will stop the deployment process and clean up any temporary resources.`,
-		Args:  cli.ExactArgs(1, "<uuid>"),
+		Use:   "cancel [uuid]",
+		Short: "Cancel a deployment by UUID, or every queued or running deployment of an application or project",
+		Long: `Cancel an in-progress deployment. This will stop the deployment process and clean up any temporary resources.
+
+With --app, --project or --all-in-progress instead of a UUID, every queued or running
+deployment is listed and, once confirmed, cancelled concurrently. Deployments pending approval
+are left alone; reject them with 'saturn approvals reject'.`,
+		Example: `  saturn deploy cancel <deployment-uuid>
+  saturn deploy cancel --app my-api
+  saturn deploy cancel --project shop --force
+  saturn deploy cancel --all-in-progress`,
+		Args: cancelArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return runBulkCancel(cmd)
+			}
+
 			ctx := cmd.Context()
 			uuid := args[0]
 
@@ -66,5 +78,23 @@ func NewCancelCommand() *cobra.Command {
 	}
 
 	cmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().String("app", "", "Cancel the queued and running deployments of this application (name or UUID)")
+	cmd.Flags().String("project", "", "Cancel the queued and running deployments of every application in this project (name or UUID)")
+	cmd.Flags().Bool("all-in-progress", false, "Cancel every queued or running deployment of the team")
+	cmd.MarkFlagsMutuallyExclusive("app", "project", "all-in-progress")
 	return cmd
 }
+
+// cancelArgs accepts either a deployment UUID or one of --app, --project and --all-in-progress
+func cancelArgs(cmd *cobra.Command, args []string) error {
+	bulk := cmd.Flags().Changed("app") || cmd.Flags().Changed("project") || cmd.Flags().Changed("all-in-progress")
+	switch {
+	case len(args) > 1:
+		return fmt.Errorf("expected at most 1 argument, got %d\n\nUsage: %s", len(args), cmd.UseLine())
+	case len(args) == 1 && bulk:
+		return fmt.Errorf("give either a deployment UUID or --app, --project or --all-in-progress, not both")
+	case len(args) == 0 && !bulk:
+		return fmt.Errorf("requires a deployment UUID, or --app, --project or --all-in-progress\n\nUsage: %s", cmd.UseLine())
+	}
+	return nil
+}
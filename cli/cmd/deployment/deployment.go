@@ -16,6 +16,14 @@ func NewDeploymentCommand() *cobra.Command {
 	cmd.AddCommand(NewListCommand())
 	cmd.AddCommand(NewGetCommand())
 	cmd.AddCommand(NewCancelCommand())
+	cmd.AddCommand(NewPromoteCommand())
+	cmd.AddCommand(NewAnalyzeCommand())
+	cmd.AddCommand(NewReviewCommand())
+	cmd.AddCommand(NewDiffCommand())
+	cmd.AddCommand(NewStatsCommand())
+	logsCmd := NewLogsCommand()
+	logsCmd.AddCommand(NewLogsExportCommand())
+	cmd.AddCommand(logsCmd)
 	cmd.AddCommand(NewTagCommand())
 	cmd.AddCommand(NewPRCommand())
 	cmd.AddCommand(NewSmartCommand())
@@ -0,0 +1,255 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// NewDiffCommand compares two deployments
+func NewDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <from_uuid> <to_uuid> | diff <app> --previous",
+		Short: "Compare two deployments",
+		Long: `Show what changed between two deployments: their commits, statuses and build
+durations, and, when run inside a clone of the repository, the commits and files
+between them and the .saturn.yml components those files belong to.
+
+With --previous, the latest deployment of an application (name or UUID) is compared
+with the last successful one before it.`,
+		Example: `  saturn deploy diff <from-uuid> <to-uuid>
+  saturn deploy diff my-api --previous`,
+		Args: cli.MinArgs(1, "<from_uuid> <to_uuid>, or <app> --previous"),
+		RunE: runDiff,
+	}
+
+	cmd.Flags().Bool("previous", false, "Compare the latest deployment of an application with the last successful one")
+	return cmd
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	previous, _ := cmd.Flags().GetBool("previous")
+	if previous && len(args) != 1 {
+		return fmt.Errorf("--previous takes a single application name or UUID")
+	}
+	if !previous && len(args) != 2 {
+		return fmt.Errorf("specify two deployment UUIDs, or an application with --previous")
+	}
+
+	client, err := cli.GetAPIClient(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get API client: %w", err)
+	}
+	deploySvc := service.NewDeploymentService(client)
+
+	fromUUID, toUUID := "", ""
+	if previous {
+		app, err := service.NewApplicationService(client).Find(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		fromUUID, toUUID, err = previousDeployments(ctx, deploySvc, app.UUID)
+		if err != nil {
+			return err
+		}
+	} else {
+		fromUUID, toUUID = args[0], args[1]
+	}
+
+	diff := &models.DeploymentDiff{}
+	for _, d := range []struct {
+		uuid string
+		into *models.Deployment
+	}{{fromUUID, &diff.From}, {toUUID, &diff.To}} {
+		deployment, err := deploySvc.Get(ctx, d.uuid)
+		if err != nil {
+			return err
+		}
+		// The build log is not part of the comparison
+		deployment.Logs = nil
+		*d.into = *deployment
+	}
+	gitDiff(ctx, diff)
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != output.FormatTable {
+		formatter, err := output.NewFormatter(format, output.Options{})
+		if err != nil {
+			return fmt.Errorf("failed to create formatter: %w", err)
+		}
+		return formatter.Format(diff)
+	}
+	printDiff(cmd.OutOrStdout(), diff)
+	return nil
+}
+
+// previousDeployments returns the last successful deployment of an application before its
+// latest one, and the latest one
+func previousDeployments(ctx context.Context, deploySvc *service.DeploymentService, appUUID string) (string, string, error) {
+	deployments, err := deploySvc.ListByApplicationWithPagination(ctx, appUUID, 0, 50)
+	if err != nil {
+		return "", "", err
+	}
+	if len(deployments) == 0 {
+		return "", "", fmt.Errorf("application %s has no deployments", appUUID)
+	}
+	service.SortByMostRecent(deployments)
+	for _, d := range deployments[1:] {
+		if d.Status == "finished" {
+			return d.UUID, deployments[0].UUID, nil
+		}
+	}
+	return "", "", fmt.Errorf("no successful deployment before %s found for application %s", deployments[0].UUID, appUUID)
+}
+
+// gitDiff fills in the commits, files and components between the two deployments from
+// the local repository, or records why it could not
+func gitDiff(ctx context.Context, diff *models.DeploymentDiff) {
+	from, to := commitOf(diff.From), commitOf(diff.To)
+	if from == "" || to == "" {
+		diff.GitError = "a deployment has no commit"
+		return
+	}
+	if from == to {
+		return
+	}
+	// Commits come from the API, so they are checked before they reach git
+	for _, c := range []string{from, to} {
+		if !service.CommitSHAPattern.MatchString(c) {
+			diff.GitError = fmt.Sprintf("deployment commit %q is not a commit SHA", c)
+			return
+		}
+	}
+	for _, c := range []string{from, to} {
+		if !service.GitCommitExists(ctx, c) {
+			diff.GitError = fmt.Sprintf("commit %s is not in a local git repository; run from a clone of the application's repository, after git fetch", models.ShortSHA(c))
+			return
+		}
+	}
+
+	commits, err := service.GetCommitLog(ctx, from, to)
+	if err != nil {
+		diff.GitError = err.Error()
+		return
+	}
+	files, err := service.GetChangedFilesBetween(ctx, from, to)
+	if err != nil {
+		diff.GitError = err.Error()
+		return
+	}
+	diff.Commits, diff.Files = commits, files
+
+	if cfg, err := service.LoadConfig("."); err == nil && cfg != nil {
+		if components := service.MatchComponents(cfg, files); len(components) > 0 {
+			diff.Components = components
+		}
+	}
+}
+
+// commitOf returns the commit a deployment built, or "" when it is not a specific commit
+func commitOf(d models.Deployment) string {
+	if d.Commit == nil || *d.Commit == "HEAD" {
+		return ""
+	}
+	return *d.Commit
+}
+
+func printDiff(out io.Writer, diff *models.DeploymentDiff) {
+	color := output.ColorEnabled(out)
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	firstLine := func(s string) string {
+		line, _, _ := strings.Cut(s, "\n")
+		return line
+	}
+	duration := func(d models.Deployment) string {
+		if elapsed, ok := d.BuildDuration(); ok {
+			return elapsed.Round(time.Second).String()
+		}
+		return "-"
+	}
+	queued := func(d models.Deployment) string {
+		if t, ok := d.CreatedTime(); ok {
+			return t.Local().Format("2006-01-02 15:04:05")
+		}
+		return "-"
+	}
+	status := func(d models.Deployment) string {
+		c := output.ColorGreen
+		if ExitCodeForStatus(d.Status) != ExitCodeSuccess {
+			c = output.ColorRed
+		}
+		if !service.IsTerminalStatus(d.Status) {
+			c = output.ColorYellow
+		}
+		return output.Colorize(fmt.Sprintf("%-40s", d.Status), c, color)
+	}
+
+	rows := [][3]string{
+		{"Deployment", diff.From.UUID, diff.To.UUID},
+		{"Application", deref(diff.From.ApplicationName), deref(diff.To.ApplicationName)},
+		{"Commit", models.ShortSHA(deref(diff.From.Commit)), models.ShortSHA(deref(diff.To.Commit))},
+		{"Message", firstLine(deref(diff.From.CommitMessage)), firstLine(deref(diff.To.CommitMessage))},
+		{"Queued", queued(diff.From), queued(diff.To)},
+		{"Duration", duration(diff.From), duration(diff.To)},
+	}
+	fmt.Fprintf(out, "%-12s %s %s\n", "", output.Colorize(fmt.Sprintf("%-40s", "FROM"), output.ColorBold, color), output.Colorize("TO", output.ColorBold, color))
+	fmt.Fprintf(out, "%-12s %s %s\n", "Status", status(diff.From), strings.TrimRight(status(diff.To), " "))
+	for _, r := range rows {
+		fmt.Fprintf(out, "%-12s %-40s %s\n", r[0], truncate(r[1], 40), r[2])
+	}
+
+	if diff.GitError != "" {
+		fmt.Fprintf(out, "\nChanges unavailable: %s\n", diff.GitError)
+		return
+	}
+	if commitOf(diff.From) == commitOf(diff.To) {
+		fmt.Fprintln(out, "\nBoth deployments built the same commit")
+		return
+	}
+
+	fmt.Fprintf(out, "\n%s\n", output.Colorize(fmt.Sprintf("%d commit(s):", len(diff.Commits)), output.ColorBold, color))
+	for _, c := range diff.Commits {
+		fmt.Fprintf(out, "  %s %s %s\n", output.Colorize(models.ShortSHA(c.SHA), output.ColorYellow, color), c.Subject, output.Colorize("("+c.Author+")", output.ColorGray, color))
+	}
+
+	fmt.Fprintf(out, "\n%s\n", output.Colorize(fmt.Sprintf("%d file(s) changed:", len(diff.Files)), output.ColorBold, color))
+	for _, f := range diff.Files {
+		fmt.Fprintf(out, "  %s\n", f)
+	}
+
+	if len(diff.Components) > 0 {
+		names := make([]string, 0, len(diff.Components))
+		for name := range diff.Components {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(out, "\n%s\n", output.Colorize("Components (.saturn.yml):", output.ColorBold, color))
+		for _, name := range names {
+			fmt.Fprintf(out, "  %s: %d file(s)\n", name, len(diff.Components[name]))
+		}
+	}
+}
+
+// truncate shortens s to n characters, marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	if len([]rune(s)) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
@@ -0,0 +1,67 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestPrintDiff(t *testing.T) {
+	diff := &models.DeploymentDiff{
+		From: models.Deployment{
+			UUID: "dep-good", Status: "finished", ApplicationName: strPtr("api"),
+			Commit: strPtr("1111111aaaa"), CommitMessage: strPtr("Fix login\n\nLonger body"),
+			CreatedAt: strPtr("2026-01-01T10:00:00Z"), FinishedAt: strPtr("2026-01-01T10:01:30Z"),
+		},
+		To: models.Deployment{
+			UUID: "dep-bad", Status: "failed", ApplicationName: strPtr("api"),
+			Commit: strPtr("2222222bbbb"), CommitMessage: strPtr("Add cache"),
+			CreatedAt: strPtr("2026-01-02T10:00:00Z"),
+		},
+		Commits:    []models.GitCommit{{SHA: "2222222bbbb", Author: "Dev", Subject: "Add cache"}},
+		Files:      []string{"apps/api/cache.go", "README.md"},
+		Components: map[string][]string{"api": {"apps/api/cache.go"}},
+	}
+
+	var buf bytes.Buffer
+	printDiff(&buf, diff)
+	out := buf.String()
+
+	assert.Contains(t, out, "Status       finished                                 failed")
+	assert.Contains(t, out, "Commit       1111111                                  2222222")
+	assert.Contains(t, out, "Message      Fix login                                Add cache")
+	assert.Contains(t, out, "Duration     1m30s                                    -")
+	assert.Contains(t, out, "1 commit(s):\n  2222222 Add cache (Dev)")
+	assert.Contains(t, out, "2 file(s) changed:\n  apps/api/cache.go\n  README.md")
+	assert.Contains(t, out, "Components (.saturn.yml):\n  api: 1 file(s)")
+}
+
+func TestPrintDiff_GitUnavailable(t *testing.T) {
+	diff := &models.DeploymentDiff{
+		From:     models.Deployment{UUID: "a", Status: "finished", Commit: strPtr("1111111")},
+		To:       models.Deployment{UUID: "b", Status: "finished", Commit: strPtr("2222222")},
+		GitError: "commit 2222222 is not in a local git repository",
+	}
+
+	var buf bytes.Buffer
+	printDiff(&buf, diff)
+	assert.Contains(t, buf.String(), "Changes unavailable: commit 2222222 is not in a local git repository")
+	assert.NotContains(t, buf.String(), "file(s) changed")
+}
+
+func TestGitDiff_RejectsCommitsThatAreNotSHAs(t *testing.T) {
+	diff := &models.DeploymentDiff{
+		From: models.Deployment{UUID: "a", Commit: strPtr("1111111")},
+		To:   models.Deployment{UUID: "b", Commit: strPtr("--output=/tmp/pwned")},
+	}
+
+	gitDiff(context.Background(), diff)
+	assert.Equal(t, `deployment commit "--output=/tmp/pwned" is not a commit SHA`, diff.GitError)
+	assert.Empty(t, diff.Files)
+}
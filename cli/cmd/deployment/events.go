@@ -0,0 +1,32 @@
+package deployment
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/output"
+)
+
+// isNDJSON reports whether the command should emit an NDJSON event stream
+func isNDJSON(cmd *cobra.Command) bool {
+	format, _ := cmd.Flags().GetString("format")
+	return format == output.FormatNDJSON
+}
+
+// newEventWriter returns an event writer on stdout when --format ndjson is set, nil otherwise
+func newEventWriter(cmd *cobra.Command) *output.EventWriter {
+	if !isNDJSON(cmd) {
+		return nil
+	}
+	return output.NewEventWriter(cmd.OutOrStdout())
+}
+
+// humanOut returns the writer for human-oriented progress lines.
+// In ndjson mode stdout is reserved for events, so they go to stderr instead.
+func humanOut(cmd *cobra.Command) io.Writer {
+	if isNDJSON(cmd) {
+		return cmd.ErrOrStderr()
+	}
+	return cmd.OutOrStdout()
+}
@@ -0,0 +1,323 @@
+package deployment
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/logfilter"
+	"github.com/saturn-platform/saturn-cli/internal/logmask"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// NewLogsExportCommand writes the build logs of an application's deployments to an archive
+func NewLogsExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <app>",
+		Short: "Export the build logs of an application's deployments to a tar.gz archive",
+		Long: `Export the build logs of an application's deployments to a tar.gz archive.
+
+Every deployment gets a text log and the raw JSON log as stored by Saturn, which
+always includes hidden entries. manifest.json lists each deployment with its
+status, commit, server and timing.
+
+The values of the application's environment variables are replaced with ********
+in both files. Use --show-sensitive to export the logs unmasked.`,
+		Example: `  saturn deploy logs export my-app --since 7d --out logs.tar.gz
+  saturn deploy logs export <app-uuid> --debuglogs`,
+		Args: cli.ExactArgs(1, "<app>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			var since time.Time
+			if s, _ := cmd.Flags().GetString("since"); s != "" {
+				var err error
+				if since, err = logfilter.ParseTimeBound(s, time.Now()); err != nil {
+					return fmt.Errorf("invalid --since: %w", err)
+				}
+			}
+
+			client, err := cli.GetAPIClient(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to get API client: %w", err)
+			}
+
+			app, err := service.NewApplicationService(client).Find(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			deploySvc := service.NewDeploymentService(client)
+			deployments, err := deploySvc.ListByApplicationSince(ctx, app.UUID, since)
+			if err != nil {
+				return err
+			}
+
+			// Archives are files that get shared, so they are masked like piped logs
+			var secrets []string
+			masker, err := cli.NewLogMasker(ctx, cmd, io.Discard, func(ctx context.Context) ([]string, error) {
+				values, err := service.NewSecretService(client).ResourceSecrets(ctx, []models.EnvironmentResource{{UUID: app.UUID, Type: models.ResourceTypeApplication}})
+				secrets = values
+				return values, err
+			})
+			if err != nil {
+				return err
+			}
+
+			outPath, _ := cmd.Flags().GetString("out")
+			if outPath == "" {
+				outPath = app.Name + "-deploy-logs.tar.gz"
+			}
+			file, err := os.Create(outPath)
+			if err != nil {
+				return fmt.Errorf("failed to create archive: %w", err)
+			}
+
+			showHidden, _ := cmd.Flags().GetBool("debuglogs")
+			manifest := logArchiveManifest{
+				Application: archivedApplication{UUID: app.UUID, Name: app.Name},
+				ExportedAt:  time.Now().UTC(),
+				Deployments: []archivedDeployment{},
+			}
+			if !since.IsZero() {
+				manifest.Since = &since
+			}
+
+			progress := cmd.ErrOrStderr()
+			archive := newLogArchive(file, app.Name+"-deploy-logs")
+			if masker != nil {
+				archive.masker = masker
+				archive.rawMasker = logmask.New(append(secrets, jsonEscaped(secrets)...))
+			}
+			failed, unparsed := 0, 0
+			for i, d := range deployments {
+				fmt.Fprintf(progress, "\rFetching deployment logs %d/%d", i+1, len(deployments))
+				entry, err := archive.AddDeployment(ctx, deploySvc, d, showHidden)
+				if err != nil {
+					if ctx.Err() != nil {
+						break
+					}
+					failed++
+				}
+				if entry.Warning != "" {
+					unparsed++
+				}
+				manifest.Deployments = append(manifest.Deployments, entry)
+			}
+			if len(deployments) > 0 {
+				fmt.Fprintln(progress)
+			}
+			if unparsed > 0 {
+				fmt.Fprintf(progress, "Warning: logs of %d deployments could not be parsed and were archived raw; see the warning fields in manifest.json\n", unparsed)
+			}
+
+			err = archive.Close(manifest)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err == nil {
+				err = ctx.Err()
+			}
+			if err != nil {
+				_ = os.Remove(outPath)
+				return fmt.Errorf("failed to write archive: %w", err)
+			}
+
+			fmt.Fprintf(humanOut(cmd), "Exported %d deployments of %s to %s\n", len(manifest.Deployments), app.Name, outPath)
+			if failed > 0 {
+				return fmt.Errorf("logs of %d deployments could not be fetched; see the error fields in manifest.json", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("since", "", "Only export deployments queued after this time (e.g. 7d, 24h, 2026-01-02)")
+	cmd.Flags().StringP("out", "o", "", "Archive path (default: <app>-deploy-logs.tar.gz)")
+	cmd.Flags().Bool("debuglogs", false, "Include hidden entries in the text logs")
+	return cmd
+}
+
+// logArchiveManifest is written to manifest.json at the root of the archive
+type logArchiveManifest struct {
+	Application archivedApplication  `json:"application"`
+	ExportedAt  time.Time            `json:"exported_at"`
+	Since       *time.Time           `json:"since,omitempty"`
+	Deployments []archivedDeployment `json:"deployments"`
+}
+
+type archivedApplication struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// archivedDeployment describes one deployment and the files holding its logs
+type archivedDeployment struct {
+	DeploymentUUID  string   `json:"deployment_uuid"`
+	Status          string   `json:"status"`
+	Commit          *string  `json:"commit,omitempty"`
+	CommitMessage   *string  `json:"commit_message,omitempty"`
+	Server          *string  `json:"server,omitempty"`
+	Rollback        bool     `json:"rollback"`
+	CreatedAt       *string  `json:"created_at,omitempty"`
+	FinishedAt      *string  `json:"finished_at,omitempty"`
+	DurationSeconds *float64 `json:"duration_seconds,omitempty"`
+	LogFile         string   `json:"log_file,omitempty"`
+	RawFile         string   `json:"raw_file,omitempty"`
+	Error           string   `json:"error,omitempty"`
+	Warning         string   `json:"warning,omitempty"`
+}
+
+// logArchive writes deployment logs into a gzip-compressed tar stream under a single directory
+type logArchive struct {
+	gz  *gzip.Writer
+	tw  *tar.Writer
+	dir string
+	// masker hides secrets in the text logs, and rawMasker in the JSON logs, where they
+	// may be escaped; nil writes the logs as they are
+	masker    *logmask.Masker
+	rawMasker *logmask.Masker
+}
+
+func newLogArchive(w io.Writer, dir string) *logArchive {
+	gz := gzip.NewWriter(w)
+	return &logArchive{gz: gz, tw: tar.NewWriter(gz), dir: dir}
+}
+
+// AddDeployment fetches the logs of d and adds its text and raw JSON files. The returned
+// manifest entry records the error when the logs could not be fetched, and a warning when
+// they could not be parsed, in which case the text file holds the raw log.
+func (a *logArchive) AddDeployment(ctx context.Context, deploySvc *service.DeploymentService, d models.Deployment, showHidden bool) (archivedDeployment, error) {
+	entry := manifestEntry(d)
+
+	full, err := deploySvc.Get(ctx, d.UUID)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry, err
+	}
+	entry = manifestEntry(*full)
+
+	raw := ""
+	if full.Logs != nil {
+		raw = *full.Logs
+	}
+	text, err := models.ParseAndFormatLogs(raw, showHidden)
+	if err == nil && raw != "" {
+		// ParseAndFormatLogs passes unparsable logs through, or formats what it could decode
+		var entries []models.LogEntry
+		err = json.Unmarshal([]byte(raw), &entries)
+	}
+	if err != nil {
+		entry.Warning = fmt.Sprintf("logs could not be parsed, the text log holds the raw log: %v", err)
+		text = a.rawMasker.Mask(raw)
+	} else {
+		text = a.masker.Mask(text)
+	}
+	raw = a.rawMasker.Mask(raw)
+
+	modTime := time.Now()
+	if t, ok := full.FinishedTime(); ok {
+		modTime = t
+	} else if t, ok := full.CreatedTime(); ok {
+		modTime = t
+	}
+
+	base := archiveBaseName(*full)
+	entry.LogFile = base + ".log"
+	entry.RawFile = base + ".json"
+	if err := a.writeFile(entry.LogFile, []byte(text), modTime); err != nil {
+		return entry, err
+	}
+	if err := a.writeFile(entry.RawFile, []byte(raw), modTime); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
+
+// Close writes manifest.json and flushes the archive
+func (a *logArchive) Close(manifest logArchiveManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := a.writeFile("manifest.json", append(data, '\n'), manifest.ExportedAt); err != nil {
+		return err
+	}
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	return a.gz.Close()
+}
+
+func (a *logArchive) writeFile(name string, data []byte, modTime time.Time) error {
+	if err := a.tw.WriteHeader(&tar.Header{
+		Name:    a.dir + "/" + name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err := a.tw.Write(data)
+	return err
+}
+
+// jsonEscaped returns the values that change when written inside a JSON string, as
+// encoded by Go and by PHP, which also escapes slashes
+func jsonEscaped(values []string) []string {
+	var escaped []string
+	for _, v := range values {
+		var b strings.Builder
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			continue
+		}
+		s := strings.TrimSuffix(b.String(), "\n")
+		s = s[1 : len(s)-1]
+		for _, e := range []string{s, strings.ReplaceAll(s, "/", `\/`)} {
+			if e != v {
+				escaped = append(escaped, e)
+			}
+		}
+	}
+	return escaped
+}
+
+// manifestEntry copies the metadata of a deployment into its manifest entry
+func manifestEntry(d models.Deployment) archivedDeployment {
+	entry := archivedDeployment{
+		DeploymentUUID: d.UUID,
+		Status:         d.Status,
+		Commit:         d.Commit,
+		CommitMessage:  d.CommitMessage,
+		Server:         d.ServerName,
+		Rollback:       d.Rollback != nil && *d.Rollback,
+		CreatedAt:      d.CreatedAt,
+		FinishedAt:     d.FinishedAt,
+	}
+	created, okCreated := d.CreatedTime()
+	finished, okFinished := d.FinishedTime()
+	if okCreated && okFinished && !finished.Before(created) {
+		seconds := finished.Sub(created).Seconds()
+		entry.DurationSeconds = &seconds
+	}
+	return entry
+}
+
+// archiveBaseName names the files of a deployment so they sort chronologically
+func archiveBaseName(d models.Deployment) string {
+	if t, ok := d.CreatedTime(); ok {
+		return "deployments/" + t.UTC().Format("20060102-150405") + "-" + d.UUID
+	}
+	return "deployments/" + d.UUID
+}
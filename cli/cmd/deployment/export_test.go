@@ -0,0 +1,159 @@
+package deployment
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/logmask"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+func readArchive(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(body)
+	}
+}
+
+func TestLogArchive(t *testing.T) {
+	logs := `[{"output":"Building","type":"stdout","batch":1,"order":1},{"output":"secret step","type":"stdout","hidden":true,"batch":1,"order":2},{"output":"Done","type":"stdout","batch":1,"order":3}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/deployments/dep-1":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"deployment_uuid": "dep-1",
+				"status":          "finished",
+				"commit":          "abc123",
+				"server_name":     "prod-1",
+				"created_at":      "2026-01-02T10:00:00.000000Z",
+				"finished_at":     "2026-01-02T10:01:30.000000Z",
+				"logs":            logs,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Deployment not found."}`))
+		}
+	}))
+	defer server.Close()
+
+	deploySvc := service.NewDeploymentService(api.NewClient(server.URL, "test-token"))
+
+	var buf bytes.Buffer
+	archive := newLogArchive(&buf, "web-deploy-logs")
+	manifest := logArchiveManifest{
+		Application: archivedApplication{UUID: "app-1", Name: "web"},
+		ExportedAt:  time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	entry, err := archive.AddDeployment(context.Background(), deploySvc, models.Deployment{UUID: "dep-1"}, false)
+	require.NoError(t, err)
+	manifest.Deployments = append(manifest.Deployments, entry)
+
+	missing, err := archive.AddDeployment(context.Background(), deploySvc, models.Deployment{UUID: "dep-2", Status: "failed"}, false)
+	require.Error(t, err)
+	manifest.Deployments = append(manifest.Deployments, missing)
+
+	require.NoError(t, archive.Close(manifest))
+
+	files := readArchive(t, buf.Bytes())
+	base := "web-deploy-logs/deployments/20260102-100000-dep-1"
+	assert.Equal(t, "Building\nDone\n", files[base+".log"])
+	assert.Equal(t, logs, files[base+".json"])
+
+	var got logArchiveManifest
+	require.NoError(t, json.Unmarshal([]byte(files["web-deploy-logs/manifest.json"]), &got))
+	require.Len(t, got.Deployments, 2)
+
+	first := got.Deployments[0]
+	assert.Equal(t, "finished", first.Status)
+	assert.Equal(t, "abc123", *first.Commit)
+	assert.Equal(t, "prod-1", *first.Server)
+	assert.Equal(t, 90.0, *first.DurationSeconds)
+	assert.Equal(t, "deployments/20260102-100000-dep-1.log", first.LogFile)
+
+	second := got.Deployments[1]
+	assert.Equal(t, "failed", second.Status)
+	assert.Empty(t, second.LogFile)
+	assert.NotEmpty(t, second.Error)
+}
+
+func TestLogArchive_Masked(t *testing.T) {
+	logs := `[{"output":"Connecting to postgres://user:p4ss@db/app","type":"stdout","batch":1,"order":1}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"deployment_uuid": "dep-1", "status": "finished", "logs": logs})
+	}))
+	defer server.Close()
+
+	deploySvc := service.NewDeploymentService(api.NewClient(server.URL, "test-token"))
+	secrets := []string{"postgres://user:p4ss@db/app"}
+
+	var buf bytes.Buffer
+	archive := newLogArchive(&buf, "web-deploy-logs")
+	archive.masker = logmask.New(secrets)
+	archive.rawMasker = logmask.New(append(secrets, jsonEscaped(secrets)...))
+	_, err := archive.AddDeployment(context.Background(), deploySvc, models.Deployment{UUID: "dep-1"}, false)
+	require.NoError(t, err)
+	require.NoError(t, archive.Close(logArchiveManifest{}))
+
+	files := readArchive(t, buf.Bytes())
+	assert.Equal(t, "Connecting to ********\n", files["web-deploy-logs/deployments/dep-1.log"])
+	assert.Equal(t, `[{"output":"Connecting to ********","type":"stdout","batch":1,"order":1}]`, files["web-deploy-logs/deployments/dep-1.json"])
+}
+
+func TestLogArchive_UnparsableLogs(t *testing.T) {
+	logs := `[{"output":"Building with s3cr3t-t0ken","type":"stdout","batch":1,"order":1},{"output":`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"deployment_uuid": "dep-1", "status": "failed", "logs": logs})
+	}))
+	defer server.Close()
+
+	deploySvc := service.NewDeploymentService(api.NewClient(server.URL, "test-token"))
+
+	var buf bytes.Buffer
+	archive := newLogArchive(&buf, "web-deploy-logs")
+	archive.masker = logmask.New([]string{"s3cr3t-t0ken"})
+	archive.rawMasker = logmask.New([]string{"s3cr3t-t0ken"})
+	entry, err := archive.AddDeployment(context.Background(), deploySvc, models.Deployment{UUID: "dep-1"}, false)
+	require.NoError(t, err)
+	assert.Contains(t, entry.Warning, "could not be parsed")
+	require.NoError(t, archive.Close(logArchiveManifest{}))
+
+	masked := `[{"output":"Building with ********","type":"stdout","batch":1,"order":1},{"output":`
+	files := readArchive(t, buf.Bytes())
+	assert.Equal(t, masked, files["web-deploy-logs/deployments/dep-1.log"])
+	assert.Equal(t, masked, files["web-deploy-logs/deployments/dep-1.json"])
+}
+
+func TestJSONEscaped(t *testing.T) {
+	assert.Equal(t, []string{`a\"b/c`, `a\"b\/c`}, jsonEscaped([]string{`a"b/c`}))
+	assert.Equal(t, []string{`https:\/\/x`}, jsonEscaped([]string{"https://x"}))
+	assert.Empty(t, jsonEscaped([]string{"plain"}))
+}
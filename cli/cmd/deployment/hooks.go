@@ -0,0 +1,190 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/config"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// Ways to notify the user that a wait has ended
+const (
+	NotifyBell    = "bell"
+	NotifyDesktop = "desktop"
+)
+
+// deployHooks are the local commands to run, and the notification to send, when a waited deployment ends
+type deployHooks struct {
+	onSuccess string
+	onFailure string
+	notify    string
+}
+
+// addHookFlags adds the --on-success, --on-failure and --notify flags
+func addHookFlags(cmd *cobra.Command) {
+	cmd.Flags().String("on-success", "", "Run this shell command for every deployment that finishes (implies --wait)")
+	cmd.Flags().String("on-failure", "", "Run this shell command for every deployment that fails, is cancelled or times out (implies --wait)")
+	cmd.Flags().String("notify", "", "Notify when the wait ends: desktop or bell (implies --wait)")
+	cmd.Flags().Lookup("notify").NoOptDefVal = NotifyDesktop
+}
+
+// hooksRequested reports whether a hook flag was given, which implies --wait
+func hooksRequested(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("on-success") || cmd.Flags().Changed("on-failure") || cmd.Flags().Changed("notify")
+}
+
+// loadHooks returns the hooks from the config file, overridden by the hook flags
+func loadHooks(cmd *cobra.Command) (deployHooks, error) {
+	var hooks deployHooks
+	if cfg, err := config.Load(); err == nil && cfg.Hooks != nil {
+		hooks = deployHooks{onSuccess: cfg.Hooks.OnSuccess, onFailure: cfg.Hooks.OnFailure, notify: cfg.Hooks.Notify}
+	}
+	if cmd.Flags().Changed("on-success") {
+		hooks.onSuccess, _ = cmd.Flags().GetString("on-success")
+	}
+	if cmd.Flags().Changed("on-failure") {
+		hooks.onFailure, _ = cmd.Flags().GetString("on-failure")
+	}
+	if cmd.Flags().Changed("notify") {
+		hooks.notify, _ = cmd.Flags().GetString("notify")
+	}
+	if hooks.notify != "" && hooks.notify != NotifyBell && hooks.notify != NotifyDesktop {
+		return hooks, fmt.Errorf("invalid notification %q: expected %s or %s", hooks.notify, NotifyDesktop, NotifyBell)
+	}
+	return hooks, nil
+}
+
+// runDeployHooks runs the success or failure hook for every waited deployment and sends the
+// notification. Failing hooks are reported but do not change the outcome of the command.
+// When the command itself was interrupted, the deployments did not end, so no hook runs.
+func runDeployHooks(cmd *cobra.Command, deploySvc *service.DeploymentService, hooks deployHooks, targets []WaitTarget, results []service.WaitResult) {
+	if cmd.Context().Err() != nil {
+		if hooks.onSuccess != "" || hooks.onFailure != "" || hooks.notify != "" {
+			fmt.Fprintln(cmd.ErrOrStderr(), "Wait interrupted, skipping the deploy hooks")
+		}
+		return
+	}
+
+	// Hooks run to completion even if the command is interrupted meanwhile
+	ctx := context.WithoutCancel(cmd.Context())
+	resources := make(map[string]string, len(targets))
+	for _, t := range targets {
+		resources[t.DeploymentUUID] = t.Resource
+	}
+
+	failed := 0
+	for _, res := range results {
+		command, name := hooks.onSuccess, "on-success"
+		if !res.Finished {
+			failed++
+			command, name = hooks.onFailure, "on-failure"
+		}
+		if command == "" {
+			continue
+		}
+
+		commit := ""
+		if d, err := deploySvc.Get(ctx, res.DeploymentUUID); err == nil {
+			commit = commitOf(*d)
+		}
+		env := hookEnv(res.DeploymentUUID, resources[res.DeploymentUUID], hookStatus(res), commit)
+
+		fmt.Fprintf(humanOut(cmd), "Running %s hook for %s\n", name, resources[res.DeploymentUUID])
+		if err := runHook(ctx, command, env, humanOut(cmd), cmd.ErrOrStderr()); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s hook for %s failed: %v\n", name, resources[res.DeploymentUUID], err)
+		}
+	}
+
+	if hooks.notify != "" && len(results) > 0 {
+		title, message := notificationText(targets, results, failed)
+		notify(cmd.ErrOrStderr(), hooks.notify, title, message)
+	}
+}
+
+// hookStatus is the status passed to hooks: the final deployment status, or wait-timeout
+// when the deployment was still running when the wait gave up
+func hookStatus(res service.WaitResult) string {
+	if !res.Finished && !service.IsTerminalStatus(res.Status) {
+		return "wait-timeout"
+	}
+	return res.Status
+}
+
+// hookEnv returns the environment of a hook: the CLI's own plus the deployment variables
+func hookEnv(deploymentUUID, resource, status, commit string) []string {
+	return append(os.Environ(),
+		"SATURN_DEPLOYMENT_UUID="+deploymentUUID,
+		"SATURN_RESOURCE="+resource,
+		"SATURN_STATUS="+status,
+		"SATURN_COMMIT="+commit,
+	)
+}
+
+// runHook runs command through the shell of the platform
+func runHook(ctx context.Context, command string, env []string, stdout, stderr io.Writer) error {
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	c.Env = env
+	c.Stdout = stdout
+	c.Stderr = stderr
+	return c.Run()
+}
+
+// notificationText summarises the outcome of a wait for a notification
+func notificationText(targets []WaitTarget, results []service.WaitResult, failed int) (string, string) {
+	title := "Saturn: deployment finished"
+	if failed > 0 {
+		title = "Saturn: deployment failed"
+	}
+	if len(results) == 1 {
+		resource := results[0].DeploymentUUID
+		for _, t := range targets {
+			if t.DeploymentUUID == results[0].DeploymentUUID {
+				resource = t.Resource
+			}
+		}
+		return title, fmt.Sprintf("%s: %s", resource, hookStatus(results[0]))
+	}
+	if failed > 0 {
+		return title, fmt.Sprintf("%d of %d deployments did not finish", failed, len(results))
+	}
+	return title, fmt.Sprintf("All %d deployments finished", len(results))
+}
+
+// notify sends a desktop notification, falling back to the terminal bell where
+// desktop notifications are unavailable
+func notify(bell io.Writer, mode, title, message string) {
+	if mode == NotifyDesktop && desktopNotify(title, message) == nil {
+		return
+	}
+	fmt.Fprint(bell, "\a")
+}
+
+func desktopNotify(title, message string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		return exec.Command("osascript", "-e", script).Run()
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return exec.Command("notify-send", title, message).Run()
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,141 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+func hookCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().String("format", "table", "")
+	AddWaitFlags(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func TestLoadHooks(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", filepath.Join(home, "AppData", "Roaming"))
+
+	// Without a config file only the flags count
+	hooks, err := loadHooks(hookCommand(t, "--on-failure", "echo failed", "--notify"))
+	require.NoError(t, err)
+	assert.Equal(t, deployHooks{onFailure: "echo failed", notify: NotifyDesktop}, hooks)
+
+	configDir := filepath.Join(home, ".config", "saturn")
+	if runtime.GOOS == "windows" {
+		configDir = filepath.Join(home, "AppData", "Roaming", "saturn")
+	}
+	require.NoError(t, os.MkdirAll(configDir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.json"),
+		[]byte(`{"instances":[],"hooks":{"onSuccess":"say done","onFailure":"say failed","notify":"bell"}}`), 0o600))
+
+	hooks, err = loadHooks(hookCommand(t))
+	require.NoError(t, err)
+	assert.Equal(t, deployHooks{onSuccess: "say done", onFailure: "say failed", notify: NotifyBell}, hooks)
+
+	// Flags override the config file
+	hooks, err = loadHooks(hookCommand(t, "--on-success", "", "--notify=desktop"))
+	require.NoError(t, err)
+	assert.Equal(t, deployHooks{onFailure: "say failed", notify: NotifyDesktop}, hooks)
+
+	_, err = loadHooks(hookCommand(t, "--notify=email"))
+	assert.Error(t, err)
+}
+
+func TestHooksRequested(t *testing.T) {
+	assert.False(t, hooksRequested(hookCommand(t, "--wait")))
+	assert.True(t, hooksRequested(hookCommand(t, "--on-success", "true")))
+	assert.True(t, hooksRequested(hookCommand(t, "--notify")))
+}
+
+func TestRunDeployHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands below use sh")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/deployments/dep-api":
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-api","status":"finished","commit":"3f2a9c1e"}`))
+		case "/api/v1/deployments/dep-web":
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-web","status":"in_progress","commit":"HEAD"}`))
+		}
+	}))
+	defer server.Close()
+
+	cmd := hookCommand(t,
+		"--on-success", `echo "ok $SATURN_RESOURCE $SATURN_STATUS $SATURN_COMMIT"`,
+		"--on-failure", `echo "failed $SATURN_DEPLOYMENT_UUID $SATURN_STATUS"; exit 3`)
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetContext(context.Background())
+
+	hooks, err := loadHooks(cmd)
+	require.NoError(t, err)
+	targets := []WaitTarget{
+		{DeploymentUUID: "dep-api", Resource: "api"},
+		{DeploymentUUID: "dep-web", Resource: "web"},
+	}
+	results := []service.WaitResult{
+		{DeploymentUUID: "dep-api", Status: "finished", Finished: true},
+		{DeploymentUUID: "dep-web", Status: "in_progress"},
+	}
+	runDeployHooks(cmd, service.NewDeploymentService(api.NewClient(server.URL, "test-token")), hooks, targets, results)
+
+	assert.Contains(t, stdout.String(), "ok api finished 3f2a9c1e\n")
+	assert.Contains(t, stdout.String(), "failed dep-web wait-timeout\n")
+	assert.Contains(t, stderr.String(), "Warning: on-failure hook for web failed: exit status 3")
+}
+
+func TestRunDeployHooks_Interrupted(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cmd := hookCommand(t, "--on-failure", "echo failed")
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cmd.SetContext(ctx)
+
+	hooks, err := loadHooks(cmd)
+	require.NoError(t, err)
+	runDeployHooks(cmd, service.NewDeploymentService(api.NewClient("http://unused", "test-token")), hooks,
+		[]WaitTarget{{DeploymentUUID: "dep-web", Resource: "web"}}, []service.WaitResult{{DeploymentUUID: "dep-web", Status: "in_progress"}})
+
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, "Wait interrupted, skipping the deploy hooks\n", stderr.String())
+}
+
+func TestNotificationText(t *testing.T) {
+	targets := []WaitTarget{{DeploymentUUID: "dep-1", Resource: "api"}, {DeploymentUUID: "dep-2", Resource: "web"}}
+
+	title, message := notificationText(targets[:1], []service.WaitResult{{DeploymentUUID: "dep-1", Status: "finished", Finished: true}}, 0)
+	assert.Equal(t, "Saturn: deployment finished", title)
+	assert.Equal(t, "api: finished", message)
+
+	title, message = notificationText(targets, []service.WaitResult{
+		{DeploymentUUID: "dep-1", Status: "finished", Finished: true},
+		{DeploymentUUID: "dep-2", Status: "failed"},
+	}, 1)
+	assert.Equal(t, "Saturn: deployment failed", title)
+	assert.Equal(t, "1 of 2 deployments did not finish", message)
+}
@@ -1,6 +1,7 @@
 package deployment
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -12,31 +13,27 @@ import (
 
 // NewListCommand lists all deployments
 func NewListCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all deployments",
-		Long:  `List all currently running deployments across all resources.`,
+		Long:  `List all currently running deployments across all resources. Use --watch to keep the list refreshed.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			ctx := cmd.Context()
-
 			client, err := cli.GetAPIClient(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to get API client: %w", err)
 			}
 
 			deploySvc := service.NewDeploymentService(client)
-			deployments, err := deploySvc.List(ctx)
-			if err != nil {
-				return fmt.Errorf("failed to list deployments: %w", err)
-			}
-
-			format, _ := cmd.Flags().GetString("format")
-			formatter, err := output.NewFormatter(format, output.Options{})
-			if err != nil {
-				return fmt.Errorf("failed to create formatter: %w", err)
-			}
-
-			return formatter.Format(deployments)
+			return cli.RunWithWatch(cmd, output.Options{}, func(ctx context.Context) (any, error) {
+				deployments, err := deploySvc.List(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list deployments: %w", err)
+				}
+				return deployments, nil
+			})
 		},
 	}
+
+	cli.AddWatchFlags(cmd)
+	return cmd
 }
@@ -0,0 +1,227 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/logmask"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// NewLogsCommand shows the build log of a deployment
+func NewLogsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs <uuid>",
+		Short: "Show the build log of a deployment",
+		Long: `Show the build log of a deployment.
+
+With --follow, new log entries are printed as the build progresses until the
+deployment ends. The exit code then reflects the final status: 0 when it
+finished, 1 when it failed or timed out and 3 when it was cancelled.
+
+--since, --until, --grep and --level filter the entries. Entries written to
+stderr count as errors unless their text names another level.
+
+When the output is not a terminal, the values of the application's environment
+variables are replaced with ******** . Use --mask to mask on a terminal as well,
+or --show-sensitive to never mask.`,
+		Example: `  saturn deploy logs <uuid>
+  saturn deploy logs <uuid> --follow --commands
+  saturn deploy logs <uuid> --level error`,
+		Args: cli.ExactArgs(1, "<uuid>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uuid := args[0]
+
+			client, err := cli.GetAPIClient(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to get API client: %w", err)
+			}
+			deploySvc := service.NewDeploymentService(client)
+
+			follow, _ := cmd.Flags().GetBool("follow")
+			showHidden, _ := cmd.Flags().GetBool("debuglogs")
+			pollSec, _ := cmd.Flags().GetInt("poll-interval")
+			printer := newLogPrinter(cmd, false)
+			filter, err := cli.NewLogFilter(cmd)
+			if err != nil {
+				return err
+			}
+			printer.masker, err = cli.NewLogMasker(cmd.Context(), cmd, cmd.OutOrStdout(), func(ctx context.Context) ([]string, error) {
+				return service.NewSecretService(client).DeploymentSecrets(ctx, uuid)
+			})
+			if err != nil {
+				return err
+			}
+
+			if !follow {
+				entries, _, err := deploySvc.LogEntries(cmd.Context(), uuid, showHidden)
+				if err != nil {
+					return fmt.Errorf("failed to get deployment logs: %w", err)
+				}
+				for _, entry := range entries {
+					if filter.MatchEntry(entry) {
+						printer.Print(WaitTarget{DeploymentUUID: uuid}, entry)
+					}
+				}
+				return nil
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			status, err := deploySvc.FollowLogs(ctx, uuid, service.LogFollowOptions{
+				PollInterval: time.Duration(pollSec) * time.Second,
+				ShowHidden:   showHidden,
+			}, func(entry models.LogEntry) {
+				if filter.MatchEntry(entry) {
+					printer.Print(WaitTarget{DeploymentUUID: uuid}, entry)
+				}
+			})
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					fmt.Fprintln(cmd.ErrOrStderr(), "\nStopping log follow...")
+					return nil
+				}
+				return fmt.Errorf("failed to follow deployment logs: %w", err)
+			}
+			return deploymentStatusError(uuid, status)
+		},
+	}
+
+	cmd.Flags().BoolP("follow", "f", false, "Print new log entries until the deployment ends")
+	cmd.Flags().Bool("commands", false, "Show the command that produced each entry")
+	cmd.Flags().Bool("debuglogs", false, "Show debug logs (includes hidden commands and internal operations)")
+	cmd.Flags().Int("poll-interval", 2, "Poll interval in seconds when following")
+	cli.AddLogFilterFlags(cmd)
+	cli.AddLogMaskFlags(cmd)
+	return cmd
+}
+
+// logPrinter writes deployment log entries, either as text or as ndjson log events.
+// It is safe for concurrent use by several followed deployments.
+type logPrinter struct {
+	mu           sync.Mutex
+	out          io.Writer
+	events       *output.EventWriter
+	color        bool
+	showCommands bool
+	prefix       bool
+	// masker hides secrets in entries; nil prints them as they are
+	masker *logmask.Masker
+}
+
+// newLogPrinter creates a printer for cmd. With prefix set, every line is labelled
+// with the resource it belongs to, for interleaved output of several deployments.
+func newLogPrinter(cmd *cobra.Command, prefix bool) *logPrinter {
+	showCommands, _ := cmd.Flags().GetBool("commands")
+	out := cmd.OutOrStdout()
+	return &logPrinter{
+		out:          out,
+		events:       newEventWriter(cmd),
+		color:        output.ColorEnabled(out),
+		showCommands: showCommands,
+		prefix:       prefix,
+	}
+}
+
+// Print writes a single log entry of the target's deployment
+func (p *logPrinter) Print(target WaitTarget, entry models.LogEntry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry.Output = p.masker.Mask(entry.Output)
+	if entry.Command != nil {
+		command := p.masker.Mask(*entry.Command)
+		entry.Command = &command
+	}
+
+	if p.events != nil {
+		_ = p.events.Emit(output.Event{Type: output.EventLog, Resource: target.Resource, DeploymentUUID: target.DeploymentUUID, Message: entry.Output})
+		return
+	}
+
+	label := ""
+	if p.prefix {
+		label = output.Colorize("["+targetLabel(target)+"] ", output.ColorCyan, p.color)
+	}
+	if p.showCommands && entry.Command != nil && *entry.Command != "" {
+		fmt.Fprintln(p.out, label+output.Colorize("$ "+*entry.Command, output.ColorGray, p.color))
+	}
+	if entry.Output == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(entry.Output, "\n"), "\n") {
+		if entry.Type == "stderr" {
+			line = output.Colorize(line, output.ColorRed, p.color)
+		}
+		fmt.Fprintln(p.out, label+line)
+	}
+}
+
+func targetLabel(target WaitTarget) string {
+	if target.Resource != "" {
+		return target.Resource
+	}
+	return target.DeploymentUUID
+}
+
+// followTargets prints the build logs of every target until its deployment ends and
+// returns a WaitResult per target, in the order of targets. The targets share one request
+// budget, since the server rate limits the logs endpoint per token.
+func followTargets(ctx context.Context, cmd *cobra.Command, deploySvc *service.DeploymentService, targets []WaitTarget,
+	pollInterval time.Duration, onStatus service.StatusCallback) ([]service.WaitResult, error) {
+	printer := newLogPrinter(cmd, len(targets) > 1)
+	var err error
+	printer.masker, err = cli.NewLogMasker(ctx, cmd, cmd.OutOrStdout(), func(ctx context.Context) ([]string, error) {
+		client, err := cli.GetAPIClient(cmd)
+		if err != nil {
+			return nil, err
+		}
+		return service.NewSecretService(client).ResourceSecrets(ctx, secretResources(targets))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]service.WaitResult, len(targets))
+	errs := make([]error, len(targets))
+	limiter := service.NewRequestLimiter(service.DeploymentLogRequestsPerMinute)
+
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, err := deploySvc.FollowLogs(ctx, target.DeploymentUUID, service.LogFollowOptions{
+				PollInterval: pollInterval,
+				OnStatus:     onStatus,
+				Limiter:      limiter,
+			}, func(entry models.LogEntry) {
+				printer.Print(target, entry)
+			})
+			results[i] = service.WaitResult{DeploymentUUID: target.DeploymentUUID, Status: status, Finished: status == "finished"}
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,15 @@
+package deployment
+
+import "github.com/saturn-platform/saturn-cli/internal/models"
+
+// secretResources returns the resources of targets whose secrets are masked in their logs.
+// A deployment can belong to an application or a service, so the type is left open.
+func secretResources(targets []WaitTarget) []models.EnvironmentResource {
+	resources := make([]models.EnvironmentResource, 0, len(targets))
+	for _, t := range targets {
+		if t.ResourceUUID != "" {
+			resources = append(resources, models.EnvironmentResource{UUID: t.ResourceUUID, Name: t.Resource})
+		}
+	}
+	return resources
+}
@@ -0,0 +1,131 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// deployPin is the git commit or image tag an application is deployed from.
+// At most one of the fields is set.
+type deployPin struct {
+	commit   *string
+	imageTag *string
+}
+
+// addPinFlags adds the --commit, --image-tag and --restore-pin flags
+func addPinFlags(cmd *cobra.Command) {
+	cmd.Flags().String("commit", "", "Deploy this git commit (SHA) of an application")
+	cmd.Flags().String("image-tag", "", "Deploy this image tag of a Docker image application")
+	cmd.Flags().Bool("restore-pin", false, "Restore the previous commit or image tag once the deployment ends (implies --wait)")
+	cmd.MarkFlagsMutuallyExclusive("commit", "image-tag")
+}
+
+// pinFromFlags returns the pin requested with --commit or --image-tag, or nil when there is none
+func pinFromFlags(cmd *cobra.Command) (*deployPin, error) {
+	restore, _ := cmd.Flags().GetBool("restore-pin")
+	if cmd.Flags().Changed("commit") {
+		commit, _ := cmd.Flags().GetString("commit")
+		if !service.CommitSHAPattern.MatchString(commit) {
+			return nil, fmt.Errorf("invalid --commit %q: expected a commit SHA of 4 to 40 hex characters", commit)
+		}
+		return &deployPin{commit: &commit}, nil
+	}
+	if cmd.Flags().Changed("image-tag") {
+		tag, _ := cmd.Flags().GetString("image-tag")
+		if tag == "" {
+			return nil, fmt.Errorf("--image-tag must not be empty")
+		}
+		return &deployPin{imageTag: &tag}, nil
+	}
+	if restore {
+		return nil, fmt.Errorf("--restore-pin needs --commit or --image-tag")
+	}
+	return nil, nil
+}
+
+// String describes the pin, e.g. "commit 3f2a9c1" or "image tag v1.4.2"
+func (p deployPin) String() string {
+	if p.commit != nil {
+		return "commit " + *p.commit
+	}
+	if p.imageTag != nil {
+		tag := *p.imageTag
+		if tag == "" {
+			tag = "latest"
+		}
+		return "image tag " + tag
+	}
+	return "nothing"
+}
+
+// currentPin returns the value app currently has for the field p sets
+func (p deployPin) currentPin(app *models.Application) deployPin {
+	if p.commit != nil {
+		commit := "HEAD"
+		if app.GitCommitSHA != nil && *app.GitCommitSHA != "" {
+			commit = *app.GitCommitSHA
+		}
+		return deployPin{commit: &commit}
+	}
+	tag := ""
+	if app.DockerRegistryImageTag != nil {
+		tag = *app.DockerRegistryImageTag
+	}
+	return deployPin{imageTag: &tag}
+}
+
+// applyPin sets the pin on the application and returns the pin it replaces
+func applyPin(ctx context.Context, appSvc *service.ApplicationService, appUUID string, pin deployPin) (*deployPin, error) {
+	app, err := appSvc.Get(ctx, appUUID)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return nil, fmt.Errorf("--commit and --image-tag only apply to applications, and no application %s was found", appUUID)
+		}
+		return nil, err
+	}
+	previous := pin.currentPin(app)
+
+	req := models.ApplicationUpdateRequest{GitCommitSHA: pin.commit, DockerRegistryImageTag: pin.imageTag}
+	if _, err := appSvc.Update(ctx, appUUID, req); err != nil {
+		return nil, fmt.Errorf("failed to set %s: %w", pin, err)
+	}
+	return &previous, nil
+}
+
+// restorePin puts back a pin replaced by applyPin
+func restorePin(ctx context.Context, appSvc *service.ApplicationService, appUUID string, previous deployPin) error {
+	req := models.ApplicationUpdateRequest{GitCommitSHA: previous.commit, DockerRegistryImageTag: previous.imageTag}
+	if _, err := appSvc.Update(ctx, appUUID, req); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", previous, err)
+	}
+	return nil
+}
+
+// restoreCommand is the command that puts the pin back on the application by hand
+func (p deployPin) restoreCommand(appUUID string) string {
+	if p.commit != nil {
+		return fmt.Sprintf("saturn app update %s --git-commit-sha %s", appUUID, *p.commit)
+	}
+	if p.imageTag != nil {
+		return fmt.Sprintf("saturn app update %s --docker-tag=%s", appUUID, *p.imageTag)
+	}
+	return ""
+}
+
+// deploymentsEnded reports whether every target reached a terminal status. A pin may only be
+// restored then, since a build still running may yet check out the commit or pull the image.
+func deploymentsEnded(ctx context.Context, deploySvc *service.DeploymentService, targets []WaitTarget) bool {
+	for _, t := range targets {
+		d, err := deploySvc.Get(ctx, t.DeploymentUUID)
+		if err != nil || !service.IsTerminalStatus(d.Status) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+func TestPinFromFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{name: "no pin", args: nil},
+		{name: "commit", args: []string{"--commit", "3f2a9c1"}, want: "commit 3f2a9c1"},
+		{name: "image tag", args: []string{"--image-tag", "v1.4.2", "--restore-pin"}, want: "image tag v1.4.2"},
+		{name: "invalid commit", args: []string{"--commit", "main"}, wantErr: "invalid --commit"},
+		{name: "empty image tag", args: []string{"--image-tag", ""}, wantErr: "must not be empty"},
+		{name: "restore without pin", args: []string{"--restore-pin"}, wantErr: "needs --commit or --image-tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			addPinFlags(cmd)
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			pin, err := pinFromFlags(cmd)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.Nil(t, pin)
+				return
+			}
+			require.NotNil(t, pin)
+			assert.Equal(t, tt.want, pin.String())
+		})
+	}
+}
+
+func TestApplyAndRestorePin(t *testing.T) {
+	var updates []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/applications/app-1", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPatch {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			updates = append(updates, body)
+		}
+		// The application was not pinned before
+		_, _ = w.Write([]byte(`{"uuid":"app-1","name":"api","git_commit_sha":"HEAD"}`))
+	}))
+	defer server.Close()
+
+	appSvc := service.NewApplicationService(api.NewClient(server.URL, "test-token"))
+	commit := "3f2a9c1"
+	previous, err := applyPin(context.Background(), appSvc, "app-1", deployPin{commit: &commit})
+	require.NoError(t, err)
+	assert.Equal(t, "commit HEAD", previous.String())
+
+	require.NoError(t, restorePin(context.Background(), appSvc, "app-1", *previous))
+
+	require.Len(t, updates, 2)
+	assert.Equal(t, map[string]any{"git_commit_sha": "3f2a9c1"}, updates[0])
+	assert.Equal(t, map[string]any{"git_commit_sha": "HEAD"}, updates[1])
+}
+
+func TestApplyPin_NotAnApplication(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Application not found."}`))
+	}))
+	defer server.Close()
+
+	appSvc := service.NewApplicationService(api.NewClient(server.URL, "test-token"))
+	tag := "v2"
+	_, err := applyPin(context.Background(), appSvc, "svc-1", deployPin{imageTag: &tag})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only apply to applications")
+}
+
+func TestDeploymentsEnded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/deployments/dep-finished":
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-finished","status":"finished"}`))
+		case "/api/v1/deployments/dep-running":
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-running","status":"in_progress"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	deploySvc := service.NewDeploymentService(api.NewClient(server.URL, "test-token"))
+	ctx := context.Background()
+
+	assert.True(t, deploymentsEnded(ctx, deploySvc, nil), "nothing was deployed")
+	assert.True(t, deploymentsEnded(ctx, deploySvc, []WaitTarget{{DeploymentUUID: "dep-finished"}}))
+	assert.False(t, deploymentsEnded(ctx, deploySvc, []WaitTarget{{DeploymentUUID: "dep-finished"}, {DeploymentUUID: "dep-running"}}))
+	assert.False(t, deploymentsEnded(ctx, deploySvc, []WaitTarget{{DeploymentUUID: "dep-unknown"}}), "an unknown status is not an end")
+}
+
+func TestRestoreCommand(t *testing.T) {
+	commit := "HEAD"
+	assert.Equal(t, "saturn app update app-1 --git-commit-sha HEAD", deployPin{commit: &commit}.restoreCommand("app-1"))
+	tag := "v1.4.1"
+	assert.Equal(t, "saturn app update app-1 --docker-tag=v1.4.1", deployPin{imageTag: &tag}.restoreCommand("app-1"))
+}
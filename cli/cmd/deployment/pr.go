@@ -7,7 +7,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/saturn-platform/saturn-cli/internal/cli"
-	"github.com/saturn-platform/saturn-cli/internal/output"
 	"github.com/saturn-platform/saturn-cli/internal/service"
 )
 
@@ -40,32 +39,13 @@ func NewPRCommand() *cobra.Command {
 				return fmt.Errorf("failed to deploy PR preview: %w", err)
 			}
 
-			format, _ := cmd.Flags().GetString("format")
-			formatter, err := output.NewFormatter(format, output.Options{})
-			if err != nil {
+			targets := CollectWaitTargets(result, uuid)
+			if err := printDeployResult(cmd, result, targets); err != nil {
 				return err
 			}
 
-			// For table format, convert deployment info array to display format
-			if format == output.FormatTable {
-				displays := make([]ResultDisplay, len(result.Deployments))
-				for i, dep := range result.Deployments {
-					displays[i] = ResultDisplay{
-						Message:        dep.Message,
-						DeploymentUUID: dep.DeploymentUUID,
-					}
-				}
-				if err := formatter.Format(displays); err != nil {
-					return err
-				}
-			} else {
-				if err := formatter.Format(result); err != nil {
-					return err
-				}
-			}
-
 			// Handle --wait flag
-			return HandleWait(cmd, deploySvc, CollectDeploymentUUIDs(result))
+			return HandleWait(cmd, deploySvc, targets)
 		},
 	}
 
@@ -0,0 +1,116 @@
+package deployment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// NewPromoteCommand promotes a deployment to another environment
+func NewPromoteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "promote <deployment_uuid> --to <environment>",
+		Short: "Promote a deployment's image to another environment",
+		Long: `Deploy the image built by a finished deployment to the application of the same
+name in another environment of the project, without rebuilding it. The exact
+commit that was built and tested (e.g. on staging) is what gets deployed.
+
+A preview shows the target application, the commit being promoted and the commit
+currently running there, and asks for confirmation unless --yes is given.
+Promotions to production environments may need approval first (see "saturn approvals").`,
+		Example: `  saturn deploy promote <deployment-uuid> --to production
+  saturn deploy promote <deployment-uuid> --to production --yes --wait`,
+		Args: cli.ExactArgs(1, "<deployment_uuid>"),
+		RunE: runPromote,
+	}
+
+	cmd.Flags().String("to", "", "Target environment (name or UUID)")
+	_ = cmd.MarkFlagRequired("to")
+	cmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().Bool("dry-run", false, "Show the preview without promoting")
+	AddWaitFlags(cmd)
+	return cmd
+}
+
+func runPromote(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	out := humanOut(cmd)
+	events := newEventWriter(cmd)
+
+	client, err := cli.GetAPIClient(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get API client: %w", err)
+	}
+
+	to, _ := cmd.Flags().GetString("to")
+	promoteSvc := service.NewPromotionService(client)
+	plan, err := promoteSvc.Plan(ctx, args[0], to)
+	if err != nil {
+		return err
+	}
+
+	printPromotionPlan(cmd, plan)
+	if events != nil {
+		_ = events.Emit(output.Event{Type: output.EventPlan, Resource: plan.Application, DeploymentUUID: plan.DeploymentUUID, Message: fmt.Sprintf("promote %s to %s", models.ShortSHA(plan.Commit), plan.TargetEnvironment), Data: plan})
+	}
+
+	// --dry-run: stop here
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		return nil
+	}
+
+	if plan.Commit == plan.CurrentCommit {
+		fmt.Fprintf(out, "\n%s already runs commit %s in %s\n", plan.Application, models.ShortSHA(plan.Commit), plan.TargetEnvironment)
+	}
+
+	// Confirm
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes {
+		fmt.Fprint(out, "\nProceed with promotion? [y/N] ")
+		var answer string
+		if _, err := fmt.Fscanln(cmd.InOrStdin(), &answer); err != nil {
+			answer = ""
+		}
+		if !strings.HasPrefix(strings.ToLower(answer), "y") {
+			fmt.Fprintln(out, "Aborted")
+			return nil
+		}
+	}
+
+	result, err := promoteSvc.Promote(ctx, plan.DeploymentUUID, plan.TargetEnvironmentUUID)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Promoting image %s\n", result.PromotedImage)
+
+	deployResult := &service.DeployResponse{Deployments: []service.DeploymentInfo{{
+		Message:        result.Message,
+		ResourceUUID:   plan.TargetApplicationUUID,
+		DeploymentUUID: result.DeploymentUUID,
+	}}}
+	targets := CollectWaitTargets(deployResult, plan.Application)
+	if err := printDeployResult(cmd, deployResult, targets); err != nil {
+		return err
+	}
+
+	return HandleWait(cmd, promoteSvc.DeploymentService(), targets)
+}
+
+func printPromotionPlan(cmd *cobra.Command, plan *models.PromotionPlan) {
+	out := humanOut(cmd)
+	current := plan.CurrentCommit
+	if current == "" {
+		current = "(never deployed)"
+	}
+	fmt.Fprintf(out, "Promotion of %s in project %s:\n", plan.Application, plan.Project)
+	fmt.Fprintf(out, "  From:    %s (deployment %s)\n", plan.SourceEnvironment, plan.DeploymentUUID)
+	fmt.Fprintf(out, "  To:      %s (application %s)\n", plan.TargetEnvironment, plan.TargetApplicationUUID)
+	fmt.Fprintf(out, "  Commit:  %s\n", plan.Commit)
+	fmt.Fprintf(out, "  Running: %s\n", current)
+}
@@ -0,0 +1,167 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/ci"
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/logmask"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// ReportJUnit is the --report format of JUnit XML files
+const ReportJUnit = "junit"
+
+// reportLogLines is how many lines of a failed deployment's build log a report carries
+const reportLogLines = 50
+
+// deployReport is a report file to write once the wait ends
+type deployReport struct {
+	format string
+	path   string
+}
+
+// addReportFlags adds the --report flag
+func addReportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("report", nil, "Write a report once the wait ends, as format=path; only junit=<file.xml> is supported (implies --wait, repeatable)")
+}
+
+// reportsRequested reports whether --report was given, which implies --wait
+func reportsRequested(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("report")
+}
+
+// parseReports returns the reports asked for with --report
+func parseReports(cmd *cobra.Command) ([]deployReport, error) {
+	values, _ := cmd.Flags().GetStringArray("report")
+	reports := make([]deployReport, 0, len(values))
+	for _, v := range values {
+		format, path, found := strings.Cut(v, "=")
+		if !found || path == "" {
+			return nil, fmt.Errorf("invalid --report %q: expected format=path, e.g. junit=report.xml", v)
+		}
+		if format != ReportJUnit {
+			return nil, fmt.Errorf("invalid --report format %q: expected %s", format, ReportJUnit)
+		}
+		reports = append(reports, deployReport{format: format, path: path})
+	}
+	return reports, nil
+}
+
+// writeReports writes the --report files and, in GitHub Actions, the step summary,
+// the error annotations and the step outputs
+func writeReports(cmd *cobra.Command, deploySvc *service.DeploymentService, reports []deployReport, targets []WaitTarget, results []service.WaitResult) error {
+	githubActions := ci.InGitHubActions()
+	if (len(reports) == 0 && !githubActions) || len(results) == 0 {
+		return nil
+	}
+
+	ctx := context.WithoutCancel(cmd.Context())
+	ciResults, err := reportResults(ctx, cmd, deploySvc, targets, results)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, r := range reports {
+		if err := ci.WriteJUnitFile(r.path, cmd.CommandPath(), ciResults, time.Now()); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Fprintf(humanOut(cmd), "Wrote %s report to %s\n", r.format, r.path)
+	}
+
+	if githubActions {
+		// Annotations are workflow commands read from the log, kept off stdout for ndjson
+		ci.WriteAnnotations(humanOut(cmd), ciResults)
+		if err := ci.WriteStepSummary("Saturn deployments", ciResults); err != nil {
+			errs = append(errs, err)
+		}
+		if err := ci.WriteOutputs(ciResults); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reportResults describes every waited deployment for the reports, attaching the
+// end of the build log, with secrets masked, to those that did not finish
+func reportResults(ctx context.Context, cmd *cobra.Command, deploySvc *service.DeploymentService, targets []WaitTarget, results []service.WaitResult) ([]ci.Result, error) {
+	byUUID := make(map[string]WaitTarget, len(targets))
+	var failed []WaitTarget
+	for _, t := range targets {
+		byUUID[t.DeploymentUUID] = t
+	}
+	for _, res := range results {
+		if t := byUUID[res.DeploymentUUID]; !res.Finished && t.ResourceUUID != "" {
+			failed = append(failed, t)
+		}
+	}
+
+	var masker *logmask.Masker
+	if len(failed) > 0 {
+		// Reports end up in files and CI artefacts, so they are masked like piped logs
+		var err error
+		masker, err = cli.NewLogMasker(ctx, cmd, io.Discard, func(ctx context.Context) ([]string, error) {
+			client, err := cli.GetAPIClient(cmd)
+			if err != nil {
+				return nil, err
+			}
+			return service.NewSecretService(client).ResourceSecrets(ctx, secretResources(failed))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ciResults := make([]ci.Result, len(results))
+	for i, res := range results {
+		r := ci.Result{
+			Resource:       waitTargetLabel(byUUID, res.DeploymentUUID),
+			DeploymentUUID: res.DeploymentUUID,
+			Status:         hookStatus(res),
+			Passed:         res.Finished,
+		}
+		if d, err := deploySvc.Get(ctx, res.DeploymentUUID); err == nil {
+			r.Commit = commitOf(*d)
+			r.Duration, _ = d.BuildDuration()
+		}
+		if !res.Finished {
+			r.Log = masker.Mask(logTail(ctx, deploySvc, res.DeploymentUUID, reportLogLines))
+		}
+		ciResults[i] = r
+	}
+	return ciResults, nil
+}
+
+// waitTargetLabel names the resource of a deployment, falling back to its UUID
+func waitTargetLabel(byUUID map[string]WaitTarget, deploymentUUID string) string {
+	if t, ok := byUUID[deploymentUUID]; ok && t.Resource != "" {
+		return t.Resource
+	}
+	return deploymentUUID
+}
+
+// logTail returns the last lines of the build log of a deployment, or "" when the
+// log cannot be fetched
+func logTail(ctx context.Context, deploySvc *service.DeploymentService, deploymentUUID string, lines int) string {
+	entries, _, err := deploySvc.LogEntries(ctx, deploymentUUID, false)
+	if err != nil {
+		return ""
+	}
+	var all []string
+	for _, e := range entries {
+		all = append(all, strings.Split(strings.TrimRight(e.Output, "\n"), "\n")...)
+	}
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	return strings.Join(all, "\n")
+}
@@ -0,0 +1,94 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+func TestParseReports(t *testing.T) {
+	reports, err := parseReports(hookCommand(t, "--report", "junit=out/deploy.xml"))
+	require.NoError(t, err)
+	assert.Equal(t, []deployReport{{format: ReportJUnit, path: "out/deploy.xml"}}, reports)
+
+	_, err = parseReports(hookCommand(t, "--report", "deploy.xml"))
+	assert.Error(t, err)
+	_, err = parseReports(hookCommand(t, "--report", "html=deploy.html"))
+	assert.Error(t, err)
+}
+
+func TestReportsRequested(t *testing.T) {
+	assert.False(t, reportsRequested(hookCommand(t, "--wait")))
+	assert.True(t, reportsRequested(hookCommand(t, "--report", "junit=deploy.xml")))
+}
+
+func TestWriteReports(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	summaryPath := filepath.Join(dir, "summary.md")
+	outputPath := filepath.Join(dir, "output")
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_STEP_SUMMARY", summaryPath)
+	t.Setenv("GITHUB_OUTPUT", outputPath)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/deployments/dep-api":
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-api","status":"finished","commit":"3f2a9c1e","created_at":"2026-03-02T10:00:00Z","finished_at":"2026-03-02T10:01:30Z"}`))
+		case "/api/v1/deployments/dep-web":
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-web","status":"failed","commit":"HEAD"}`))
+		case "/api/v1/deployments/dep-web/logs":
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-web","status":"failed","logs":[{"output":"npm ERR! build failed","type":"stderr"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	reportPath := filepath.Join(dir, "deploy.xml")
+	cmd := hookCommand(t, "--report", "junit="+reportPath)
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetContext(context.Background())
+
+	reports, err := parseReports(cmd)
+	require.NoError(t, err)
+	targets := []WaitTarget{
+		{DeploymentUUID: "dep-api", Resource: "api"},
+		{DeploymentUUID: "dep-web", Resource: "web"},
+	}
+	results := []service.WaitResult{
+		{DeploymentUUID: "dep-api", Status: "finished", Finished: true},
+		{DeploymentUUID: "dep-web", Status: "failed"},
+	}
+	require.NoError(t, writeReports(cmd, service.NewDeploymentService(api.NewClient(server.URL, "test-token")), reports, targets, results))
+
+	report, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(report), `<testcase name="api" classname="saturn.deploy" time="90.000">`)
+	assert.Contains(t, string(report), `<failure message="deployment dep-web ended with status failed" type="failed">npm ERR! build failed</failure>`)
+
+	assert.Contains(t, stdout.String(), "Wrote junit report to "+reportPath)
+	assert.Contains(t, stdout.String(), "::error title=Deployment of web failed::deployment dep-web ended with status failed\n")
+
+	summary, err := os.ReadFile(summaryPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(summary), "| ❌ | web | failed | - | - | `dep-web` |")
+
+	outputs, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(outputs), "status=failure\n")
+	assert.Contains(t, string(outputs), "failed_resources=web\n")
+}
@@ -0,0 +1,244 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// ReviewReport is the result of "deploy review" in machine-readable formats
+type ReviewReport struct {
+	DeploymentUUID string                       `json:"deployment_uuid"`
+	CommitSHA      string                       `json:"commit_sha"`
+	Status         string                       `json:"status"`
+	FilesCount     int                          `json:"files_count"`
+	Violations     []models.CodeReviewViolation `json:"violations"`
+	TotalCount     int                          `json:"total_count"`
+	SecretsHidden  bool                         `json:"secrets_hidden"`
+}
+
+// NewReviewCommand runs a code review of the commit a deployment built
+func NewReviewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "review <deployment_uuid>",
+		Short: "Show the code review of a deployment's commit",
+		Long: `Review the commit built by a deployment for leaked secrets, dangerous functions
+and other issues, and print the violations grouped by severity and file. A review
+is started when the commit has none yet, and the command waits for it.
+
+With --fail-on the command exits with code 1 when a violation of that severity or
+higher is found, so CI can gate merges on the review. Violations revealing secrets
+are hidden from users who cannot update the application; they count as failing.`,
+		Example: `  saturn deploy review <deployment-uuid>
+  saturn deploy review <deployment-uuid> --fail-on high`,
+		Args: cli.ExactArgs(1, "<deployment_uuid>"),
+		RunE: runReview,
+	}
+
+	cmd.Flags().String("fail-on", "", "Exit with code 1 on violations of this severity or higher (low, medium, high, critical)")
+	addAnalysisWaitFlags(cmd)
+	return cmd
+}
+
+func runReview(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	deploymentUUID := args[0]
+
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	failOn = strings.ToLower(failOn)
+	if failOn != "" && models.SeverityRank(failOn) == 0 {
+		return fmt.Errorf("invalid --fail-on %q: use low, medium, high or critical", failOn)
+	}
+
+	client, err := cli.GetAPIClient(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get API client: %w", err)
+	}
+
+	deployment, err := service.NewDeploymentService(client).Get(ctx, deploymentUUID)
+	if err != nil {
+		return err
+	}
+	if deployment.Commit == nil || *deployment.Commit == "" {
+		return fmt.Errorf("deployment %s has no commit to review", deploymentUUID)
+	}
+
+	reviewSvc := service.NewCodeReviewService(client)
+	review, err := codeReview(cmd, reviewSvc, deploymentUUID)
+	if err != nil {
+		return err
+	}
+	if review.Status == models.AnalysisStatusFailed {
+		message := "unknown error"
+		if review.ErrorMessage != nil {
+			message = *review.ErrorMessage
+		}
+		return fmt.Errorf("code review of deployment %s failed: %s", deploymentUUID, message)
+	}
+
+	violations, err := reviewSvc.Violations(ctx, deploymentUUID)
+	if err != nil {
+		return err
+	}
+	sortViolations(violations.Violations)
+
+	report := ReviewReport{
+		DeploymentUUID: deploymentUUID,
+		CommitSHA:      review.CommitSHA,
+		Status:         review.StatusLabel,
+		FilesCount:     review.FilesCount,
+		Violations:     violations.Violations,
+		TotalCount:     violations.TotalCount,
+		SecretsHidden:  violations.SecretsHidden,
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == output.FormatTable {
+		printReview(cmd.OutOrStdout(), &report)
+	} else {
+		formatter, err := output.NewFormatter(format, output.Options{})
+		if err != nil {
+			return fmt.Errorf("failed to create formatter: %w", err)
+		}
+		if err := formatter.Format(report); err != nil {
+			return err
+		}
+	}
+
+	if failOn == "" {
+		return nil
+	}
+	if failing := failingViolations(&report, failOn); failing > 0 {
+		return cli.WithExitCode(fmt.Errorf("code review found %d violation(s) of severity %s or higher", failing, failOn), ExitCodeFailed)
+	}
+	return nil
+}
+
+// codeReview returns the finished review of a deployment's commit, starting one when the
+// commit has none or the last one failed
+func codeReview(cmd *cobra.Command, reviewSvc *service.CodeReviewService, deploymentUUID string) (*models.CodeReview, error) {
+	ctx := cmd.Context()
+	existing, err := reviewSvc.Get(ctx, deploymentUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *models.CodeReview
+	switch {
+	case existing.Review != nil && existing.Review.Status == models.AnalysisStatusCompleted:
+		return existing.Review, nil
+	case existing.Review != nil && existing.Review.Status != models.AnalysisStatusFailed:
+		// Pending or analyzing: wait for it below
+	default:
+		status, err := reviewSvc.Status(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !status.Enabled {
+			return nil, fmt.Errorf("code review is disabled on this Saturn instance (AI_CODE_REVIEW_ENABLED)")
+		}
+		triggered, err := reviewSvc.Trigger(ctx, deploymentUUID)
+		if err != nil {
+			return nil, err
+		}
+		if triggered.Review != nil && triggered.Review.Status == models.AnalysisStatusCompleted {
+			return triggered.Review, nil
+		}
+		previous = existing.Review
+	}
+
+	waitCtx, cancel, pollInterval := analysisWaitContext(cmd)
+	defer cancel()
+	fmt.Fprintf(cmd.ErrOrStderr(), "Reviewing the commit of deployment %s...\n", deploymentUUID)
+	review, err := reviewSvc.WaitForReview(waitCtx, deploymentUUID, pollInterval, previous)
+	if err != nil {
+		if waitCtx.Err() == context.DeadlineExceeded {
+			return nil, cli.WithExitCode(fmt.Errorf("timed out waiting for the code review of deployment %s", deploymentUUID), ExitCodeWaitTimeout)
+		}
+		return nil, err
+	}
+	return review, nil
+}
+
+// sortViolations orders violations by severity, most severe first, then by file and line
+func sortViolations(violations []models.CodeReviewViolation) {
+	line := func(v models.CodeReviewViolation) int {
+		if v.LineNumber == nil {
+			return 0
+		}
+		return *v.LineNumber
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if ra, rb := models.SeverityRank(a.Severity), models.SeverityRank(b.Severity); ra != rb {
+			return ra > rb
+		}
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return line(a) < line(b)
+	})
+}
+
+// failingViolations counts the violations at or above the failOn severity. Violations
+// hidden because they reveal secrets always count.
+func failingViolations(report *ReviewReport, failOn string) int {
+	threshold := models.SeverityRank(failOn)
+	failing := 0
+	for _, v := range report.Violations {
+		if models.SeverityRank(v.Severity) >= threshold {
+			failing++
+		}
+	}
+	if report.SecretsHidden {
+		failing += max(report.TotalCount-len(report.Violations), 0)
+	}
+	return failing
+}
+
+// printReview prints the violations of a sorted report grouped by severity and file
+func printReview(out io.Writer, report *ReviewReport) {
+	color := output.ColorEnabled(out)
+	fmt.Fprintf(out, "Code review of %s: %s, %d file(s), %d violation(s)\n",
+		models.ShortSHA(report.CommitSHA), report.Status, report.FilesCount, report.TotalCount)
+
+	severity, file := "", ""
+	for i, v := range report.Violations {
+		if i == 0 || v.Severity != severity {
+			severity, file = v.Severity, ""
+			count := 0
+			for _, other := range report.Violations {
+				if other.Severity == severity {
+					count++
+				}
+			}
+			fmt.Fprintf(out, "\n%s\n", output.Colorize(fmt.Sprintf("%s (%d)", strings.ToUpper(severity), count), severityColor(severity), color))
+		}
+		if v.FilePath != file {
+			file = v.FilePath
+			fmt.Fprintf(out, "  %s\n", output.Colorize(file, output.ColorBold, color))
+		}
+
+		location := "-"
+		if v.LineNumber != nil {
+			location = fmt.Sprintf("%d", *v.LineNumber)
+		}
+		fmt.Fprintf(out, "    %5s  %-8s %s\n", location, v.RuleID, v.Message)
+		if v.Suggestion != nil && *v.Suggestion != "" {
+			fmt.Fprintf(out, "    %5s  %-8s %s\n", "", "", output.Colorize("suggestion: "+*v.Suggestion, output.ColorGray, color))
+		}
+	}
+
+	if hidden := report.TotalCount - len(report.Violations); report.SecretsHidden && hidden > 0 {
+		fmt.Fprintf(out, "\n%d violation(s) revealing secrets are hidden; they are visible to users who can update the application\n", hidden)
+	}
+}
@@ -0,0 +1,80 @@
+package deployment
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+func violation(severity, file string, line int, rule string) models.CodeReviewViolation {
+	return models.CodeReviewViolation{Severity: severity, FilePath: file, LineNumber: &line, RuleID: rule, Message: rule + " found"}
+}
+
+func TestSortViolations(t *testing.T) {
+	violations := []models.CodeReviewViolation{
+		violation("low", "a.go", 1, "L1"),
+		violation("critical", "b.go", 9, "C2"),
+		violation("high", "a.go", 5, "H1"),
+		violation("critical", "b.go", 2, "C1"),
+		violation("critical", "a.go", 7, "C0"),
+	}
+	sortViolations(violations)
+
+	var rules []string
+	for _, v := range violations {
+		rules = append(rules, v.RuleID)
+	}
+	assert.Equal(t, []string{"C0", "C1", "C2", "H1", "L1"}, rules)
+}
+
+func TestFailingViolations(t *testing.T) {
+	report := &ReviewReport{
+		Violations: []models.CodeReviewViolation{
+			violation("critical", "a.go", 1, "C"),
+			violation("high", "a.go", 2, "H"),
+			violation("medium", "a.go", 3, "M"),
+		},
+		TotalCount: 3,
+	}
+	assert.Equal(t, 1, failingViolations(report, "critical"))
+	assert.Equal(t, 2, failingViolations(report, "high"))
+	assert.Equal(t, 3, failingViolations(report, "low"))
+
+	report.TotalCount, report.SecretsHidden = 5, true
+	assert.Equal(t, 3, failingViolations(report, "critical"), "hidden secret violations count as failing")
+}
+
+func TestPrintReview(t *testing.T) {
+	suggestion := "Move it to an environment variable"
+	v := violation("critical", "config/app.php", 12, "SEC001")
+	v.Suggestion = &suggestion
+	report := &ReviewReport{
+		CommitSHA:  "0123456789",
+		Status:     "Critical Issues",
+		FilesCount: 4,
+		Violations: []models.CodeReviewViolation{
+			v,
+			violation("critical", "config/app.php", 40, "SEC002"),
+			violation("low", "main.go", 3, "DNG001"),
+		},
+		TotalCount: 3,
+	}
+
+	var buf bytes.Buffer
+	printReview(&buf, report)
+	assert.Equal(t, `Code review of 0123456: Critical Issues, 4 file(s), 3 violation(s)
+
+CRITICAL (2)
+  config/app.php
+       12  SEC001   SEC001 found
+                    suggestion: Move it to an environment variable
+       40  SEC002   SEC002 found
+
+LOW (1)
+  main.go
+        3  DNG001   DNG001 found
+`, buf.String())
+}
@@ -0,0 +1,154 @@
+package deployment
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// rollbackOutcome is the result of rolling back an application after a failed deployment
+type rollbackOutcome struct {
+	Resource     string
+	Failed       service.WaitResult
+	TargetUUID   string
+	TargetCommit string
+	// RollbackUUID is the deployment performing the rollback and Status its final status
+	RollbackUUID string
+	Status       string
+	Err          error
+	// StillRunning is set when the wait, usually its --timeout, ended before the deployment
+	// did, so it was left running rather than rolled back
+	StillRunning bool
+}
+
+// rollbackOnFailure reports whether a deployment status calls for a rollback
+func rollbackOnFailure(status string) bool {
+	return status == "failed" || status == "timed-out"
+}
+
+// rollbackFailures rolls every application whose deployment failed or timed out back to
+// its most recent finished deployment, and waits for the rollbacks with a fresh --timeout.
+// Resources that are not applications, or were never deployed successfully, are reported
+// as outcomes with an error. Deployments still running when the wait ended are not rolled
+// back, since a rollback would queue behind them; they are reported as such.
+func rollbackFailures(cmd *cobra.Command, deploySvc *service.DeploymentService, targets []WaitTarget, results []service.WaitResult,
+	pollInterval time.Duration, onStatus service.StatusCallback, resources map[string]string) []rollbackOutcome {
+	out := humanOut(cmd)
+	events := newEventWriter(cmd)
+	timeoutSec, _ := cmd.Flags().GetInt("timeout")
+
+	byDeployment := make(map[string]WaitTarget, len(targets))
+	for _, t := range targets {
+		byDeployment[t.DeploymentUUID] = t
+	}
+
+	var outcomes []rollbackOutcome
+	seen := make(map[string]bool)
+	for _, res := range results {
+		target := byDeployment[res.DeploymentUUID]
+		if target.ResourceUUID == "" || seen[target.ResourceUUID] {
+			continue
+		}
+		if !service.IsTerminalStatus(res.Status) {
+			seen[target.ResourceUUID] = true
+			outcomes = append(outcomes, rollbackOutcome{Resource: target.Resource, Failed: res, StillRunning: true})
+			continue
+		}
+		if !rollbackOnFailure(res.Status) {
+			continue
+		}
+		seen[target.ResourceUUID] = true
+		outcome := rollbackOutcome{Resource: target.Resource, Failed: res}
+
+		previous, err := deploySvc.LatestFinished(cmd.Context(), target.ResourceUUID)
+		switch {
+		case err != nil:
+			outcome.Err = fmt.Errorf("cannot find a deployment to roll back to: %w", err)
+		case previous == nil:
+			outcome.Err = fmt.Errorf("no successful deployment to roll back to")
+		}
+		if outcome.Err != nil {
+			outcomes = append(outcomes, outcome)
+			continue
+		}
+		outcome.TargetUUID = previous.UUID
+		if previous.Commit != nil {
+			outcome.TargetCommit = *previous.Commit
+		}
+
+		fmt.Fprintf(out, "Rolling back %s to deployment %s (commit %s)...\n", target.Resource, previous.UUID, models.ShortSHA(outcome.TargetCommit))
+		rollback, err := deploySvc.ExecuteRollback(cmd.Context(), target.ResourceUUID, previous.UUID)
+		if err != nil {
+			outcome.Err = err
+		} else if rollback.DeploymentUUID == "" {
+			outcome.Err = fmt.Errorf("the server did not start a rollback deployment: %s", rollback.Message)
+		} else {
+			outcome.RollbackUUID = rollback.DeploymentUUID
+			resources[rollback.DeploymentUUID] = target.Resource
+			if events != nil {
+				_ = events.Emit(output.Event{Type: output.EventDeployStarted, Resource: target.Resource, DeploymentUUID: rollback.DeploymentUUID, Message: "rollback to " + previous.UUID})
+			}
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	var uuids []string
+	for _, o := range outcomes {
+		if o.RollbackUUID != "" {
+			uuids = append(uuids, o.RollbackUUID)
+		}
+	}
+	if len(uuids) == 0 {
+		return outcomes
+	}
+
+	ctx, deadline := newApprovalDeadline(cmd.Context(), time.Duration(timeoutSec)*time.Second)
+	defer deadline.Stop()
+	results, err := deploySvc.WaitForMultiple(ctx, uuids, pollInterval, onStatus)
+	statuses := make(map[string]string, len(results))
+	for _, r := range results {
+		statuses[r.DeploymentUUID] = r.Status
+		if events != nil {
+			_ = events.Emit(output.Event{Type: output.EventFinished, Resource: resources[r.DeploymentUUID], DeploymentUUID: r.DeploymentUUID, Status: r.Status})
+		}
+	}
+	for i, o := range outcomes {
+		if o.RollbackUUID == "" {
+			continue
+		}
+		outcomes[i].Status = statuses[o.RollbackUUID]
+		if err != nil && !service.IsTerminalStatus(outcomes[i].Status) {
+			if deadline.Exceeded() {
+				outcomes[i].Err = fmt.Errorf("timed out after %ds waiting for the rollback", timeoutSec)
+			} else {
+				outcomes[i].Err = err
+			}
+		}
+	}
+	return outcomes
+}
+
+// printRollbackSummary prints one line per rolled back application
+func printRollbackSummary(cmd *cobra.Command, outcomes []rollbackOutcome) {
+	w := cmd.ErrOrStderr()
+	fmt.Fprintln(w, "Rollback summary:")
+	for _, o := range outcomes {
+		failed := fmt.Sprintf("deployment %s %s", o.Failed.DeploymentUUID, o.Failed.Status)
+		switch {
+		case o.StillRunning:
+			fmt.Fprintf(w, "  %s: deployment %s was still running when the wait ended; not rolled back (cancel it with: saturn deploy cancel %s)\n",
+				o.Resource, o.Failed.DeploymentUUID, o.Failed.DeploymentUUID)
+		case o.Err != nil:
+			fmt.Fprintf(w, "  %s: %s; rollback failed: %v\n", o.Resource, failed, o.Err)
+		case o.Status == "finished":
+			fmt.Fprintf(w, "  %s: %s; rolled back to %s (commit %s) by deployment %s\n", o.Resource, failed, o.TargetUUID, models.ShortSHA(o.TargetCommit), o.RollbackUUID)
+		default:
+			fmt.Fprintf(w, "  %s: %s; rollback deployment %s ended with status %s\n", o.Resource, failed, o.RollbackUUID, o.Status)
+		}
+	}
+}
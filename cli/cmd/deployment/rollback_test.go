@@ -0,0 +1,88 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+func TestRollbackFailures(t *testing.T) {
+	rolledBack := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/deployments/applications/app-api":
+			_, _ = w.Write([]byte(`{"count":3,"deployments":[
+				{"deployment_uuid":"dep-bad","status":"failed","commit":"bbbbbbbb","created_at":"2026-01-03T00:00:00Z"},
+				{"deployment_uuid":"dep-good","status":"finished","commit":"aaaaaaaa","created_at":"2026-01-02T00:00:00Z"},
+				{"deployment_uuid":"dep-old","status":"finished","commit":"99999999","created_at":"2026-01-01T00:00:00Z"}]}`))
+		case "/api/v1/deployments/applications/app-new":
+			_, _ = w.Write([]byte(`{"count":1,"deployments":[{"deployment_uuid":"dep-first","status":"failed"}]}`))
+		case "/api/v1/applications/app-api/rollback/dep-good":
+			assert.Equal(t, http.MethodPost, r.Method)
+			rolledBack = true
+			_, _ = w.Write([]byte(`{"message":"Rollback initiated","deployment_uuid":"dep-rollback"}`))
+		case "/api/v1/deployments/dep-rollback":
+			_, _ = w.Write([]byte(`{"deployment_uuid":"dep-rollback","status":"finished"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("format", "table", "")
+	AddWaitFlags(cmd)
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetContext(context.Background())
+
+	targets := []WaitTarget{
+		{DeploymentUUID: "dep-bad", Resource: "api", ResourceUUID: "app-api"},
+		{DeploymentUUID: "dep-web", Resource: "web", ResourceUUID: "app-web"},
+		{DeploymentUUID: "dep-first", Resource: "new", ResourceUUID: "app-new"},
+		{DeploymentUUID: "dep-slow", Resource: "worker", ResourceUUID: "app-worker"},
+	}
+	results := []service.WaitResult{
+		{DeploymentUUID: "dep-bad", Status: "failed"},
+		{DeploymentUUID: "dep-web", Status: "finished", Finished: true},
+		{DeploymentUUID: "dep-first", Status: "timed-out"},
+		// The CLI's --timeout expired while this one was building
+		{DeploymentUUID: "dep-slow", Status: "in_progress"},
+	}
+
+	deploySvc := service.NewDeploymentService(api.NewClient(server.URL, "test-token"))
+	outcomes := rollbackFailures(cmd, deploySvc, targets, results, time.Millisecond, nil, map[string]string{})
+	require.Len(t, outcomes, 3)
+	assert.True(t, rolledBack)
+
+	assert.Equal(t, "api", outcomes[0].Resource)
+	assert.Equal(t, "dep-good", outcomes[0].TargetUUID)
+	assert.Equal(t, "dep-rollback", outcomes[0].RollbackUUID)
+	assert.Equal(t, "finished", outcomes[0].Status)
+	assert.NoError(t, outcomes[0].Err)
+
+	assert.Equal(t, "new", outcomes[1].Resource)
+	assert.ErrorContains(t, outcomes[1].Err, "no successful deployment to roll back to")
+
+	assert.Equal(t, "worker", outcomes[2].Resource)
+	assert.True(t, outcomes[2].StillRunning)
+	assert.Empty(t, outcomes[2].RollbackUUID)
+
+	printRollbackSummary(cmd, outcomes)
+	assert.Contains(t, stderr.String(), "api: deployment dep-bad failed; rolled back to dep-good (commit aaaaaaa) by deployment dep-rollback")
+	assert.Contains(t, stderr.String(), "new: deployment dep-first timed-out; rollback failed: no successful deployment to roll back to")
+	assert.Contains(t, stderr.String(), "worker: deployment dep-slow was still running when the wait ended; not rolled back (cancel it with: saturn deploy cancel dep-slow)")
+	assert.Contains(t, stdout.String(), "Rolling back api to deployment dep-good (commit aaaaaaa)...")
+}
@@ -0,0 +1,49 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// scopedApp is an application selected directly or through its project
+type scopedApp struct {
+	UUID string
+	Name string
+}
+
+// findProject returns the project with the given UUID or name, or nil when there is none
+func findProject(ctx context.Context, projectSvc *service.ProjectService, ref string) (*models.Project, error) {
+	projects, err := projectSvc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		if projects[i].UUID == ref || projects[i].Name == ref {
+			return &projects[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// projectApplications returns the applications in every environment of a project,
+// named after the application and its environment
+func projectApplications(ctx context.Context, projectSvc *service.ProjectService, projectUUID string) ([]scopedApp, error) {
+	project, err := projectSvc.Get(ctx, projectUUID)
+	if err != nil {
+		return nil, err
+	}
+	var apps []scopedApp
+	for _, env := range project.Environments {
+		resources, err := projectSvc.GetEnvironment(ctx, project.UUID, env.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load environment %s: %w", env.Name, err)
+		}
+		for _, a := range resources.Applications {
+			apps = append(apps, scopedApp{UUID: a.UUID, Name: a.Name + " (" + env.Name + ")"})
+		}
+	}
+	return apps, nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/saturn-platform/saturn-cli/internal/cli"
 	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
 	"github.com/saturn-platform/saturn-cli/internal/service"
 )
 
@@ -24,23 +25,54 @@ by matching the local git remote URL.
 
 Use --init to generate a .saturn.yml from your Saturn resources.
 
+Changes are counted from where HEAD forked from the base branch (--since merge-base),
+so commits that landed on the base branch since do not deploy anything. With
+--since last-deploy every component is compared against the commit of its
+resource's latest finished deployment instead, falling back to the merge-base for
+components never deployed. The base branch and deployed commits are fetched from
+origin when a shallow CI clone lacks them. --include-uncommitted adds the changes
+of the working tree.
+
+Paths are globs: * and ? match within a directory, ** across directories, [a-z]
+a character class and {api,web} any of the alternatives. path takes one pattern
+or a list. Files matching a component's ignore patterns do not deploy it; files
+matching the top-level ignore patterns deploy nothing.
+
+triggers deploy other components whenever a component changes, and are followed
+transitively. depends_on orders the deployment into waves: a component deploys
+once the components it depends on in the same run have finished, and the
+components of a wave deploy in parallel. Every wave but the last is waited for
+as with --wait, with --logs, approvals and --timeout applying to each wave. When
+a wave fails the later ones are skipped; --rollback-on-failure, the hooks and
+--report still cover every deployment started.
+
 Example .saturn.yml:
   version: 1
   base_branch: main
+  ignore: ["**/*.md", "**/__tests__/**"]
   components:
     api:
-      path: "apps/api/**"
+      path: ["apps/api/**", "go.{mod,sum}"]
+      ignore: "apps/api/docs/**"
       resource: "my-api-app"
+      depends_on: ["ui"]
     web:
       path: "apps/web/**"
       resource: "my-frontend"
+      depends_on: ["ui", "api"]
+    ui:
+      path: "packages/ui/**"
+      resource: "my-ui-lib"
+      triggers: ["api", "web"]
     shared:
       path: "packages/shared/**"
-      triggers: ["api", "web"]`,
+      triggers: ["ui"]`,
 		RunE: runSmart,
 	}
 
 	cmd.Flags().String("base", "", "Base branch for diff (default: from .saturn.yml or \"main\")")
+	cmd.Flags().String("since", service.SinceMergeBase, "Compare against the merge-base with the base branch, or each resource's last deployed commit (merge-base|last-deploy)")
+	cmd.Flags().Bool("include-uncommitted", false, "Include staged, unstaged and untracked changes of the working tree")
 	cmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
 	cmd.Flags().BoolP("force", "f", false, "Force rebuild all matched components")
 	cmd.Flags().Bool("init", false, "Generate .saturn.yml from Saturn API resources")
@@ -53,6 +85,8 @@ Example .saturn.yml:
 func runSmart(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
 	initMode, _ := cmd.Flags().GetBool("init")
+	out := humanOut(cmd)
+	events := newEventWriter(cmd)
 
 	client, err := cli.GetAPIClient(cmd)
 	if err != nil {
@@ -72,18 +106,23 @@ func runSmart(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get working directory: %w", err)
 	}
 
+	since, _ := cmd.Flags().GetString("since")
+	if err := service.ParseSince(since); err != nil {
+		return err
+	}
+
 	cfg, err := service.LoadConfig(dir)
 	if err != nil {
 		return err
 	}
 
 	if cfg == nil {
-		fmt.Fprintln(cmd.OutOrStdout(), "No .saturn.yml found, auto-detecting from API...")
+		fmt.Fprintln(out, "No .saturn.yml found, auto-detecting from API...")
 		cfg, err = smartSvc.AutoDetect(ctx)
 		if err != nil {
 			return err
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "Detected %d component(s)\n", len(cfg.Components))
+		fmt.Fprintf(out, "Detected %d component(s)\n", len(cfg.Components))
 	}
 
 	// Determine base branch
@@ -96,31 +135,47 @@ func runSmart(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Get changed files
-	files, err := service.GetChangedFiles(cmd.Context(), base)
+	includeUncommitted, _ := cmd.Flags().GetBool("include-uncommitted")
+	changes, err := smartSvc.GetChanges(ctx, cfg, service.ChangeOptions{
+		Since:              since,
+		Base:               base,
+		IncludeUncommitted: includeUncommitted,
+		OnFetch: func(what string) {
+			fmt.Fprintf(out, "Fetching %s from origin...\n", what)
+		},
+	})
 	if err != nil {
 		return err
 	}
 
-	if len(files) == 0 {
-		fmt.Fprintf(cmd.OutOrStdout(), "No files changed between %s and HEAD\n", base)
+	changed := false
+	for _, set := range changes {
+		if len(set.Files) > 0 {
+			changed = true
+		}
+		fmt.Fprintf(out, "%d file(s) changed since %s\n", len(set.Files), set.Label)
+	}
+	if !changed {
+		fmt.Fprintln(out, "No files changed")
 		return nil
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "%d file(s) changed since %s\n", len(files), base)
-
 	// Build deploy plan
-	plan, err := smartSvc.BuildDeployPlan(ctx, files, cfg)
+	plan, err := smartSvc.BuildDeployPlanFromChanges(ctx, changes, cfg)
 	if err != nil {
 		return err
 	}
 
 	if len(plan.Components) == 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "No components matched the changed files")
+		fmt.Fprintln(out, "No components matched the changed files")
 		return nil
 	}
 
 	// Display plan
 	printPlan(cmd, plan)
+	if events != nil {
+		_ = events.Emit(output.Event{Type: output.EventPlan, Message: fmt.Sprintf("%d component(s) to deploy", len(plan.Components)), Data: plan})
+	}
 
 	// --dry-run: stop here
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
@@ -131,23 +186,86 @@ func runSmart(cmd *cobra.Command, _ []string) error {
 	// Confirm
 	yes, _ := cmd.Flags().GetBool("yes")
 	if !yes {
-		fmt.Fprint(cmd.OutOrStdout(), "\nProceed with deployment? [y/N] ")
+		fmt.Fprint(out, "\nProceed with deployment? [y/N] ")
 		var answer string
 		if _, err := fmt.Fscanln(cmd.InOrStdin(), &answer); err != nil {
 			answer = ""
 		}
 		if !strings.HasPrefix(strings.ToLower(answer), "y") {
-			fmt.Fprintln(cmd.OutOrStdout(), "Aborted")
+			fmt.Fprintln(out, "Aborted")
 			return nil
 		}
 	}
 
-	// Execute deployment
+	// Execute deployment, one wave at a time. Later waves wait for the earlier ones, so
+	// those are waited for even without --wait.
 	force, _ := cmd.Flags().GetBool("force")
-	results, allUUIDs := smartSvc.ExecuteSmartDeploy(ctx, plan, force)
+	deploySvc := smartSvc.DeploymentService()
+	waitAll := waitRequested(cmd)
+	var waiter *deployWait
+	if waitAll || len(plan.Waves) > 1 {
+		if waiter, err = newDeployWait(cmd, deploySvc); err != nil {
+			return err
+		}
+	}
 
-	// Display results
-	printResults(cmd, results)
+	var results []models.SmartDeployResult
+	var targets []WaitTarget
+	var waitResults []service.WaitResult
+	var waitErr error
+	for wave := 1; wave <= len(plan.Waves); wave++ {
+		if len(plan.Waves) > 1 {
+			fmt.Fprintf(out, "\nWave %d/%d: %s\n", wave, len(plan.Waves), strings.Join(plan.Waves[wave-1], ", "))
+		}
+		waveResults, _ := smartSvc.ExecuteWave(ctx, plan, wave, force)
+		printResults(cmd, waveResults)
+		results = append(results, waveResults...)
+
+		var waveTargets []WaitTarget
+		for _, r := range waveResults {
+			for _, uuid := range r.DeploymentUUIDs {
+				waveTargets = append(waveTargets, WaitTarget{DeploymentUUID: uuid, Resource: r.ResourceName, ResourceUUID: r.ResourceUUID})
+			}
+			if events == nil {
+				continue
+			}
+			if !r.Success {
+				_ = events.Emit(output.Event{Type: output.EventError, Resource: r.ResourceName, Message: r.Error})
+			}
+			for _, uuid := range r.DeploymentUUIDs {
+				_ = events.Emit(output.Event{Type: output.EventDeployStarted, Resource: r.ResourceName, DeploymentUUID: uuid, Message: r.Message})
+			}
+		}
+		targets = append(targets, waveTargets...)
+
+		// Later waves depend on this one, so they only start once it deployed successfully
+		last := wave == len(plan.Waves)
+		reason := ""
+		for _, r := range waveResults {
+			if !r.Success {
+				reason = fmt.Sprintf("wave %d failed to deploy", wave)
+			}
+		}
+		if len(waveTargets) > 0 && (waitAll || (!last && reason == "")) {
+			if !last && reason == "" {
+				fmt.Fprintf(out, "Waiting for wave %d to finish before starting wave %d\n", wave, wave+1)
+			}
+			waveWait, err := waiter.Wait(waveTargets)
+			waitResults = append(waitResults, waveWait...)
+			if err != nil && waitErr == nil {
+				waitErr = err
+			}
+			if err != nil || !allFinished(waveWait) {
+				reason = fmt.Sprintf("wave %d did not finish", wave)
+			}
+		}
+		if reason != "" && !last {
+			skipped := skipLaterWaves(plan, wave, reason)
+			printResults(cmd, skipped)
+			results = append(results, skipped...)
+			break
+		}
+	}
 
 	// Summary
 	successCount := 0
@@ -157,14 +275,32 @@ func runSmart(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "\nSmart deploy complete: %d/%d succeeded\n", successCount, len(results))
+	fmt.Fprintf(out, "\nSmart deploy complete: %d/%d succeeded\n", successCount, len(results))
 
+	// Rollbacks, hooks and reports cover every deployment started, also after a wave failed
+	var waitExitErr error
+	if waitAll {
+		waitExitErr = waiter.Finish(targets, waitResults, waitErr)
+	} else if waiter != nil {
+		waitExitErr = waiter.ExitError(waitResults, waitErr)
+	}
+	if waitExitErr != nil {
+		return waitExitErr
+	}
 	if successCount < len(results) {
 		return fmt.Errorf("some deployments failed")
 	}
+	return nil
+}
 
-	// Handle --wait
-	return HandleWait(cmd, smartSvc.DeploymentService(), allUUIDs)
+// allFinished reports whether every waited deployment finished successfully
+func allFinished(results []service.WaitResult) bool {
+	for _, res := range results {
+		if !res.Finished {
+			return false
+		}
+	}
+	return true
 }
 
 func handleInit(cmd *cobra.Command, smartSvc *service.SmartDeployService) error {
@@ -186,31 +322,56 @@ func handleInit(cmd *cobra.Command, smartSvc *service.SmartDeployService) error
 
 	fmt.Fprintf(cmd.OutOrStdout(), "Generated .saturn.yml with %d component(s)\n", len(cfg.Components))
 	for name, comp := range cfg.Components {
-		fmt.Fprintf(cmd.OutOrStdout(), "  %s: path=%q resource=%q\n", name, comp.Path, comp.Resource)
+		fmt.Fprintf(cmd.OutOrStdout(), "  %s: path=%q resource=%q\n", name, strings.Join(comp.Path, ","), comp.Resource)
 	}
 
 	return nil
 }
 
 func printPlan(cmd *cobra.Command, plan *models.SmartDeployPlan) {
-	fmt.Fprintf(cmd.OutOrStdout(), "\nDeploy Plan (%d component(s), %d file(s) changed):\n", len(plan.Components), plan.FilesTotal)
-	fmt.Fprintf(cmd.OutOrStdout(), "%-20s %-25s %-8s %-10s %s\n", "COMPONENT", "RESOURCE", "FILES", "REASON", "TRIGGER")
-	fmt.Fprintf(cmd.OutOrStdout(), "%-20s %-25s %-8s %-10s %s\n", strings.Repeat("-", 20), strings.Repeat("-", 25), strings.Repeat("-", 8), strings.Repeat("-", 10), strings.Repeat("-", 15))
+	out := humanOut(cmd)
+	fmt.Fprintf(out, "\nDeploy Plan (%d component(s), %d file(s) changed):\n", len(plan.Components), plan.FilesTotal)
+	fmt.Fprintf(out, "%-6s %-20s %-25s %-8s %-10s %s\n", "WAVE", "COMPONENT", "RESOURCE", "FILES", "REASON", "TRIGGER")
+	fmt.Fprintf(out, "%-6s %-20s %-25s %-8s %-10s %s\n", strings.Repeat("-", 6), strings.Repeat("-", 20), strings.Repeat("-", 25), strings.Repeat("-", 8), strings.Repeat("-", 10), strings.Repeat("-", 15))
 
 	for _, c := range plan.Components {
 		trigger := ""
 		if c.TriggerBy != "" {
 			trigger = c.TriggerBy
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "%-20s %-25s %-8d %-10s %s\n", c.Name, c.ResourceName, c.FilesChanged, c.Reason, trigger)
+		fmt.Fprintf(out, "%-6d %-20s %-25s %-8d %-10s %s\n", c.Wave, c.Name, c.ResourceName, c.FilesChanged, c.Reason, trigger)
+	}
+
+	if len(plan.Waves) > 1 {
+		fmt.Fprintln(out, "\nWaves (each starts once the previous one finished deploying):")
+		for i, wave := range plan.Waves {
+			fmt.Fprintf(out, "  %d. %s\n", i+1, strings.Join(wave, ", "))
+		}
+	}
+}
+
+// skipLaterWaves reports the components of the waves after wave as not deployed
+func skipLaterWaves(plan *models.SmartDeployPlan, wave int, reason string) []models.SmartDeployResult {
+	var skipped []models.SmartDeployResult
+	for _, c := range plan.Components {
+		if c.Wave > wave {
+			skipped = append(skipped, models.SmartDeployResult{
+				Name:         c.Name,
+				ResourceName: c.ResourceName,
+				ResourceUUID: c.ResourceUUID,
+				Error:        "skipped: " + reason,
+			})
+		}
 	}
+	return skipped
 }
 
 func printResults(cmd *cobra.Command, results []models.SmartDeployResult) {
-	fmt.Fprintln(cmd.OutOrStdout(), "\nDeploy Results:")
+	out := humanOut(cmd)
+	fmt.Fprintln(out, "\nDeploy Results:")
 	for _, r := range results {
 		if r.Success {
-			fmt.Fprintf(cmd.OutOrStdout(), "  [OK]   %s (%s): %s\n", r.Name, r.ResourceName, r.Message)
+			fmt.Fprintf(out, "  [OK]   %s (%s): %s\n", r.Name, r.ResourceName, r.Message)
 		} else {
 			fmt.Fprintf(cmd.ErrOrStderr(), "  [FAIL] %s (%s): %s\n", r.Name, r.ResourceName, r.Error)
 		}
@@ -1,10 +1,14 @@
 package deployment
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
 )
 
 func TestNewSmartCommand_Flags(t *testing.T) {
@@ -89,3 +93,40 @@ func TestSmartCommand_RegisteredInDeployment(t *testing.T) {
 	}
 	assert.True(t, found, "smart command should be registered as subcommand of deploy")
 }
+
+func TestPrintPlan_Waves(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("format", "table", "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	printPlan(cmd, &models.SmartDeployPlan{
+		FilesTotal: 1,
+		Components: []models.SmartDeployComponent{
+			{Name: "lib", ResourceName: "my-lib", Reason: "direct", FilesChanged: 1, Wave: 1},
+			{Name: "api", ResourceName: "my-api", Reason: "triggered", TriggerBy: "lib", Wave: 2},
+			{Name: "web", ResourceName: "my-web", Reason: "triggered", TriggerBy: "lib", Wave: 2},
+		},
+		Waves: [][]string{{"lib"}, {"api", "web"}},
+	})
+
+	assert.Contains(t, out.String(), "WAVE   COMPONENT")
+	assert.Contains(t, out.String(), "2      api                  my-api                    0        triggered  lib\n")
+	assert.Contains(t, out.String(), "  1. lib\n  2. api, web\n")
+}
+
+func TestSkipLaterWaves(t *testing.T) {
+	plan := &models.SmartDeployPlan{
+		Components: []models.SmartDeployComponent{
+			{Name: "lib", Wave: 1},
+			{Name: "api", ResourceName: "my-api", Wave: 2},
+			{Name: "web", Wave: 3},
+		},
+	}
+
+	skipped := skipLaterWaves(plan, 1, "wave 1 did not finish")
+	assert.Len(t, skipped, 2)
+	assert.Equal(t, "api", skipped[0].Name)
+	assert.False(t, skipped[0].Success)
+	assert.Equal(t, "skipped: wave 1 did not finish", skipped[0].Error)
+}
@@ -0,0 +1,229 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/logfilter"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// histogramWidth is the length of the longest bar in the weekly histogram
+const histogramWidth = 40
+
+// NewStatsCommand computes deployment statistics from the deployment history
+func NewStatsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats [app|project]",
+		Short: "Show deployment frequency, success rate, build times and DORA-style metrics",
+		Long: `Show deployment statistics for an application, a project (name or UUID) or, without an
+argument, every application:
+
+  - deployment frequency: releases (successful deployments other than rollbacks) per week and per day
+  - success and failure rate of completed deployments
+  - p50 and p95 build duration, from queueing to the end of successful deployments
+  - change-failure rate: the share of the period's releases that were rolled back
+  - mean time to restore: from a rolled back deployment going live until its rollback completed
+
+Use --histogram for deployments per week, or --format json for the numbers and weekly counts.`,
+		Example: `  saturn deploy stats my-app
+  saturn deploy stats shop --since 90d
+  saturn deploy stats --since 2026-01-01 --histogram
+  saturn deploy stats shop --format json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runStats,
+	}
+
+	cmd.Flags().String("since", "30d", "Start of the period (e.g. 30d, 12h, 2026-01-02)")
+	cmd.Flags().Bool("histogram", false, "Show deployments per week as a histogram")
+	return cmd
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	now := time.Now()
+
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	since, err := logfilter.ParseTimeBound(sinceFlag, now)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if !since.Before(now) {
+		return fmt.Errorf("--since must be in the past")
+	}
+
+	client, err := cli.GetAPIClient(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get API client: %w", err)
+	}
+
+	ref := ""
+	if len(args) > 0 {
+		ref = args[0]
+	}
+	scope, apps, err := statsScope(ctx, client, ref)
+	if err != nil {
+		return err
+	}
+
+	deploySvc := service.NewDeploymentService(client)
+	histories := make([]service.DeploymentHistory, 0, len(apps))
+	for _, app := range apps {
+		history, err := deploySvc.History(ctx, app.UUID, since)
+		if err != nil {
+			return fmt.Errorf("failed to load the deployment history of %s: %w", app.Name, err)
+		}
+		histories = append(histories, *history)
+	}
+
+	stats := service.ComputeDeploymentStats(histories, since, now)
+	stats.Scope = scope
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != output.FormatTable {
+		formatter, err := output.NewFormatter(format, output.Options{})
+		if err != nil {
+			return fmt.Errorf("failed to create formatter: %w", err)
+		}
+		return formatter.Format(stats)
+	}
+
+	out := cmd.OutOrStdout()
+	if histogram, _ := cmd.Flags().GetBool("histogram"); histogram {
+		printStatsHistogram(out, stats)
+		return nil
+	}
+	printStats(out, stats)
+	return nil
+}
+
+// statsScope resolves the applications to compute statistics for: every application when ref
+// is empty, otherwise the application or the applications of the project ref names
+func statsScope(ctx context.Context, client *api.Client, ref string) (string, []scopedApp, error) {
+	apps, err := service.NewApplicationService(client).List(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	if ref == "" {
+		scope := make([]scopedApp, len(apps))
+		for i, a := range apps {
+			scope[i] = scopedApp{UUID: a.UUID, Name: a.Name}
+		}
+		return "all applications", scope, nil
+	}
+
+	var appMatches []scopedApp
+	for _, a := range apps {
+		if a.UUID == ref || a.Name == ref {
+			appMatches = append(appMatches, scopedApp{UUID: a.UUID, Name: a.Name})
+		}
+	}
+
+	projectSvc := service.NewProjectService(client)
+	project, err := findProject(ctx, projectSvc, ref)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch {
+	case len(appMatches) > 0 && project != nil:
+		return "", nil, fmt.Errorf("'%s' names both an application and a project; use the UUID instead", ref)
+	case len(appMatches) > 1:
+		return "", nil, fmt.Errorf("several applications are named '%s'; use the UUID instead", ref)
+	case len(appMatches) == 1:
+		return "application " + appMatches[0].Name, appMatches, nil
+	case project == nil:
+		return "", nil, fmt.Errorf("no application or project named '%s' found", ref)
+	}
+
+	scope, err := projectApplications(ctx, projectSvc, project.UUID)
+	if err != nil {
+		return "", nil, err
+	}
+	return "project " + project.Name, scope, nil
+}
+
+func printStats(out io.Writer, s models.DeploymentStats) {
+	color := output.ColorEnabled(out)
+
+	fmt.Fprintf(out, "%s\n", output.Colorize(fmt.Sprintf("Deployment stats for %s (%d applications), %s to %s",
+		s.Scope, s.Applications, s.Since.Format("2006-01-02"), s.Until.Format("2006-01-02")), output.ColorBold, color))
+
+	counts := fmt.Sprintf("%d succeeded, %d failed, %d cancelled", s.Succeeded, s.Failed, s.Cancelled)
+	if s.InProgress > 0 {
+		counts += fmt.Sprintf(", %d running", s.InProgress)
+	}
+	rows := [][2]string{
+		{"Deployments", fmt.Sprintf("%d (%s)", s.Deployments, counts)},
+		{"Deployment frequency", fmt.Sprintf("%.1f per week (%.1f per day)", s.DeploysPerWeek, s.DeploysPerDay)},
+		{"Success rate", formatPercent(s.SuccessRate)},
+		{"Failure rate", formatPercent(s.FailureRate)},
+		{"Build time p50", formatSeconds(s.BuildP50Seconds)},
+		{"Build time p95", formatSeconds(s.BuildP95Seconds)},
+		{"Change failure rate", fmt.Sprintf("%s (%d rollbacks)", formatPercent(s.ChangeFailureRate), s.Rollbacks)},
+		{"Mean time to restore", fmt.Sprintf("%s (%d restores)", formatSeconds(s.MTTRSeconds), s.Restores)},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(out, "  %-22s %s\n", row[0], row[1])
+	}
+}
+
+func printStatsHistogram(out io.Writer, s models.DeploymentStats) {
+	color := output.ColorEnabled(out)
+
+	fmt.Fprintf(out, "%s\n", output.Colorize(fmt.Sprintf("Deployments per week for %s", s.Scope), output.ColorBold, color))
+	maxTotal := 0
+	for _, w := range s.Weeks {
+		maxTotal = max(maxTotal, w.Succeeded+w.Failed+w.Other)
+	}
+	for _, w := range s.Weeks {
+		total := w.Succeeded + w.Failed + w.Other
+		fmt.Fprintf(out, "  %s  %s %d", w.Start.Format("2006-01-02"), histogramBar(w, maxTotal, histogramWidth, color), total)
+		if w.Failed > 0 {
+			fmt.Fprintf(out, " (%d failed)", w.Failed)
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "\n  █ succeeded  ▓ failed  ░ cancelled or running\n")
+}
+
+// histogramBar draws a week as a bar of succeeded, failed and other deployments, scaled so
+// that a week with maxTotal deployments is width characters long
+func histogramBar(w models.WeeklyDeployments, maxTotal, width int, color bool) string {
+	if maxTotal == 0 {
+		return ""
+	}
+	scale := func(n int) int {
+		if n == 0 {
+			return 0
+		}
+		// Round up so that a single deployment still shows
+		return max((n*width+maxTotal-1)/maxTotal, 1)
+	}
+	return output.Colorize(strings.Repeat("█", scale(w.Succeeded)), output.ColorGreen, color) +
+		output.Colorize(strings.Repeat("▓", scale(w.Failed)), output.ColorRed, color) +
+		output.Colorize(strings.Repeat("░", scale(w.Other)), output.ColorGray, color)
+}
+
+func formatPercent(p *float64) string {
+	if p == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", *p)
+}
+
+func formatSeconds(s *float64) string {
+	if s == nil {
+		return "-"
+	}
+	return time.Duration(*s * float64(time.Second)).Round(time.Second).String()
+}
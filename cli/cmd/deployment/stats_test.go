@@ -0,0 +1,56 @@
+package deployment
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestHistogramBar(t *testing.T) {
+	week := models.WeeklyDeployments{Succeeded: 6, Failed: 1, Other: 1}
+	assert.Equal(t, "██████▓░", histogramBar(week, 8, 8, false))
+
+	// Scaled down, a single failure still shows
+	assert.Equal(t, "███▓░", histogramBar(week, 16, 8, false))
+
+	assert.Equal(t, "", histogramBar(models.WeeklyDeployments{}, 0, 8, false))
+}
+
+func TestPrintStats(t *testing.T) {
+	stats := models.DeploymentStats{
+		Scope:             "project shop",
+		Since:             time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Until:             time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Applications:      2,
+		Deployments:       10,
+		Succeeded:         8,
+		Failed:            2,
+		DeploysPerDay:     8.0 / 30,
+		DeploysPerWeek:    8.0 / 30 * 7,
+		SuccessRate:       floatPtr(80),
+		FailureRate:       floatPtr(20),
+		BuildP50Seconds:   floatPtr(192.4),
+		BuildP95Seconds:   floatPtr(460),
+		Rollbacks:         1,
+		ChangeFailureRate: floatPtr(12.5),
+	}
+
+	var buf bytes.Buffer
+	printStats(&buf, stats)
+	out := buf.String()
+
+	assert.Contains(t, out, "Deployment stats for project shop (2 applications), 2026-03-01 to 2026-03-31")
+	assert.Contains(t, out, "10 (8 succeeded, 2 failed, 0 cancelled)")
+	assert.Contains(t, out, "1.9 per week (0.3 per day)")
+	assert.Contains(t, out, "Success rate           80.0%")
+	assert.Contains(t, out, "Build time p50         3m12s")
+	assert.Contains(t, out, "Build time p95         7m40s")
+	assert.Contains(t, out, "12.5% (1 rollbacks)")
+	assert.Contains(t, out, "Mean time to restore   - (0 restores)")
+}
@@ -1,6 +1,8 @@
 package deployment
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -21,53 +23,114 @@ func NewUUIDCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "uuid <uuid>",
 		Short: "Deploy by uuid",
-		Args:  cli.ExactArgs(1, "<uuid>"),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := cmd.Context()
-			uuid := args[0]
-
-			client, err := cli.GetAPIClient(cmd)
-			if err != nil {
-				return fmt.Errorf("failed to get API client: %w", err)
-			}
+		Long: `Deploy a resource by UUID.
 
-			force, _ := cmd.Flags().GetBool("force")
-			deploySvc := service.NewDeploymentService(client)
-			result, err := deploySvc.Deploy(ctx, uuid, force)
-			if err != nil {
-				return fmt.Errorf("failed to deploy resource: %w", err)
-			}
+For an application, --commit or --image-tag deploys a specific git commit or Docker image
+tag. The application stays pinned to it for later deployments unless --restore-pin is given,
+which puts back the previous commit or tag once the deployment ends. When the wait stops
+before that, the pin is left in place and the command to restore it is printed.`,
+		Example: `  saturn deploy uuid <uuid> --wait
+  saturn deploy uuid <app-uuid> --commit 3f2a9c1 --restore-pin
+  saturn deploy uuid <app-uuid> --image-tag v1.4.2`,
+		Args: cli.ExactArgs(1, "<uuid>"),
+		RunE: runDeployUUID,
+	}
 
-			format, _ := cmd.Flags().GetString("format")
-			formatter, err := output.NewFormatter(format, output.Options{})
-			if err != nil {
-				return err
-			}
+	cmd.Flags().Bool("force", false, "Force deployment")
+	addPinFlags(cmd)
+	AddWaitFlags(cmd)
+	return cmd
+}
 
-			// For table format, convert deployment info array to display format
-			if format == output.FormatTable {
-				displays := make([]ResultDisplay, len(result.Deployments))
-				for i, dep := range result.Deployments {
-					displays[i] = ResultDisplay{
-						Message:        dep.Message,
-						DeploymentUUID: dep.DeploymentUUID,
-					}
-				}
-				if err := formatter.Format(displays); err != nil {
-					return err
+func runDeployUUID(cmd *cobra.Command, args []string) (err error) {
+	ctx := cmd.Context()
+	uuid := args[0]
+
+	pin, err := pinFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	restore, _ := cmd.Flags().GetBool("restore-pin")
+	if restore {
+		// The pin must stay in place until the build has checked out the commit or pulled the image
+		_ = cmd.Flags().Set("wait", "true")
+	}
+
+	client, err := cli.GetAPIClient(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get API client: %w", err)
+	}
+
+	deploySvc := service.NewDeploymentService(client)
+	var targets []WaitTarget
+	if pin != nil {
+		appSvc := service.NewApplicationService(client)
+		previous, pinErr := applyPin(ctx, appSvc, uuid, *pin)
+		if pinErr != nil {
+			return pinErr
+		}
+		fmt.Fprintf(humanOut(cmd), "Pinned application %s to %s (was %s)\n", uuid, pin, previous)
+		if restore {
+			defer func() {
+				// Restore even when the wait was interrupted, but only once the deployment has ended
+				ctx := context.WithoutCancel(ctx)
+				if !deploymentsEnded(ctx, deploySvc, targets) {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Application %s is left on %s because its deployment has not ended yet. Once it has, restore %s with:\n  %s\n",
+						uuid, pin, previous, previous.restoreCommand(uuid))
+					return
 				}
-			} else {
-				if err := formatter.Format(result); err != nil {
-					return err
+				if restoreErr := restorePin(ctx, appSvc, uuid, *previous); restoreErr != nil {
+					err = errors.Join(err, restoreErr)
+					return
 				}
+				fmt.Fprintf(humanOut(cmd), "Restored %s on application %s\n", previous, uuid)
+			}()
+		}
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	result, err := deploySvc.Deploy(ctx, uuid, force)
+	if err != nil {
+		return fmt.Errorf("failed to deploy resource: %w", err)
+	}
+
+	targets = CollectWaitTargets(result, uuid)
+	if err := printDeployResult(cmd, result, targets); err != nil {
+		return err
+	}
+
+	// Handle --wait flag
+	return HandleWait(cmd, deploySvc, targets)
+}
+
+// printDeployResult prints the result of a deploy request in the selected format.
+// With --format ndjson a deploy_started event is emitted per deployment instead.
+func printDeployResult(cmd *cobra.Command, result *service.DeployResponse, targets []WaitTarget) error {
+	if events := newEventWriter(cmd); events != nil {
+		for _, t := range targets {
+			if err := events.Emit(output.Event{Type: output.EventDeployStarted, Resource: t.Resource, DeploymentUUID: t.DeploymentUUID}); err != nil {
+				return err
 			}
+		}
+		return nil
+	}
 
-			// Handle --wait flag
-			return HandleWait(cmd, deploySvc, CollectDeploymentUUIDs(result))
-		},
+	format, _ := cmd.Flags().GetString("format")
+	formatter, err := output.NewFormatter(format, output.Options{})
+	if err != nil {
+		return err
 	}
 
-	cmd.Flags().Bool("force", false, "Force deployment")
-	AddWaitFlags(cmd)
-	return cmd
+	// For table format, convert deployment info array to display format
+	if format == output.FormatTable {
+		displays := make([]ResultDisplay, len(result.Deployments))
+		for i, dep := range result.Deployments {
+			displays[i] = ResultDisplay{
+				Message:        dep.Message,
+				DeploymentUUID: dep.DeploymentUUID,
+			}
+		}
+		return formatter.Format(displays)
+	}
+	return formatter.Format(result)
 }
@@ -1,93 +1,291 @@
 package deployment
 
 import (
-	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
 	"github.com/saturn-platform/saturn-cli/internal/service"
 )
 
 const (
 	// ExitCodeSuccess means all deployments finished successfully
 	ExitCodeSuccess = 0
-	// ExitCodeFailed means one or more deployments failed/cancelled/timed-out
+	// ExitCodeFailed means one or more deployments failed, timed out or, with --wait, were cancelled
 	ExitCodeFailed = 1
 	// ExitCodeWaitTimeout means the --timeout was exceeded while waiting
-	ExitCodeWaitTimeout = 2
+	ExitCodeWaitTimeout = cli.ExitCodeWaitTimeout
+	// ExitCodeCancelled means the deployment followed by deploy logs --follow was cancelled
+	ExitCodeCancelled = 3
 )
 
-// AddWaitFlags adds --wait, --timeout, and --poll-interval flags to a deploy command
+// ExitCodeForStatus maps a terminal deployment status to the exit code of deploy logs --follow
+func ExitCodeForStatus(status string) int {
+	switch status {
+	case "finished":
+		return ExitCodeSuccess
+	case "cancelled-by-user", "cancelled":
+		return ExitCodeCancelled
+	default:
+		return ExitCodeFailed
+	}
+}
+
+// deploymentStatusError returns nil for a finished deployment and an error carrying
+// the matching exit code otherwise
+func deploymentStatusError(deploymentUUID, status string) error {
+	code := ExitCodeForStatus(status)
+	if code == ExitCodeSuccess {
+		return nil
+	}
+	return cli.WithExitCode(fmt.Errorf("deployment %s ended with status %s", deploymentUUID, status), code)
+}
+
+// WaitTarget is a deployment to wait for, labelled with the resource it deploys
+type WaitTarget struct {
+	DeploymentUUID string
+	Resource       string
+	ResourceUUID   string
+}
+
+// AddWaitFlags adds the flags HandleWait reads to a deploy command: --wait, --timeout,
+// --poll-interval and --logs, --rollback-on-failure, the --on-success and
+// --on-failure hooks with --notify, --report, and --mask
 func AddWaitFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolP("wait", "w", false, "Wait for deployment to complete before exiting")
 	cmd.Flags().Int("timeout", 600, "Timeout in seconds when using --wait (default 600)")
 	cmd.Flags().Int("poll-interval", 3, "Poll interval in seconds when using --wait (default 3)")
+	cmd.Flags().Bool("logs", false, "Print the build logs while waiting (implies --wait)")
+	cmd.Flags().Bool("rollback-on-failure", false, "When a deployment fails or times out on the server, roll the application back to its last successful deployment (implies --wait)")
+	addHookFlags(cmd)
+	addReportFlags(cmd)
+	cli.AddLogMaskFlags(cmd)
+}
+
+// waitRequested reports whether a flag asks HandleWait to wait for the deployments
+func waitRequested(cmd *cobra.Command) bool {
+	wait, _ := cmd.Flags().GetBool("wait")
+	showLogs, _ := cmd.Flags().GetBool("logs")
+	rollback, _ := cmd.Flags().GetBool("rollback-on-failure")
+	return wait || showLogs || rollback || hooksRequested(cmd) || reportsRequested(cmd)
 }
 
 // HandleWait checks if --wait was set and blocks until all deployments complete.
 // Returns nil if --wait was not set. Returns an error if deployments failed or timed out.
-func HandleWait(cmd *cobra.Command, deploySvc *service.DeploymentService, deploymentUUIDs []string) error {
-	wait, _ := cmd.Flags().GetBool("wait")
-	if !wait || len(deploymentUUIDs) == 0 {
+// With --logs, the build logs are printed while waiting.
+// With --rollback-on-failure, applications whose deployment failed are rolled back.
+// The --on-success and --on-failure hooks, or those from the config file, run for every
+// deployment once the wait ends, followed by the --notify notification.
+// The --report files are written last; in GitHub Actions a step summary, error
+// annotations and step outputs are written as well.
+// With --format ndjson, progress is emitted as status_changed, finished and error events.
+// Deployments waiting for approval keep being waited for, with the timeout paused.
+func HandleWait(cmd *cobra.Command, deploySvc *service.DeploymentService, targets []WaitTarget) error {
+	if !waitRequested(cmd) || len(targets) == 0 {
 		return nil
 	}
 
+	w, err := newDeployWait(cmd, deploySvc)
+	if err != nil {
+		return err
+	}
+	results, err := w.Wait(targets)
+	return w.Finish(targets, results, err)
+}
+
+// deployWait waits for deployments the way HandleWait does. Commands that start deployments
+// in steps, like the waves of a smart deploy, call Wait for every step and Finish once.
+type deployWait struct {
+	cmd          *cobra.Command
+	deploySvc    *service.DeploymentService
+	hooks        deployHooks
+	reports      []deployReport
+	timeoutSec   int
+	pollInterval time.Duration
+	events       *output.EventWriter
+
+	// Track last printed status per UUID to avoid spamming
+	mu         sync.Mutex
+	lastStatus map[string]string
+	resources  map[string]string
+	// deadline is the --timeout of the current Wait, and timedOut records that one ran out
+	deadline *approvalDeadline
+	timedOut bool
+}
+
+// newDeployWait reads the wait flags and the hooks, failing on invalid ones before anything is waited for
+func newDeployWait(cmd *cobra.Command, deploySvc *service.DeploymentService) (*deployWait, error) {
+	hooks, err := loadHooks(cmd)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := parseReports(cmd)
+	if err != nil {
+		return nil, err
+	}
 	timeoutSec, _ := cmd.Flags().GetInt("timeout")
 	pollSec, _ := cmd.Flags().GetInt("poll-interval")
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), time.Duration(timeoutSec)*time.Second)
-	defer cancel()
+	return &deployWait{
+		cmd:          cmd,
+		deploySvc:    deploySvc,
+		hooks:        hooks,
+		reports:      reports,
+		timeoutSec:   timeoutSec,
+		pollInterval: time.Duration(pollSec) * time.Second,
+		events:       newEventWriter(cmd),
+		lastStatus:   make(map[string]string),
+		resources:    make(map[string]string),
+	}, nil
+}
 
-	pollInterval := time.Duration(pollSec) * time.Second
+// onStatus prints the status changes of waited deployments
+func (w *deployWait) onStatus(uuid, status string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.lastStatus[uuid] == status {
+		return
+	}
+	w.lastStatus[uuid] = status
+	cmd := w.cmd
 
-	// Track last printed status per UUID to avoid spamming
-	lastStatus := make(map[string]string)
+	// Waiting for a person to approve does not count towards --timeout
+	pending := status == service.StatusPendingApproval
+	if w.deadline != nil {
+		w.deadline.SetPending(uuid, pending)
+	}
+	var approval *models.DeploymentApproval
+	if pending {
+		approval = approvalStatus(cmd.Context(), cmd, uuid)
+	}
 
-	onStatus := func(uuid, status string) {
-		if lastStatus[uuid] != status {
-			lastStatus[uuid] = status
-			fmt.Fprintf(cmd.OutOrStdout(), "  [%s] %s\n", uuid, status)
+	if w.events != nil {
+		event := output.Event{Type: output.EventStatusChanged, Resource: w.resources[uuid], DeploymentUUID: uuid, Status: status}
+		if pending {
+			event.Message = approvalWaitMessage(approval)
+			if approval != nil {
+				event.Data = approval
+			}
 		}
+		_ = w.events.Emit(event)
+		return
 	}
+	fmt.Fprintf(cmd.OutOrStdout(), "  [%s] %s\n", uuid, status)
+	if pending {
+		fmt.Fprintf(cmd.OutOrStdout(), "    %s\n", approvalWaitMessage(approval))
+		fmt.Fprintf(cmd.OutOrStdout(), "    to approve: saturn approvals approve %s (the timeout is paused meanwhile)\n", uuid)
+	}
+}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Waiting for %d deployment(s) to complete (timeout: %ds)...\n", len(deploymentUUIDs), timeoutSec)
+// Wait blocks until the deployments of targets end or --timeout runs out, printing their
+// status changes, or their build logs with --logs, and then their final status
+func (w *deployWait) Wait(targets []WaitTarget) ([]service.WaitResult, error) {
+	cmd := w.cmd
+	showLogs, _ := cmd.Flags().GetBool("logs")
 
-	results, err := deploySvc.WaitForMultiple(ctx, deploymentUUIDs, pollInterval, onStatus)
+	ctx, deadline := newApprovalDeadline(cmd.Context(), time.Duration(w.timeoutSec)*time.Second)
+	defer deadline.Stop()
+
+	deploymentUUIDs := make([]string, len(targets))
+	w.mu.Lock()
+	w.deadline = deadline
+	for i, t := range targets {
+		deploymentUUIDs[i] = t.DeploymentUUID
+		w.resources[t.DeploymentUUID] = t.Resource
+	}
+	w.mu.Unlock()
+
+	fmt.Fprintf(humanOut(cmd), "Waiting for %d deployment(s) to complete (timeout: %ds)...\n", len(deploymentUUIDs), w.timeoutSec)
+
+	var results []service.WaitResult
+	var err error
+	if showLogs {
+		results, err = followTargets(ctx, cmd, w.deploySvc, targets, w.pollInterval, w.onStatus)
+	} else {
+		results, err = w.deploySvc.WaitForMultiple(ctx, deploymentUUIDs, w.pollInterval, w.onStatus)
+	}
+	if err != nil && deadline.Exceeded() {
+		w.timedOut = true
+	}
 
-	// Print final summary
-	allSuccess := true
 	for _, res := range results {
+		if w.events != nil {
+			_ = w.events.Emit(output.Event{Type: output.EventFinished, Resource: w.resources[res.DeploymentUUID], DeploymentUUID: res.DeploymentUUID, Status: res.Status})
+			continue
+		}
 		if res.Finished {
 			fmt.Fprintf(cmd.OutOrStdout(), "  [%s] finished\n", res.DeploymentUUID)
 		} else {
-			allSuccess = false
 			fmt.Fprintf(cmd.ErrOrStderr(), "  [%s] %s\n", res.DeploymentUUID, res.Status)
 		}
 	}
+	return results, err
+}
 
-	if err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
-			return fmt.Errorf("wait timeout exceeded (%ds), exit code %d", timeoutSec, ExitCodeWaitTimeout)
+// Finish rolls back failed deployments with --rollback-on-failure, runs the hooks and writes
+// the reports over every waited deployment, and returns the error the command exits with.
+// waitErr is the first error a Wait returned.
+func (w *deployWait) Finish(targets []WaitTarget, results []service.WaitResult, waitErr error) error {
+	cmd := w.cmd
+	if rollback, _ := cmd.Flags().GetBool("rollback-on-failure"); rollback {
+		if outcomes := rollbackFailures(cmd, w.deploySvc, targets, results, w.pollInterval, w.onStatus, w.resources); len(outcomes) > 0 {
+			printRollbackSummary(cmd, outcomes)
 		}
-		return fmt.Errorf("error waiting for deployments: %w", err)
 	}
 
-	if !allSuccess {
-		return fmt.Errorf("one or more deployments did not finish successfully")
+	runDeployHooks(cmd, w.deploySvc, w.hooks, targets, results)
+	reportErr := writeReports(cmd, w.deploySvc, w.reports, targets, results)
+
+	err := w.ExitError(results, waitErr)
+	if err == nil {
+		err = reportErr
+	}
+	if err != nil && w.events != nil {
+		_ = w.events.Emit(output.Event{Type: output.EventError, Message: err.Error()})
+	}
+	return err
+}
+
+// ExitError returns the error, with its exit code, for the outcome of the waits: 2 when
+// --timeout ran out, otherwise 1 when any deployment did not finish, cancelled ones included
+func (w *deployWait) ExitError(results []service.WaitResult, waitErr error) error {
+	if waitErr != nil {
+		if w.timedOut {
+			return cli.WithExitCode(fmt.Errorf("wait timeout exceeded (%ds), exit code %d", w.timeoutSec, ExitCodeWaitTimeout), ExitCodeWaitTimeout)
+		}
+		return fmt.Errorf("error waiting for deployments: %w", waitErr)
 	}
 
+	for _, res := range results {
+		if !res.Finished {
+			return cli.WithExitCode(fmt.Errorf("one or more deployments did not finish successfully"), ExitCodeFailed)
+		}
+	}
 	return nil
 }
 
-// CollectDeploymentUUIDs extracts deployment UUIDs from a DeployResponse
-func CollectDeploymentUUIDs(result *service.DeployResponse) []string {
-	uuids := make([]string, 0, len(result.Deployments))
+// CollectWaitTargets extracts the deployments to wait for from a DeployResponse.
+// resource labels the deployments; when empty the resource UUID is used instead.
+func CollectWaitTargets(result *service.DeployResponse, resource string) []WaitTarget {
+	targets := make([]WaitTarget, 0, len(result.Deployments))
 	for _, dep := range result.Deployments {
-		if dep.DeploymentUUID != "" {
-			uuids = append(uuids, dep.DeploymentUUID)
+		if dep.DeploymentUUID == "" {
+			continue
+		}
+		label := resource
+		if label == "" {
+			label = dep.ResourceUUID
 		}
+		targets = append(targets, WaitTarget{
+			DeploymentUUID: dep.DeploymentUUID,
+			Resource:       label,
+			ResourceUUID:   dep.ResourceUUID,
+		})
 	}
-	return uuids
+	return targets
 }
@@ -0,0 +1,104 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+func deploymentStatusServer(t *testing.T, statuses map[string]string) *service.DeploymentService {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		uuid := r.URL.Path[len("/api/v1/deployments/"):]
+		status, ok := statuses[uuid]
+		if !ok {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"deployment_uuid":"` + uuid + `","status":"` + status + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return service.NewDeploymentService(api.NewClient(server.URL, "test-token"))
+}
+
+func TestDeployWait_FinishCoversEveryWait(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands below use sh")
+	}
+	t.Setenv("HOME", t.TempDir())
+	deploySvc := deploymentStatusServer(t, map[string]string{"dep-ui": "finished", "dep-api": "failed"})
+
+	cmd := hookCommand(t, "--poll-interval", "1", "--on-failure", `echo "failed $SATURN_RESOURCE"`)
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetContext(context.Background())
+
+	w, err := newDeployWait(cmd, deploySvc)
+	require.NoError(t, err)
+
+	// Two waves, waited for one after the other
+	uiWave := []WaitTarget{{DeploymentUUID: "dep-ui", Resource: "ui"}}
+	apiWave := []WaitTarget{{DeploymentUUID: "dep-api", Resource: "api"}}
+	results, err := w.Wait(uiWave)
+	require.NoError(t, err)
+	more, err := w.Wait(apiWave)
+	require.NoError(t, err)
+	results = append(results, more...)
+
+	err = w.Finish(append(uiWave, apiWave...), results, nil)
+	assert.EqualError(t, err, "one or more deployments did not finish successfully")
+	assert.Equal(t, ExitCodeFailed, cli.ExitCode(err))
+	assert.Contains(t, stdout.String(), "  [dep-ui] finished\n")
+	assert.Contains(t, stdout.String(), "failed api\n")
+	assert.Contains(t, stderr.String(), "  [dep-api] failed\n")
+}
+
+func TestDeployWait_Timeout(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	deploySvc := deploymentStatusServer(t, map[string]string{"dep-slow": "in_progress"})
+
+	cmd := hookCommand(t, "--poll-interval", "1", "--timeout", "1")
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetContext(context.Background())
+
+	w, err := newDeployWait(cmd, deploySvc)
+	require.NoError(t, err)
+	results, waitErr := w.Wait([]WaitTarget{{DeploymentUUID: "dep-slow", Resource: "worker"}})
+	require.Error(t, waitErr)
+
+	err = w.ExitError(results, waitErr)
+	assert.EqualError(t, err, "wait timeout exceeded (1s), exit code 2")
+	assert.Equal(t, ExitCodeWaitTimeout, cli.ExitCode(err))
+}
+
+func TestDeployWait_ExitError(t *testing.T) {
+	w := &deployWait{}
+	assert.NoError(t, w.ExitError([]service.WaitResult{{Status: "finished", Finished: true}}, nil))
+
+	// A cancelled deployment exits like a failed one, as it always has with --wait
+	err := w.ExitError([]service.WaitResult{{Status: "cancelled-by-user"}}, nil)
+	assert.Equal(t, ExitCodeFailed, cli.ExitCode(err))
+
+	err = w.ExitError([]service.WaitResult{{Status: "finished", Finished: true}, {Status: "failed"}}, nil)
+	assert.Equal(t, ExitCodeFailed, cli.ExitCode(err))
+}
+
+func TestExitCodeForStatus(t *testing.T) {
+	assert.Equal(t, ExitCodeSuccess, ExitCodeForStatus("finished"))
+	assert.Equal(t, ExitCodeFailed, ExitCodeForStatus("failed"))
+	assert.Equal(t, ExitCodeCancelled, ExitCodeForStatus("cancelled-by-user"))
+}
@@ -0,0 +1,293 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/logfilter"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// NewLogsCommand tails the logs of several resources at once
+func NewLogsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs [name|uuid...]",
+		Short: "Tail the logs of several resources at once",
+		Long: `Show the logs of applications, services and databases side by side, each line
+prefixed with the resource it comes from. Resources are given by name or UUID,
+or all resources of a project (and optionally one environment) are selected with
+--project and --env. Services get one prefix per container.
+
+Without --follow the current tails are printed, merged by time when every line
+carries a timestamp. JSON and logfmt lines are rendered as in "app logs"; pass
+--raw to print them unchanged.
+
+When the output is not a terminal, the values of the applications' and services'
+environment variables are replaced with ******** . Use --mask to mask on a
+terminal as well, or --show-sensitive to never mask.`,
+		Example: `  saturn logs api worker redis -f
+  saturn logs --project shop --env production --since 10m --level error`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, _ := cmd.Flags().GetString("project")
+			env, _ := cmd.Flags().GetString("env")
+			if len(args) == 0 && project == "" {
+				return fmt.Errorf("specify resources by name or UUID, or a project with --project")
+			}
+			if env != "" && project == "" {
+				return fmt.Errorf("--env requires --project")
+			}
+
+			client, err := cli.GetAPIClient(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to get API client: %w", err)
+			}
+
+			ctx := cmd.Context()
+			var resources []models.EnvironmentResource
+			if project != "" {
+				resources, err = projectResources(ctx, client, project, env, args)
+			} else {
+				resources, err = namedResources(ctx, client, args)
+			}
+			if err != nil {
+				return err
+			}
+
+			// Validate the filter flags once; every stream gets its own filter state
+			if _, err := cli.NewLogFilter(cmd); err != nil {
+				return err
+			}
+			newFilter := func() *logfilter.Filter {
+				filter, _ := cli.NewLogFilter(cmd)
+				return filter
+			}
+
+			lines, _ := cmd.Flags().GetInt("lines")
+			follow, _ := cmd.Flags().GetBool("follow")
+			pollSec, _ := cmd.Flags().GetInt("poll-interval")
+
+			var events *output.EventWriter
+			if format, _ := cmd.Flags().GetString("format"); format == output.FormatNDJSON {
+				events = output.NewEventWriter(cmd.OutOrStdout())
+			}
+			t := newTailer(cmd.OutOrStdout(), cmd.ErrOrStderr(), events, newFilter)
+			t.renderer = cli.NewLogRenderer(cmd, cmd.OutOrStdout())
+			t.masker, err = cli.NewLogMasker(ctx, cmd, cmd.OutOrStdout(), func(ctx context.Context) ([]string, error) {
+				return service.NewSecretService(client).ResourceSecrets(ctx, resources)
+			})
+			if err != nil {
+				return err
+			}
+			sources := newSources(client, resources, lines)
+
+			if !follow {
+				return t.Snapshot(ctx, sources)
+			}
+
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			t.Follow(ctx, sources, time.Duration(max(pollSec, 1))*time.Second)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("project", "", "Tail every resource of this project (name or UUID)")
+	cmd.Flags().String("env", "", "With --project, only tail resources of this environment (name or UUID)")
+	cmd.Flags().IntP("lines", "n", 100, "Number of log lines to retrieve per resource")
+	cmd.Flags().BoolP("follow", "f", false, "Keep printing new log lines")
+	cmd.Flags().Int("poll-interval", 2, "Poll interval in seconds when following")
+	cli.AddLogFilterFlags(cmd)
+	cli.AddLogRenderFlags(cmd)
+	cli.AddLogMaskFlags(cmd)
+	return cmd
+}
+
+// resourceKind maps the type reported by the resources endpoint to the kinds the
+// environment endpoint uses: application, service or database
+func resourceKind(resourceType string) string {
+	switch resourceType {
+	case models.ResourceTypeApplication, models.ResourceTypeService:
+		return resourceType
+	default:
+		return models.ResourceTypeDatabase
+	}
+}
+
+// namedResources resolves names and UUIDs against every resource of the team
+func namedResources(ctx context.Context, client *api.Client, args []string) ([]models.EnvironmentResource, error) {
+	all, err := service.NewResourceService(client).List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+
+	resources := make([]models.EnvironmentResource, 0, len(args))
+	for _, arg := range args {
+		var matches []models.Resource
+		for _, r := range all {
+			if r.UUID == arg || r.Name == arg {
+				matches = append(matches, r)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("resource '%s' not found", arg)
+		case 1:
+			r := matches[0]
+			resources = append(resources, models.EnvironmentResource{UUID: r.UUID, Name: r.Name, Status: r.Status, Type: resourceKind(r.Type)})
+		default:
+			uuids := make([]string, len(matches))
+			for i, r := range matches {
+				uuids[i] = r.UUID
+			}
+			return nil, fmt.Errorf("name '%s' matches %d resources (%s); use a UUID or --project", arg, len(matches), strings.Join(uuids, ", "))
+		}
+	}
+	return uniqueLabels(resources), nil
+}
+
+// projectResources lists the resources of a project, optionally limited to one environment
+// and to the given names
+func projectResources(ctx context.Context, client *api.Client, projectRef, envRef string, names []string) ([]models.EnvironmentResource, error) {
+	projectSvc := service.NewProjectService(client)
+	projects, err := projectSvc.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	projectUUID := ""
+	for _, p := range projects {
+		if p.UUID == projectRef || p.Name == projectRef {
+			projectUUID = p.UUID
+			break
+		}
+	}
+	if projectUUID == "" {
+		return nil, fmt.Errorf("project '%s' not found", projectRef)
+	}
+
+	project, err := projectSvc.Get(ctx, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	var resources []models.EnvironmentResource
+	found := false
+	for _, e := range project.Environments {
+		if envRef != "" && e.UUID != envRef && e.Name != envRef {
+			continue
+		}
+		found = true
+		env, err := projectSvc.GetEnvironment(ctx, projectUUID, e.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get environment %s: %w", e.Name, err)
+		}
+		resources = append(resources, env.All()...)
+	}
+	if !found {
+		return nil, fmt.Errorf("environment '%s' not found in project '%s'", envRef, projectRef)
+	}
+
+	if len(names) > 0 {
+		wanted := make(map[string]bool, len(names))
+		for _, n := range names {
+			wanted[n] = true
+		}
+		filtered := resources[:0]
+		for _, r := range resources {
+			if wanted[r.Name] || wanted[r.UUID] {
+				filtered = append(filtered, r)
+			}
+		}
+		resources = filtered
+	}
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("no matching resources in project '%s'", projectRef)
+	}
+	return uniqueLabels(resources), nil
+}
+
+// uniqueLabels disambiguates resources sharing a name, e.g. the same app in two
+// environments, by appending the start of their UUID
+func uniqueLabels(resources []models.EnvironmentResource) []models.EnvironmentResource {
+	counts := make(map[string]int, len(resources))
+	for _, r := range resources {
+		counts[r.Name]++
+	}
+	for i, r := range resources {
+		if counts[r.Name] > 1 {
+			resources[i].Name = fmt.Sprintf("%s@%s", r.Name, r.UUID[:min(len(r.UUID), 6)])
+		}
+	}
+	return resources
+}
+
+// newSources creates a log source for each resource
+func newSources(client *api.Client, resources []models.EnvironmentResource, lines int) []Source {
+	appSvc := service.NewApplicationService(client)
+	serviceSvc := service.NewService(client)
+	databaseSvc := service.NewDatabaseService(client)
+
+	sources := make([]Source, 0, len(resources))
+	for _, res := range resources {
+		src := Source{Name: res.Name}
+		switch res.Type {
+		case models.ResourceTypeApplication:
+			src.Fetch = func(ctx context.Context) (map[string]string, error) {
+				resp, err := appSvc.Logs(ctx, res.UUID, lines)
+				if err != nil {
+					return nil, err
+				}
+				return map[string]string{res.Name: resp.Logs}, nil
+			}
+		case models.ResourceTypeService:
+			src.Fetch = func(ctx context.Context) (map[string]string, error) {
+				resp, err := serviceSvc.Logs(ctx, res.UUID, lines, "")
+				if err != nil {
+					return nil, err
+				}
+				return serviceStreams(res.Name, resp), nil
+			}
+		default:
+			src.Fetch = func(ctx context.Context) (map[string]string, error) {
+				resp, err := databaseSvc.Logs(ctx, res.UUID, lines)
+				if err != nil {
+					return nil, err
+				}
+				return map[string]string{res.Name: resp.Logs}, nil
+			}
+		}
+		sources = append(sources, src)
+	}
+	return sources
+}
+
+// serviceStreams splits service logs into one stream per container, labelled
+// "service/container" when the service has more than one
+func serviceStreams(name string, resp *models.ServiceLogsResponse) map[string]string {
+	streams := make(map[string]string, len(resp.Containers))
+	for key, c := range resp.Containers {
+		if c.Logs == nil {
+			continue
+		}
+		label := name
+		if len(resp.Containers) > 1 {
+			container := c.Name
+			if container == "" {
+				container = key
+			}
+			label = name + "/" + container
+		}
+		streams[label] = *c.Logs
+	}
+	return streams
+}
@@ -0,0 +1,248 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/saturn-platform/saturn-cli/internal/cli"
+	"github.com/saturn-platform/saturn-cli/internal/logfilter"
+	"github.com/saturn-platform/saturn-cli/internal/logmask"
+	"github.com/saturn-platform/saturn-cli/internal/logrender"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// prefixColors are assigned to streams in turn; red is left out so it keeps meaning "error"
+var prefixColors = []string{output.ColorCyan, output.ColorGreen, output.ColorYellow, output.ColorMagenta, output.ColorBlue}
+
+// FetchFunc returns the current log tail of a resource, keyed by stream label.
+// Most resources have a single stream; services have one per container.
+type FetchFunc func(ctx context.Context) (map[string]string, error)
+
+// Source is a resource whose logs are tailed
+type Source struct {
+	Name  string
+	Fetch FetchFunc
+}
+
+// stream is the state of one labelled log stream
+type stream struct {
+	label  string
+	color  string
+	last   string
+	filter *logfilter.Filter
+	// warned is set once the stream was reported as having no timestamps
+	warned bool
+}
+
+// tailer interleaves the logs of several sources, prefixing every line with its stream label
+type tailer struct {
+	out       io.Writer
+	errOut    io.Writer
+	events    *output.EventWriter
+	color     bool
+	newFilter func() *logfilter.Filter
+	// renderer formats JSON and logfmt lines; nil prints them unchanged
+	renderer *logrender.Renderer
+	// masker hides secrets; nil prints lines as they are
+	masker *logmask.Masker
+
+	mu        sync.Mutex
+	streams   map[string]*stream
+	labelSize int
+}
+
+func newTailer(out, errOut io.Writer, events *output.EventWriter, newFilter func() *logfilter.Filter) *tailer {
+	return &tailer{
+		out:       out,
+		errOut:    errOut,
+		events:    events,
+		color:     output.ColorEnabled(out),
+		newFilter: newFilter,
+		streams:   make(map[string]*stream),
+	}
+}
+
+// stream returns the state for label, creating it on first use. Callers hold t.mu.
+func (t *tailer) stream(label string) *stream {
+	st, ok := t.streams[label]
+	if !ok {
+		st = &stream{label: label, color: prefixColors[len(t.streams)%len(prefixColors)], filter: t.newFilter()}
+		t.streams[label] = st
+		t.labelSize = max(t.labelSize, len(label))
+	}
+	return st
+}
+
+// writeLines prints lines of a stream that pass its filter. Callers hold t.mu.
+func (t *tailer) writeLines(st *stream, lines []string) {
+	for _, line := range lines {
+		line = t.masker.Mask(line)
+		if !st.filter.Match(line) {
+			continue
+		}
+		if t.events != nil {
+			event := output.Event{Type: output.EventLog, Resource: st.label, Message: line}
+			if t.renderer != nil {
+				event.Data = t.renderer.Normalize(line)
+			}
+			_ = t.events.Emit(event)
+			continue
+		}
+		if t.renderer != nil {
+			line = t.renderer.Text(line)
+		}
+		prefix := fmt.Sprintf("[%s]%s ", st.label, strings.Repeat(" ", t.labelSize-len(st.label)))
+		fmt.Fprintln(t.out, output.Colorize(prefix, st.color, t.color)+line)
+	}
+	if st.filter.Undated() && !st.warned {
+		fmt.Fprintf(t.errOut, "[%s] ", st.label)
+		cli.WarnUndatedLogs(t.errOut)
+		st.warned = true
+	}
+}
+
+// Snapshot fetches every source once and prints the result. When every line carries a
+// timestamp the streams are merged chronologically, otherwise they are printed one after another.
+func (t *tailer) Snapshot(ctx context.Context, sources []Source) error {
+	results := make([]map[string]string, len(sources))
+	errs := make([]error, len(sources))
+
+	var wg sync.WaitGroup
+	for i, src := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = src.Fetch(ctx)
+		}()
+	}
+	wg.Wait()
+
+	var records []logRecord
+	failed := 0
+	for i, src := range sources {
+		if errs[i] != nil {
+			fmt.Fprintf(t.errOut, "[%s] failed to fetch logs: %v\n", src.Name, errs[i])
+			failed++
+			continue
+		}
+		for _, label := range sortedLabels(results[i]) {
+			t.mu.Lock()
+			t.stream(label)
+			t.mu.Unlock()
+			records = append(records, timestampLines(label, results[i][label])...)
+		}
+	}
+
+	if allTimestamped(records) {
+		sort.SliceStable(records, func(a, b int) bool { return records[a].time.Before(records[b].time) })
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, r := range records {
+		t.writeLines(t.streams[r.label], []string{r.line})
+	}
+	if failed == len(sources) {
+		return fmt.Errorf("failed to fetch logs of every resource")
+	}
+	return nil
+}
+
+// Follow prints the current tail of every source and then polls each for new lines
+// until ctx is done
+func (t *tailer) Follow(ctx context.Context, sources []Source, interval time.Duration) {
+	var wg sync.WaitGroup
+	for _, src := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.follow(ctx, src, interval)
+		}()
+	}
+	wg.Wait()
+}
+
+func (t *tailer) follow(ctx context.Context, src Source, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastErr := ""
+	for {
+		logs, err := src.Fetch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		// Report failures once, not on every poll
+		if err != nil {
+			if err.Error() != lastErr {
+				fmt.Fprintf(t.errOut, "[%s] failed to fetch logs: %v\n", src.Name, err)
+			}
+			lastErr = err.Error()
+		} else {
+			lastErr = ""
+		}
+
+		t.mu.Lock()
+		for _, label := range sortedLabels(logs) {
+			st := t.stream(label)
+			if fresh := service.NewLogLines(st.last, logs[label]); fresh != "" {
+				t.writeLines(st, strings.Split(strings.TrimSuffix(fresh, "\n"), "\n"))
+			}
+			st.last = logs[label]
+		}
+		t.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// logRecord is a line with the timestamp detected in it, or inherited from the line before
+type logRecord struct {
+	label string
+	line  string
+	time  time.Time
+}
+
+func timestampLines(label, logs string) []logRecord {
+	if logs == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(logs, "\n"), "\n")
+	records := make([]logRecord, len(lines))
+	var last time.Time
+	for i, line := range lines {
+		if ts, ok := logfilter.DetectTimestamp(line); ok {
+			last = ts
+		}
+		records[i] = logRecord{label: label, line: line, time: last}
+	}
+	return records
+}
+
+func allTimestamped(records []logRecord) bool {
+	for _, r := range records {
+		if r.time.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedLabels(logs map[string]string) []string {
+	labels := make([]string, 0, len(logs))
+	for label := range logs {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
@@ -0,0 +1,136 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/logfilter"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+func staticSource(name string, logs map[string]string) Source {
+	return Source{Name: name, Fetch: func(context.Context) (map[string]string, error) { return logs, nil }}
+}
+
+func newTestTailer(out, errOut *bytes.Buffer) *tailer {
+	return newTailer(out, errOut, nil, func() *logfilter.Filter { return &logfilter.Filter{} })
+}
+
+func TestTailer_Snapshot_MergesByTime(t *testing.T) {
+	var out, errOut bytes.Buffer
+	tl := newTestTailer(&out, &errOut)
+
+	err := tl.Snapshot(context.Background(), []Source{
+		staticSource("api", map[string]string{"api": "2025-10-15T12:00:01Z started\n2025-10-15T12:00:03Z ready\n"}),
+		staticSource("db", map[string]string{"db": "2025-10-15T12:00:02Z listening\n"}),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "[api] 2025-10-15T12:00:01Z started\n"+
+		"[db]  2025-10-15T12:00:02Z listening\n"+
+		"[api] 2025-10-15T12:00:03Z ready\n", out.String())
+	assert.Empty(t, errOut.String())
+}
+
+func TestTailer_Snapshot_WithoutTimestampsKeepsOrder(t *testing.T) {
+	var out, errOut bytes.Buffer
+	tl := newTestTailer(&out, &errOut)
+
+	err := tl.Snapshot(context.Background(), []Source{
+		staticSource("b", map[string]string{"b": "two\n"}),
+		staticSource("a", map[string]string{"a": "one\n"}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "[b] two\n[a] one\n", out.String())
+}
+
+func TestTailer_Snapshot_UndatedLinesWarn(t *testing.T) {
+	var out, errOut bytes.Buffer
+	tl := newTailer(&out, &errOut, nil, func() *logfilter.Filter {
+		return &logfilter.Filter{Since: time.Now().Add(-time.Hour)}
+	})
+
+	err := tl.Snapshot(context.Background(), []Source{
+		staticSource("api", map[string]string{"api": "started\nready\n"}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "[api] started\n[api] ready\n", out.String())
+	assert.Equal(t, "[api] Warning: no log line has a timestamp, so --since and --until were not applied\n", errOut.String())
+}
+
+func TestTailer_Snapshot_Errors(t *testing.T) {
+	failing := Source{Name: "broken", Fetch: func(context.Context) (map[string]string, error) {
+		return nil, errors.New("boom")
+	}}
+
+	t.Run("partial failure is reported", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		err := newTestTailer(&out, &errOut).Snapshot(context.Background(), []Source{failing, staticSource("ok", map[string]string{"ok": "line\n"})})
+		require.NoError(t, err)
+		assert.Contains(t, errOut.String(), "[broken] failed to fetch logs: boom")
+		assert.Equal(t, "[ok] line\n", out.String())
+	})
+
+	t.Run("all failing returns an error", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		err := newTestTailer(&out, &errOut).Snapshot(context.Background(), []Source{failing})
+		assert.Error(t, err)
+	})
+}
+
+func TestTailer_Follow_PrintsNewLines(t *testing.T) {
+	tails := []string{"a\nb\n", "a\nb\n", "b\nc\n"}
+	calls := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := Source{Name: "api", Fetch: func(context.Context) (map[string]string, error) {
+		logs := tails[min(calls, len(tails)-1)]
+		calls++
+		if calls >= len(tails) {
+			cancel()
+		}
+		return map[string]string{"api": logs}, nil
+	}}
+
+	var out, errOut bytes.Buffer
+	newTestTailer(&out, &errOut).Follow(ctx, []Source{src}, time.Millisecond)
+
+	// The last fetch races with cancellation, so only the lines before it are certain
+	assert.True(t, strings.HasPrefix(out.String(), "[api] a\n[api] b\n"), out.String())
+	assert.NotContains(t, out.String(), "[api] a\n[api] b\n[api] a\n")
+}
+
+func TestServiceStreams(t *testing.T) {
+	web, worker := "web logs", "worker logs"
+
+	single := serviceStreams("app", &models.ServiceLogsResponse{Containers: map[string]models.ServiceContainerLogs{
+		"web": {Name: "web", Logs: &web},
+	}})
+	assert.Equal(t, map[string]string{"app": "web logs"}, single)
+
+	multi := serviceStreams("app", &models.ServiceLogsResponse{Containers: map[string]models.ServiceContainerLogs{
+		"web":    {Name: "web", Logs: &web},
+		"worker": {Logs: &worker},
+		"cache":  {Name: "cache"},
+	}})
+	assert.Equal(t, map[string]string{"app/web": "web logs", "app/worker": "worker logs"}, multi)
+}
+
+func TestUniqueLabels(t *testing.T) {
+	resources := uniqueLabels([]models.EnvironmentResource{
+		{UUID: "abcdef123", Name: "api"},
+		{UUID: "987654321", Name: "api"},
+		{UUID: "x", Name: "db"},
+	})
+	assert.Equal(t, "api@abcdef", resources[0].Name)
+	assert.Equal(t, "api@987654", resources[1].Name)
+	assert.Equal(t, "db", resources[2].Name)
+}
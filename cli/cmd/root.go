@@ -10,12 +10,15 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/saturn-platform/saturn-cli/cmd/application"
+	"github.com/saturn-platform/saturn-cli/cmd/approvals"
 	"github.com/saturn-platform/saturn-cli/cmd/completion"
 	configcmd "github.com/saturn-platform/saturn-cli/cmd/config"
 	"github.com/saturn-platform/saturn-cli/cmd/context"
+	"github.com/saturn-platform/saturn-cli/cmd/dashboard"
 	"github.com/saturn-platform/saturn-cli/cmd/database"
 	"github.com/saturn-platform/saturn-cli/cmd/deployment"
 	"github.com/saturn-platform/saturn-cli/cmd/github"
+	"github.com/saturn-platform/saturn-cli/cmd/logs"
 	"github.com/saturn-platform/saturn-cli/cmd/privatekeys"
 	"github.com/saturn-platform/saturn-cli/cmd/project"
 	"github.com/saturn-platform/saturn-cli/cmd/resources"
@@ -24,6 +27,7 @@ import (
 	"github.com/saturn-platform/saturn-cli/cmd/teams"
 	"github.com/saturn-platform/saturn-cli/cmd/update"
 	cliversion "github.com/saturn-platform/saturn-cli/cmd/version"
+	"github.com/saturn-platform/saturn-cli/internal/cli"
 	"github.com/saturn-platform/saturn-cli/internal/config"
 	"github.com/saturn-platform/saturn-cli/internal/version"
 )
@@ -59,7 +63,7 @@ var rootCmd = &cobra.Command{
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
 
@@ -80,18 +84,21 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&Token, "token", "", "", "Token for authentication (override context token)")
 	rootCmd.PersistentFlags().StringVarP(&ContextName, "context", "", "", "Use specific context by name")
 
-	rootCmd.PersistentFlags().StringVarP(&Format, "format", "", "table", "Format output (table|json|pretty)")
+	rootCmd.PersistentFlags().StringVarP(&Format, "format", "", "table", "Format output (table|json|pretty|ndjson)")
 	rootCmd.PersistentFlags().BoolVarP(&ShowSensitive, "show-sensitive", "s", false, "Show sensitive information")
 	rootCmd.PersistentFlags().BoolVarP(&Debug, "debug", "", false, "Debug mode")
 
 	// Register all subcommands
 	rootCmd.AddCommand(application.NewAppCommand())
+	rootCmd.AddCommand(approvals.NewApprovalsCommand())
 	rootCmd.AddCommand(completion.NewCompletionsCommand())
 	rootCmd.AddCommand(configcmd.NewConfigCommand())
 	rootCmd.AddCommand(context.NewContextCommand())
+	rootCmd.AddCommand(dashboard.NewDashboardCommand())
 	rootCmd.AddCommand(database.NewDatabaseCommand())
 	rootCmd.AddCommand(deployment.NewDeploymentCommand())
 	rootCmd.AddCommand(github.NewGitHubCommand())
+	rootCmd.AddCommand(logs.NewLogsCommand())
 	rootCmd.AddCommand(privatekeys.NewPrivateKeysCommand())
 	rootCmd.AddCommand(project.NewProjectCommand())
 	rootCmd.AddCommand(resources.NewResourceCommand())
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -12,12 +13,11 @@ import (
 
 // NewListCommand creates the list command
 func NewListCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all servers",
+		Long:  `List all servers. Use --watch to keep the list refreshed.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			ctx := cmd.Context()
-
 			// Get API client
 			client, err := cli.GetAPIClient(cmd)
 			if err != nil {
@@ -26,31 +26,30 @@ func NewListCommand() *cobra.Command {
 
 			// Use service layer
 			serverSvc := service.NewServerService(client)
-			servers, err := serverSvc.List(ctx)
-			if err != nil {
-				return fmt.Errorf("failed to list servers: %w", err)
-			}
 
-			// Use output formatter
 			format, _ := cmd.Flags().GetString("format")
 			showSensitive, _ := cmd.Flags().GetBool("show-sensitive")
-
-			formatter, err := output.NewFormatter(format, output.Options{
-				ShowSensitive: showSensitive,
+			watch, _ := cmd.Flags().GetString("watch")
+
+			err = cli.RunWithWatch(cmd, output.Options{ShowSensitive: showSensitive}, func(ctx context.Context) (any, error) {
+				servers, err := serverSvc.List(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list servers: %w", err)
+				}
+				return servers, nil
 			})
 			if err != nil {
 				return err
 			}
 
-			if err := formatter.Format(servers); err != nil {
-				return err
-			}
-
-			if !showSensitive && format == output.FormatTable {
+			if !showSensitive && format == output.FormatTable && watch == "" {
 				fmt.Println("\nNote: Use -s to show sensitive information.")
 			}
 
 			return nil
 		},
 	}
+
+	cli.AddWatchFlags(cmd)
+	return cmd
 }
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -11,13 +12,18 @@ import (
 
 // NewValidateCommand creates the validate command
 func NewValidateCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "validate <uuid>",
 		Args:  cli.ExactArgs(1, "<uuid>"),
 		Short: "Validate a server",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
+			until, err := cli.ResourceWaitUntil(cmd)
+			if err != nil {
+				return err
+			}
+
 			// Get API client
 			client, err := cli.GetAPIClient(cmd)
 			if err != nil {
@@ -39,7 +45,16 @@ func NewValidateCommand() *cobra.Command {
 				fmt.Printf("Server %s validated successfully\n", uuid)
 			}
 
+			// Validation runs in the background, the server settings show its outcome
+			if cli.ResourceWaitRequested(cmd) {
+				return cli.WaitForResource(cmd, "server", uuid, until, func(ctx context.Context) (service.ResourceStatus, error) {
+					return serverSvc.Status(ctx, uuid)
+				}, 0)
+			}
 			return nil
 		},
 	}
+
+	cli.AddResourceWaitFlags(cmd, service.ServerReachableUsable)
+	return cmd
 }
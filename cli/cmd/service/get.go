@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -12,13 +13,12 @@ import (
 
 // NewGetCommand gets service details
 func NewGetCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "get <uuid>",
 		Short: "Get service details",
-		Long:  `Get detailed information about a specific service.`,
+		Long:  `Get detailed information about a specific service. Use --watch to keep it refreshed.`,
 		Args:  cli.ExactArgs(1, "<uuid>"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := cmd.Context()
 			uuid := args[0]
 
 			client, err := cli.GetAPIClient(cmd)
@@ -27,17 +27,16 @@ func NewGetCommand() *cobra.Command {
 			}
 
 			serviceSvc := service.NewService(client)
-			svc, err := serviceSvc.Get(ctx, uuid)
-			if err != nil {
-				return fmt.Errorf("failed to get service: %w", err)
-			}
-
-			formatter, err := output.NewFormatter("table", output.Options{})
-			if err != nil {
-				return fmt.Errorf("failed to create formatter: %w", err)
-			}
-
-			return formatter.Format(svc)
+			return cli.RunWithWatch(cmd, output.Options{}, func(ctx context.Context) (any, error) {
+				svc, err := serviceSvc.Get(ctx, uuid)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get service: %w", err)
+				}
+				return svc, nil
+			})
 		},
 	}
+
+	cli.AddWatchFlags(cmd)
+	return cmd
 }
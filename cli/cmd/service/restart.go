@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -11,7 +12,7 @@ import (
 
 // NewRestartCommand restarts a service
 func NewRestartCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "restart <uuid>",
 		Short: "Restart a service",
 		Long:  `Restart a service (restart all containers).`,
@@ -20,6 +21,11 @@ func NewRestartCommand() *cobra.Command {
 			ctx := cmd.Context()
 			uuid := args[0]
 
+			until, err := cli.ResourceWaitUntil(cmd)
+			if err != nil {
+				return err
+			}
+
 			client, err := cli.GetAPIClient(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to get API client: %w", err)
@@ -32,7 +38,15 @@ func NewRestartCommand() *cobra.Command {
 			}
 
 			fmt.Println(resp.Message)
+			if cli.ResourceWaitRequested(cmd) {
+				return cli.WaitForResource(cmd, "service", uuid, until, func(ctx context.Context) (service.ResourceStatus, error) {
+					return serviceSvc.Status(ctx, uuid)
+				}, cli.RestartSettle)
+			}
 			return nil
 		},
 	}
+
+	cli.AddResourceWaitFlags(cmd, service.ResourceRunningHealthy)
+	return cmd
 }
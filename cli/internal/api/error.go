@@ -56,6 +56,15 @@ func IsBadRequest(err error) bool {
 	return false
 }
 
+// IsRateLimited checks if the error is a 429 Too Many Requests error
+func IsRateLimited(err error) bool {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == 429
+	}
+	return false
+}
+
 // IsServerError checks if the error is a 5xx server error
 func IsServerError(err error) bool {
 	var apiErr *Error
@@ -0,0 +1,58 @@
+// Package ci writes deployment results in formats CI systems understand: JUnit XML
+// reports, and GitHub Actions step summaries, annotations and step outputs.
+package ci
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Result is the outcome of one deployment
+type Result struct {
+	Resource       string        `json:"resource"`
+	DeploymentUUID string        `json:"deployment_uuid"`
+	Status         string        `json:"status"`
+	Commit         string        `json:"commit,omitempty"`
+	Duration       time.Duration `json:"-"`
+	Passed         bool          `json:"passed"`
+	// Log is the end of the build log of a failed deployment
+	Log string `json:"-"`
+}
+
+// appendToFile appends content to the file at path, which CI runners create beforehand
+func appendToFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(content); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// failedCount returns how many results did not pass
+func failedCount(results []Result) int {
+	failed := 0
+	for _, r := range results {
+		if !r.Passed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// formatDuration renders a duration rounded to seconds, or "-" when it is unknown
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
+
+// failureMessage describes why a deployment did not pass
+func failureMessage(r Result) string {
+	return fmt.Sprintf("deployment %s ended with status %s", r.DeploymentUUID, r.Status)
+}
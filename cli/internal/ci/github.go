@@ -0,0 +1,131 @@
+package ci
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// InGitHubActions reports whether the CLI runs in a GitHub Actions workflow
+func InGitHubActions() bool {
+	return os.Getenv("GITHUB_ACTIONS") == "true"
+}
+
+// MarkdownSummary renders results as a Markdown table for a GitHub step summary
+func MarkdownSummary(title string, results []Result) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n\n", title)
+	if failed := failedCount(results); failed > 0 {
+		fmt.Fprintf(&b, "%d of %d deployments did not finish.\n\n", failed, len(results))
+	} else {
+		fmt.Fprintf(&b, "All %d deployments finished.\n\n", len(results))
+	}
+	b.WriteString("| | Resource | Status | Commit | Duration | Deployment |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	for _, r := range results {
+		icon := "✅"
+		if !r.Passed {
+			icon = "❌"
+		}
+		commit := "-"
+		if r.Commit != "" {
+			commit = "`" + models.ShortSHA(r.Commit) + "`"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | `%s` |\n",
+			icon, markdownCell(r.Resource), r.Status, commit, formatDuration(r.Duration), r.DeploymentUUID)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// WriteStepSummary appends the Markdown summary of results to $GITHUB_STEP_SUMMARY.
+// It does nothing when the variable is not set.
+func WriteStepSummary(title string, results []Result) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+	if err := appendToFile(path, MarkdownSummary(title, results)); err != nil {
+		return fmt.Errorf("failed to write the step summary: %w", err)
+	}
+	return nil
+}
+
+// WriteAnnotations writes an ::error workflow command for every failed deployment
+func WriteAnnotations(w io.Writer, results []Result) {
+	for _, r := range results {
+		if r.Passed {
+			continue
+		}
+		title := "Deployment of " + r.Resource + " failed"
+		fmt.Fprintf(w, "::error title=%s::%s\n", escapeProperty(title), escapeData(failureMessage(r)))
+	}
+}
+
+// Outputs returns the step outputs describing results:
+//   - status: "success" when every deployment finished, "failure" otherwise
+//   - deployment_uuids: the deployment UUIDs, comma-separated
+//   - failed_resources: the resources whose deployment did not finish, comma-separated
+//   - results: every result as a JSON array
+func Outputs(results []Result) (map[string]string, error) {
+	status := "success"
+	uuids := make([]string, len(results))
+	var failed []string
+	for i, r := range results {
+		uuids[i] = r.DeploymentUUID
+		if !r.Passed {
+			status = "failure"
+			failed = append(failed, r.Resource)
+		}
+	}
+	data, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"status":           status,
+		"deployment_uuids": strings.Join(uuids, ","),
+		"failed_resources": strings.Join(failed, ","),
+		"results":          string(data),
+	}, nil
+}
+
+// WriteOutputs appends the outputs of results to $GITHUB_OUTPUT, in a stable order.
+// It does nothing when the variable is not set.
+func WriteOutputs(results []Result) error {
+	path := os.Getenv("GITHUB_OUTPUT")
+	if path == "" {
+		return nil
+	}
+	outputs, err := Outputs(results)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, name := range []string{"status", "deployment_uuids", "failed_resources", "results"} {
+		fmt.Fprintf(&b, "%s=%s\n", name, outputs[name])
+	}
+	if err := appendToFile(path, b.String()); err != nil {
+		return fmt.Errorf("failed to write the step outputs: %w", err)
+	}
+	return nil
+}
+
+// escapeData escapes the message of a workflow command
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value of a workflow command
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// markdownCell escapes the characters that would break a Markdown table cell
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,53 @@
+package ci
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var githubResults = []Result{
+	{Resource: "api", DeploymentUUID: "dep-1", Status: "finished", Commit: "3f2a9c1e5b", Duration: 95 * time.Second, Passed: true},
+	{Resource: "web|admin", DeploymentUUID: "dep-2", Status: "wait-timeout"},
+}
+
+func TestMarkdownSummary(t *testing.T) {
+	summary := MarkdownSummary("Saturn deployments", githubResults)
+	assert.Contains(t, summary, "### Saturn deployments\n")
+	assert.Contains(t, summary, "1 of 2 deployments did not finish.")
+	assert.Contains(t, summary, "| ✅ | api | finished | `3f2a9c1` | 1m35s | `dep-1` |\n")
+	assert.Contains(t, summary, "| ❌ | web\\|admin | wait-timeout | - | - | `dep-2` |\n")
+}
+
+func TestWriteAnnotations(t *testing.T) {
+	var buf bytes.Buffer
+	WriteAnnotations(&buf, []Result{{Resource: "api: eu, 100%", DeploymentUUID: "dep-1", Status: "failed"}})
+	assert.Equal(t, "::error title=Deployment of api%3A eu%2C 100%25 failed::deployment dep-1 ended with status failed\n", buf.String())
+}
+
+func TestWriteOutputs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "output")
+	require.NoError(t, os.WriteFile(path, []byte("earlier=1\n"), 0o600))
+	t.Setenv("GITHUB_OUTPUT", path)
+
+	require.NoError(t, WriteOutputs(githubResults))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "earlier=1\n"+
+		"status=failure\n"+
+		"deployment_uuids=dep-1,dep-2\n"+
+		"failed_resources=web|admin\n"+
+		`results=[{"resource":"api","deployment_uuid":"dep-1","status":"finished","commit":"3f2a9c1e5b","passed":true},{"resource":"web|admin","deployment_uuid":"dep-2","status":"wait-timeout","passed":false}]`+"\n",
+		string(data))
+}
+
+func TestWriteStepSummary_Unset(t *testing.T) {
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+	assert.NoError(t, WriteStepSummary("Saturn deployments", githubResults))
+}
@@ -0,0 +1,108 @@
+package ci
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes results as a JUnit XML report with one test case per deployment.
+// Failed deployments carry the end of their build log in the failure element.
+func WriteJUnit(w io.Writer, suite string, results []Result, timestamp time.Time) error {
+	var total time.Duration
+	cases := make([]junitTestCase, len(results))
+	for i, r := range results {
+		total += r.Duration
+		tc := junitTestCase{
+			Name:      r.Resource,
+			ClassName: "saturn.deploy",
+			Time:      seconds(r.Duration),
+			SystemOut: fmt.Sprintf("deployment %s, status %s", r.DeploymentUUID, r.Status),
+		}
+		if r.Commit != "" {
+			tc.SystemOut += ", commit " + r.Commit
+		}
+		if !r.Passed {
+			tc.Failure = &junitFailure{Message: failureMessage(r), Type: r.Status, Text: r.Log}
+		}
+		cases[i] = tc
+	}
+
+	failures := failedCount(results)
+	report := junitTestSuites{
+		Name:     "saturn",
+		Tests:    len(results),
+		Failures: failures,
+		Time:     seconds(total),
+		Suites: []junitTestSuite{{
+			Name:      suite,
+			Tests:     len(results),
+			Failures:  failures,
+			Time:      seconds(total),
+			Timestamp: timestamp.UTC().Format("2006-01-02T15:04:05"),
+			Cases:     cases,
+		}},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteJUnitFile writes a JUnit XML report to path
+func WriteJUnitFile(path, suite string, results []Result, timestamp time.Time) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create JUnit report: %w", err)
+	}
+	if err := WriteJUnit(f, suite, results, timestamp); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return f.Close()
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
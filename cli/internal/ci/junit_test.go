@@ -0,0 +1,43 @@
+package ci
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	results := []Result{
+		{Resource: "api", DeploymentUUID: "dep-1", Status: "finished", Commit: "3f2a9c1e", Duration: 90 * time.Second, Passed: true},
+		{Resource: "web", DeploymentUUID: "dep-2", Status: "failed", Duration: 30 * time.Second, Log: "npm ERR! <missing> & broken"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJUnit(&buf, "saturn deploy smart", results, time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)))
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, 2, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+	assert.Equal(t, "120.000", report.Time)
+
+	require.Len(t, report.Suites, 1)
+	suite := report.Suites[0]
+	assert.Equal(t, "saturn deploy smart", suite.Name)
+	assert.Equal(t, "2026-03-02T10:00:00", suite.Timestamp)
+	require.Len(t, suite.Cases, 2)
+
+	assert.Equal(t, "api", suite.Cases[0].Name)
+	assert.Equal(t, "90.000", suite.Cases[0].Time)
+	assert.Nil(t, suite.Cases[0].Failure)
+	assert.Contains(t, suite.Cases[0].SystemOut, "commit 3f2a9c1e")
+
+	require.NotNil(t, suite.Cases[1].Failure)
+	assert.Equal(t, "failed", suite.Cases[1].Failure.Type)
+	assert.Equal(t, "deployment dep-2 ended with status failed", suite.Cases[1].Failure.Message)
+	assert.Equal(t, "npm ERR! <missing> & broken", suite.Cases[1].Failure.Text)
+}
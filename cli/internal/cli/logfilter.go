@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/logfilter"
+)
+
+// AddLogFilterFlags adds --since, --until, --grep, --invert-match and --level flags to a logs command
+func AddLogFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("since", "", "Only show lines logged after this time (e.g. 15m, 2h, 2026-01-02T15:04:05Z)")
+	cmd.Flags().String("until", "", "Only show lines logged before this time (same formats as --since)")
+	cmd.Flags().String("grep", "", "Only show lines matching this regular expression")
+	cmd.Flags().BoolP("invert-match", "v", false, "With --grep, show the lines that do not match")
+	cmd.Flags().String("level", "", "Only show lines of at least this severity (error, warn, info, debug)")
+}
+
+// NewLogFilter builds a log filter from the flags added by AddLogFilterFlags
+func NewLogFilter(cmd *cobra.Command) (*logfilter.Filter, error) {
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	grep, _ := cmd.Flags().GetString("grep")
+	invert, _ := cmd.Flags().GetBool("invert-match")
+	level, _ := cmd.Flags().GetString("level")
+
+	filter := &logfilter.Filter{Invert: invert}
+	now := time.Now()
+	var err error
+
+	if since != "" {
+		if filter.Since, err = logfilter.ParseTimeBound(since, now); err != nil {
+			return nil, fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if until != "" {
+		if filter.Until, err = logfilter.ParseTimeBound(until, now); err != nil {
+			return nil, fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+	if grep != "" {
+		if filter.Pattern, err = regexp.Compile(grep); err != nil {
+			return nil, fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+	} else if invert {
+		return nil, fmt.Errorf("--invert-match requires --grep")
+	}
+	if level != "" {
+		if filter.MinLevel, err = logfilter.ParseLevel(level); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+// WarnUndatedLogs tells that --since and --until kept every line, because none had a timestamp
+func WarnUndatedLogs(w io.Writer) {
+	fmt.Fprintln(w, "Warning: no log line has a timestamp, so --since and --until were not applied")
+}
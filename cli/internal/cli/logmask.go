@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/logmask"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+)
+
+// AddLogMaskFlags adds the --mask flag to a logs command
+func AddLogMaskFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("mask", false, "Mask environment variable values in the logs, also when printing to a terminal")
+}
+
+// LogMaskEnabled reports whether secrets should be masked in logs written to w. Masking
+// is on when w is not a terminal (a file, a pipe or a CI log) or CI is set, or when --mask
+// is given; --show-sensitive always turns it off.
+func LogMaskEnabled(cmd *cobra.Command, w io.Writer) (bool, error) {
+	mask, _ := cmd.Flags().GetBool("mask")
+	showSensitive, _ := cmd.Flags().GetBool("show-sensitive")
+	if mask && showSensitive {
+		return false, fmt.Errorf("--mask and --show-sensitive cannot be used together")
+	}
+	if showSensitive {
+		return false, nil
+	}
+	return mask || !output.IsTerminal(w) || os.Getenv("CI") != "", nil
+}
+
+// NewLogMasker builds a masker for logs written to w from the secret values returned by
+// fetch. It returns nil when masking is off. When the values cannot be fetched a warning
+// is printed and logs are shown unmasked, unless --mask was given explicitly.
+func NewLogMasker(ctx context.Context, cmd *cobra.Command, w io.Writer, fetch func(context.Context) ([]string, error)) (*logmask.Masker, error) {
+	enabled, err := LogMaskEnabled(cmd, w)
+	if err != nil || !enabled {
+		return nil, err
+	}
+	values, err := fetch(ctx)
+	if err != nil {
+		if mask, _ := cmd.Flags().GetBool("mask"); mask {
+			return nil, fmt.Errorf("failed to load secrets to mask: %w", err)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: secrets are not masked, failed to load environment variables: %v\n", err)
+		return nil, nil
+	}
+	return logmask.New(values), nil
+}
@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/logrender"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+)
+
+// AddLogRenderFlags adds --log-fields and --raw flags to a logs command
+func AddLogRenderFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("log-fields", nil, "Extra fields of JSON/logfmt lines to show (e.g. user_id,http.status)")
+	cmd.Flags().Bool("raw", false, "Print JSON/logfmt lines as they are instead of rendering them")
+}
+
+// NewLogRenderer builds a renderer for output written to w from the flags added by
+// AddLogRenderFlags. It returns nil when --raw is set.
+func NewLogRenderer(cmd *cobra.Command, w io.Writer) *logrender.Renderer {
+	if raw, _ := cmd.Flags().GetBool("raw"); raw {
+		return nil
+	}
+	fields, _ := cmd.Flags().GetStringSlice("log-fields")
+	return &logrender.Renderer{Fields: fields, Color: output.ColorEnabled(w)}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/service"
+)
+
+// ExitCodeWaitTimeout is the exit code when --timeout is exceeded while waiting
+const ExitCodeWaitTimeout = 2
+
+// RestartSettle is how long a restarted resource may keep its old status before a
+// matching status counts as the restart having completed
+const RestartSettle = 20 * time.Second
+
+// AddResourceWaitFlags adds --wait, --until, --timeout and --poll-interval to a lifecycle
+// command. until is the status waited for by default.
+func AddResourceWaitFlags(cmd *cobra.Command, until string) {
+	cmd.Flags().BoolP("wait", "w", false, "Wait until the resource reaches the --until status before exiting")
+	cmd.Flags().String("until", until, "Status to wait for, as state[:health] (implies --wait)")
+	cmd.Flags().Int("timeout", 600, "Timeout in seconds when using --wait")
+	cmd.Flags().Int("poll-interval", 3, "Poll interval in seconds when using --wait")
+}
+
+// ResourceWaitRequested reports whether --wait or --until was given
+func ResourceWaitRequested(cmd *cobra.Command) bool {
+	wait, _ := cmd.Flags().GetBool("wait")
+	return wait || cmd.Flags().Changed("until")
+}
+
+// ResourceWaitUntil returns the validated --until status. Commands check it before acting,
+// so a typo does not leave them having restarted something without waiting.
+func ResourceWaitUntil(cmd *cobra.Command) (string, error) {
+	until, _ := cmd.Flags().GetString("until")
+	if err := service.ParseUntil(until); err != nil {
+		return "", fmt.Errorf("invalid --until: %w", err)
+	}
+	return until, nil
+}
+
+// ResourceWaitContext returns a context that ends with --timeout, along with the timeout
+func ResourceWaitContext(cmd *cobra.Command) (context.Context, context.CancelFunc, int) {
+	timeoutSec, _ := cmd.Flags().GetInt("timeout")
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Duration(timeoutSec)*time.Second)
+	return ctx, cancel, timeoutSec
+}
+
+// WaitForResource blocks until the resource reaches until, the validated --until status,
+// printing every status change. kind and uuid name the resource in messages, e.g.
+// "database" and its UUID. settle is passed on as service.ResourceWaitOptions.Settle.
+// Exceeding --timeout returns an error with exit code ExitCodeWaitTimeout.
+func WaitForResource(cmd *cobra.Command, kind, uuid, until string, fetch service.ResourceStatusFunc, settle time.Duration) error {
+	ctx, cancel, timeoutSec := ResourceWaitContext(cmd)
+	defer cancel()
+	return WaitForResourceContext(ctx, cmd, kind, uuid, until, timeoutSec, fetch, settle)
+}
+
+// WaitForResourceContext is WaitForResource with the --timeout context already set up,
+// for commands that wait for something else first within the same timeout
+func WaitForResourceContext(ctx context.Context, cmd *cobra.Command, kind, uuid, until string, timeoutSec int,
+	fetch service.ResourceStatusFunc, settle time.Duration) error {
+	pollSec, _ := cmd.Flags().GetInt("poll-interval")
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "Waiting for %s %s to be %s (timeout: %ds)...\n", kind, uuid, until, timeoutSec)
+	status, err := service.WaitForResource(ctx, fetch, service.ResourceWaitOptions{
+		Until:        until,
+		PollInterval: time.Duration(pollSec) * time.Second,
+		Settle:       settle,
+		OnStatus: func(status string) {
+			fmt.Fprintf(out, "  [%s] %s\n", uuid, status)
+		},
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return WithExitCode(fmt.Errorf("wait timeout exceeded (%ds): %s %s is %s, not %s", timeoutSec, kind, uuid, statusOrUnknown(status), until), ExitCodeWaitTimeout)
+		}
+		return fmt.Errorf("error waiting for %s %s: %w", kind, uuid, err)
+	}
+	fmt.Fprintf(out, "%s %s is %s\n", kind, uuid, status)
+	return nil
+}
+
+func statusOrUnknown(status string) string {
+	if status == "" {
+		return "unknown"
+	}
+	return status
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/saturn-platform/saturn-cli/internal/output"
+)
+
+// DefaultWatchInterval is used when --watch is given without a value
+const DefaultWatchInterval = 2 * time.Second
+
+// FetchFunc loads the data a get/list command prints
+type FetchFunc func(ctx context.Context) (any, error)
+
+// AddWatchFlags adds --watch[=interval] and --until flags to a get/list command
+func AddWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().String("watch", "", "Re-run the command and redraw the output every interval (e.g. --watch or --watch=5s)")
+	cmd.Flags().Lookup("watch").NoOptDefVal = DefaultWatchInterval.String()
+	cmd.Flags().StringSlice("until", nil, "With --watch, exit once every row matches field=value (e.g. --until status=running)")
+}
+
+// parseWatchInterval accepts a Go duration ("5s", "1m") or a number of seconds
+func parseWatchInterval(s string) (time.Duration, error) {
+	if secs, err := strconv.Atoi(s); err == nil {
+		s = fmt.Sprintf("%ds", secs)
+	}
+	interval, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --watch interval %q: %w", s, err)
+	}
+	if interval < time.Second {
+		return 0, fmt.Errorf("--watch interval must be at least 1s")
+	}
+	return interval, nil
+}
+
+// RunWithWatch prints the result of fetch once, or keeps re-running it when --watch is set.
+// In watch mode the output is redrawn in place on a terminal; otherwise only changed rows are printed.
+// With --until the command exits successfully as soon as every row matches, and with an error
+// if it is interrupted before that. The met condition is reported on stderr so JSON and NDJSON
+// output stay parseable.
+func RunWithWatch(cmd *cobra.Command, opts output.Options, fetch FetchFunc) error {
+	ctx := cmd.Context()
+	format, _ := cmd.Flags().GetString("format")
+	watchSpec, _ := cmd.Flags().GetString("watch")
+	untilSpecs, _ := cmd.Flags().GetStringSlice("until")
+
+	if opts.Writer == nil {
+		opts.Writer = cmd.OutOrStdout()
+	}
+
+	if watchSpec == "" {
+		if len(untilSpecs) > 0 {
+			return fmt.Errorf("--until requires --watch")
+		}
+		data, err := fetch(ctx)
+		if err != nil {
+			return err
+		}
+		formatter, err := output.NewFormatter(format, opts)
+		if err != nil {
+			return err
+		}
+		return formatter.Format(data)
+	}
+
+	interval, err := parseWatchInterval(watchSpec)
+	if err != nil {
+		return err
+	}
+	conds, err := output.ParseUntil(untilSpecs)
+	if err != nil {
+		return err
+	}
+	if _, err := output.NewFormatter(format, opts); err != nil {
+		return err
+	}
+
+	tty := output.IsTerminal(opts.Writer)
+	opts.Color = output.ColorEnabled(opts.Writer)
+	title := fmt.Sprintf("Every %s: %s %s", interval, cmd.CommandPath(), strings.Join(cmd.Flags().Args(), " "))
+	renderer := output.NewWatchRenderer(format, opts, tty, strings.TrimSpace(title))
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		data, err := fetch(ctx)
+		if err != nil {
+			// Keep watching through transient errors, but report them
+			fmt.Fprintf(cmd.ErrOrStderr(), "[%s] %v\n", time.Now().Format("15:04:05"), err)
+		} else {
+			if err := renderer.Render(data); err != nil {
+				return err
+			}
+			if renderer.Satisfied(conds) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Condition met: %s\n", strings.Join(untilSpecs, ", "))
+				return nil
+			}
+		}
+
+		select {
+		case <-sigChan:
+			if len(conds) > 0 {
+				return fmt.Errorf("watch interrupted before condition was met: %s", strings.Join(untilSpecs, ", "))
+			}
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
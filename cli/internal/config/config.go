@@ -14,9 +14,18 @@ import (
 type Config struct {
 	Instances           []Instance `json:"instances"`
 	LastUpdateCheckTime string     `json:"lastUpdateCheckTime"`
+	Hooks               *Hooks     `json:"hooks,omitempty"`
 	path                string     // config file path (not serialized)
 }
 
+// Hooks are local commands run, and notifications sent, when a waited deployment ends
+type Hooks struct {
+	OnSuccess string `json:"onSuccess,omitempty"`
+	OnFailure string `json:"onFailure,omitempty"`
+	// Notify is "bell" or "desktop"
+	Notify string `json:"notify,omitempty"`
+}
+
 // New creates a new config with default values
 func New() *Config {
 	return &Config{
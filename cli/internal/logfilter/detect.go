@@ -0,0 +1,156 @@
+package logfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Level is the severity of a log line
+type Level int
+
+// Severity levels, from least to most severe. LevelUnknown means none was detected.
+const (
+	LevelUnknown Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{
+	LevelUnknown: "unknown",
+	LevelDebug:   "debug",
+	LevelInfo:    "info",
+	LevelWarn:    "warn",
+	LevelError:   "error",
+}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// levelWords maps the severity names used by common loggers to levels
+var levelWords = map[string]Level{
+	"trace": LevelDebug, "debug": LevelDebug, "dbug": LevelDebug,
+	"info": LevelInfo, "information": LevelInfo, "notice": LevelInfo,
+	"warn": LevelWarn, "warning": LevelWarn,
+	"err": LevelError, "error": LevelError, "fatal": LevelError, "crit": LevelError, "critical": LevelError,
+	"panic": LevelError, "alert": LevelError, "emerg": LevelError, "emergency": LevelError, "severe": LevelError,
+}
+
+// ParseLevel parses a level name given on the command line
+func ParseLevel(s string) (Level, error) {
+	level, ok := levelWords[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return LevelUnknown, fmt.Errorf("invalid log level %q (expected error, warn, info or debug)", s)
+	}
+	return level, nil
+}
+
+// NumericLevel maps the numeric levels of pino and bunyan (10 trace ... 60 fatal) to a level
+func NumericLevel(n int) Level {
+	switch {
+	case n >= 50:
+		return LevelError
+	case n >= 40:
+		return LevelWarn
+	case n >= 30:
+		return LevelInfo
+	case n > 0:
+		return LevelDebug
+	default:
+		return LevelUnknown
+	}
+}
+
+var (
+	// "level":"error" in JSON logs, and pino/bunyan numeric levels ("level":50)
+	jsonLevel        = regexp.MustCompile(`"(?i:level|severity|lvl|loglevel)"\s*:\s*"([A-Za-z]+)"`)
+	jsonNumericLevel = regexp.MustCompile(`"level"\s*:\s*([1-6]0)\b`)
+	// level=error in logfmt
+	logfmtLevel = regexp.MustCompile(`(?i)\b(?:level|lvl|severity)=["']?([a-z]+)`)
+	// [error], <warn>, production.ERROR: and bare upper-case words such as ERROR or WARN
+	bracketLevel = regexp.MustCompile(`[\[<(]([A-Za-z]+)[\]>)]`)
+	upperLevel   = regexp.MustCompile(`\b(TRACE|DEBUG|INFO|NOTICE|WARN|WARNING|ERROR|ERR|FATAL|CRITICAL|CRIT|PANIC|ALERT|EMERG|SEVERE)\b`)
+	// glog/klog prefix: E1015 12:00:00.000000
+	klogLevel = regexp.MustCompile(`^([DIWEF])\d{4} \d{2}:\d{2}:\d{2}`)
+)
+
+var klogLevels = map[string]Level{"D": LevelDebug, "I": LevelInfo, "W": LevelWarn, "E": LevelError, "F": LevelError}
+
+// DetectLevel guesses the severity of a log line written in a common format:
+// JSON, logfmt, bracketed or upper-case level names, or glog prefixes
+func DetectLevel(line string) Level {
+	if m := jsonLevel.FindStringSubmatch(line); m != nil {
+		if level, ok := levelWords[strings.ToLower(m[1])]; ok {
+			return level
+		}
+	}
+	if m := jsonNumericLevel.FindStringSubmatch(line); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return NumericLevel(n)
+	}
+	if m := logfmtLevel.FindStringSubmatch(line); m != nil {
+		if level, ok := levelWords[strings.ToLower(m[1])]; ok {
+			return level
+		}
+	}
+	if m := klogLevel.FindStringSubmatch(line); m != nil {
+		return klogLevels[m[1]]
+	}
+	for _, m := range bracketLevel.FindAllStringSubmatch(line, 3) {
+		if level, ok := levelWords[strings.ToLower(m[1])]; ok {
+			return level
+		}
+	}
+	if m := upperLevel.FindStringSubmatch(line); m != nil {
+		return levelWords[strings.ToLower(m[1])]
+	}
+	return LevelUnknown
+}
+
+var (
+	// 2025-10-15T12:00:00Z, 2025-10-15 12:00:00.123+02:00, 2025/10/15 12:00:00
+	isoTimestamp = regexp.MustCompile(`\d{4}[-/]\d{2}[-/]\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?`)
+	// 15/Oct/2025:12:00:00 +0000 (common log format)
+	clfTimestamp = regexp.MustCompile(`\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}`)
+	// "ts":1697371200.123 in JSON logs
+	epochTimestamp = regexp.MustCompile(`"(?:ts|time|timestamp)"\s*:\s*(\d{10})(?:\.(\d+))?`)
+)
+
+var isoLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// DetectTimestamp finds the first timestamp in a log line. Timestamps without a
+// time zone are read as UTC, which is what containers log in by default.
+func DetectTimestamp(line string) (time.Time, bool) {
+	if m := isoTimestamp.FindString(line); m != "" {
+		normalized := strings.NewReplacer("/", "-", ",", ".").Replace(m[:10]) + "T" + strings.Replace(m[11:], ",", ".", 1)
+		for _, layout := range isoLayouts {
+			if t, err := time.Parse(layout, normalized); err == nil {
+				return t, true
+			}
+		}
+	}
+	if m := clfTimestamp.FindString(line); m != "" {
+		if t, err := time.Parse("02/Jan/2006:15:04:05 -0700", m); err == nil {
+			return t, true
+		}
+	}
+	if m := epochTimestamp.FindStringSubmatch(line); m != nil {
+		secs, _ := strconv.ParseInt(m[1], 10, 64)
+		nanos := int64(0)
+		if m[2] != "" {
+			frac := (m[2] + "000000000")[:9]
+			nanos, _ = strconv.ParseInt(frac, 10, 64)
+		}
+		return time.Unix(secs, nanos).UTC(), true
+	}
+	return time.Time{}, false
+}
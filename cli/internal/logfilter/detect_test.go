@@ -0,0 +1,70 @@
+package logfilter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectLevel(t *testing.T) {
+	tests := []struct {
+		line string
+		want Level
+	}{
+		{`{"level":"error","msg":"db down"}`, LevelError},
+		{`{"severity":"WARNING","message":"slow query"}`, LevelWarn},
+		{`{"level":30,"msg":"listening"}`, LevelInfo},
+		{`{"level":50,"msg":"crash"}`, LevelError},
+		{`time=2026-01-02T10:00:00Z level=warn msg="retrying"`, LevelWarn},
+		{`[2026-01-02 10:00:00] production.ERROR: Connection refused`, LevelError},
+		{`2026/01/02 10:00:00 [error] 29#29: upstream timed out`, LevelError},
+		{`E0102 10:00:00.000000       1 controller.go:42] sync failed`, LevelError},
+		{`INFO  Server started on :3000`, LevelInfo},
+		{`GET /health 200 1.2ms`, LevelUnknown},
+		{`an error occurred in lower case prose`, LevelUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectLevel(tt.line))
+		})
+	}
+}
+
+func TestDetectTimestamp(t *testing.T) {
+	want := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		line string
+		want time.Time
+	}{
+		{`2026-01-02T10:00:00Z GET /`, want},
+		{`2026-01-02T12:00:00+02:00 GET /`, want},
+		{`[2026-01-02 10:00:00] production.INFO: ok`, want},
+		{`2026/01/02 10:00:00 [error] upstream`, want},
+		{`10.0.0.1 - - [02/Jan/2026:10:00:00 +0000] "GET / HTTP/1.1" 200`, want},
+		{`{"ts":1767348000,"msg":"ok"}`, want},
+		{`{"time":"2026-01-02T10:00:00.500Z"}`, want.Add(500 * time.Millisecond)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := DetectTimestamp(tt.line)
+			require.True(t, ok)
+			assert.True(t, tt.want.Equal(got), "got %s", got)
+		})
+	}
+
+	_, ok := DetectTimestamp("no timestamp here")
+	assert.False(t, ok)
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("WARN")
+	require.NoError(t, err)
+	assert.Equal(t, LevelWarn, level)
+
+	_, err = ParseLevel("loud")
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+// Package logfilter selects log lines by time range, pattern and severity.
+// Timestamps and levels are detected from the text of each line, so the same
+// filter works for container output and deployment log entries.
+package logfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// Filter selects log lines. The zero value matches everything.
+//
+// Lines without a timestamp, and indented continuation lines such as stack
+// traces, are judged by the timestamp and level of the line before them. Lines
+// before the first timestamp of a stream are kept by the time range, since
+// nothing tells when they were logged. A Filter therefore carries state between
+// calls and should be used for a single log stream.
+type Filter struct {
+	// Since and Until bound the line timestamps; zero values leave the range open
+	Since time.Time
+	Until time.Time
+	// Pattern keeps lines matching the regular expression, or dropping them with Invert
+	Pattern *regexp.Regexp
+	Invert  bool
+	// MinLevel keeps lines of at least this severity
+	MinLevel Level
+
+	lastTime  time.Time
+	lastLevel Level
+	seen      bool
+	dated     bool
+}
+
+// Active reports whether the filter can drop any line
+func (f *Filter) Active() bool {
+	return f.timeBound() || f.Pattern != nil || f.MinLevel != LevelUnknown
+}
+
+func (f *Filter) timeBound() bool {
+	return !f.Since.IsZero() || !f.Until.IsZero()
+}
+
+// Undated reports whether the filter has a time range but none of the lines it saw
+// carried a timestamp, so the range kept all of them
+func (f *Filter) Undated() bool {
+	return f.timeBound() && f.seen && !f.dated
+}
+
+// Match reports whether a line is kept
+func (f *Filter) Match(line string) bool {
+	f.noteTime(DetectTimestamp(line))
+
+	level := DetectLevel(line)
+	if level == LevelUnknown && startsIndented(line) {
+		level = f.lastLevel
+	}
+	f.lastLevel = level
+
+	return f.match(line, f.lastTime, level)
+}
+
+// Lines filters multi-line text, keeping its trailing newline
+func (f *Filter) Lines(text string) string {
+	if !f.Active() || text == "" {
+		return text
+	}
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		if f.Match(line) {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// MatchEntry reports whether a deployment log entry is kept. Its timestamp field is
+// used for the time range; entries on stderr without a detectable level count as errors.
+func (f *Filter) MatchEntry(entry models.LogEntry) bool {
+	ts, ok := DetectTimestamp(entry.Timestamp)
+	if !ok {
+		ts, ok = DetectTimestamp(entry.Output)
+	}
+	f.noteTime(ts, ok)
+
+	level := DetectLevel(entry.Output)
+	if level == LevelUnknown && entry.Type == "stderr" {
+		level = LevelError
+	}
+	f.lastLevel = level
+
+	return f.match(entry.Output, f.lastTime, level)
+}
+
+// noteTime records the timestamp of a line, if it has one
+func (f *Filter) noteTime(ts time.Time, ok bool) {
+	f.seen = true
+	if ok {
+		f.lastTime = ts
+		f.dated = true
+	}
+}
+
+func (f *Filter) match(text string, ts time.Time, level Level) bool {
+	// A zero ts means no line so far had a timestamp
+	if f.timeBound() && !ts.IsZero() {
+		if !f.Since.IsZero() && ts.Before(f.Since) {
+			return false
+		}
+		if !f.Until.IsZero() && ts.After(f.Until) {
+			return false
+		}
+	}
+	if f.MinLevel != LevelUnknown && level < f.MinLevel {
+		return false
+	}
+	if f.Pattern != nil && f.Pattern.MatchString(text) == f.Invert {
+		return false
+	}
+	return true
+}
+
+func startsIndented(line string) bool {
+	return line != "" && unicode.IsSpace(rune(line[0]))
+}
+
+var timeBoundLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTimeBound parses a --since/--until value: a duration before now ("15m", "2h",
+// "3d"), an RFC 3339 timestamp, or a local date and time ("2026-01-02 15:04")
+func ParseTimeBound(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("invalid time %q: duration must be positive", s)
+		}
+		return now.Add(-d), nil
+	}
+	for _, layout := range timeBoundLayouts {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use a duration like 15m or a timestamp like 2006-01-02T15:04:05Z)", s)
+}
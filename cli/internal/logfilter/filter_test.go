@@ -0,0 +1,94 @@
+package logfilter
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+const sampleLogs = `2026-01-02T09:50:00Z INFO starting
+2026-01-02T09:55:00Z ERROR payment failed
+    at charge (billing.js:10)
+2026-01-02T10:01:00Z WARN slow response
+2026-01-02T10:02:00Z INFO healthy
+`
+
+func TestFilter_Lines(t *testing.T) {
+	since := time.Date(2026, 1, 2, 9, 52, 0, 0, time.UTC)
+
+	f := &Filter{Since: since}
+	assert.Equal(t, `2026-01-02T09:55:00Z ERROR payment failed
+    at charge (billing.js:10)
+2026-01-02T10:01:00Z WARN slow response
+2026-01-02T10:02:00Z INFO healthy
+`, f.Lines(sampleLogs))
+
+	f = &Filter{MinLevel: LevelWarn}
+	assert.Equal(t, `2026-01-02T09:55:00Z ERROR payment failed
+    at charge (billing.js:10)
+2026-01-02T10:01:00Z WARN slow response
+`, f.Lines(sampleLogs))
+
+	f = &Filter{Pattern: regexp.MustCompile(`INFO`), Invert: true, Until: since}
+	assert.Empty(t, f.Lines(sampleLogs))
+
+	f = &Filter{}
+	assert.False(t, f.Active())
+	assert.Equal(t, sampleLogs, f.Lines(sampleLogs))
+}
+
+func TestFilter_Undated(t *testing.T) {
+	since := time.Date(2026, 1, 2, 9, 52, 0, 0, time.UTC)
+
+	// docker logs without --timestamps: the time range cannot apply
+	f := &Filter{Since: since}
+	assert.False(t, f.Undated())
+	assert.Equal(t, "starting\nready\n", f.Lines("starting\nready\n"))
+	assert.True(t, f.Undated())
+
+	// Lines before the first timestamp are kept, later ones inherit it
+	f = &Filter{Since: since}
+	assert.Equal(t, "booting\n2026-01-02T09:55:00Z ERROR payment failed\nretrying\n",
+		f.Lines("booting\n2026-01-02T09:50:00Z INFO starting\nstill starting\n2026-01-02T09:55:00Z ERROR payment failed\nretrying\n"))
+	assert.False(t, f.Undated())
+
+	f = &Filter{MinLevel: LevelWarn}
+	f.Lines("starting\n")
+	assert.False(t, f.Undated())
+}
+
+func TestFilter_MatchEntry(t *testing.T) {
+	f := &Filter{MinLevel: LevelError, Since: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)}
+
+	assert.True(t, f.MatchEntry(models.LogEntry{Output: "npm ERR! build failed", Type: "stderr", Timestamp: "2026-01-02T10:05:00+00:00"}))
+	assert.False(t, f.MatchEntry(models.LogEntry{Output: "npm ERR! old failure", Type: "stderr", Timestamp: "2026-01-02T09:00:00+00:00"}))
+	assert.False(t, f.MatchEntry(models.LogEntry{Output: "Step 3/8", Type: "stdout", Timestamp: "2026-01-02T10:05:00+00:00"}))
+}
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	got, err := ParseTimeBound("15m", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-15*time.Minute), got)
+
+	got, err = ParseTimeBound("2d", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, -2), got)
+
+	got, err = ParseTimeBound("2026-01-01T08:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC), got)
+
+	got, err = ParseTimeBound("2026-01-01 08:30", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 8, 30, 0, 0, time.UTC), got)
+
+	_, err = ParseTimeBound("yesterday", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,77 @@
+// Package logmask hides known secret values, such as the environment variables of
+// a resource, in log output.
+package logmask
+
+import (
+	"sort"
+	"strings"
+)
+
+// Placeholder replaces every masked value
+const Placeholder = "********"
+
+// MinLength is the shortest value that is masked. Shorter values ("1", "true", "5432")
+// would mostly hide innocent text; longer numbers may be PINs, account numbers or
+// numeric API keys and are masked like any other value.
+const MinLength = 6
+
+// Masker replaces secret values in text. A nil Masker leaves text unchanged.
+type Masker struct {
+	replacer *strings.Replacer
+	count    int
+}
+
+// New creates a masker for values. Values shorter than MinLength, which covers booleans
+// and small numbers, and values the API already masked are ignored; each line of a multi-line value (e.g. a
+// private key) is masked on its own, since logs are printed line by line.
+func New(values []string) *Masker {
+	seen := make(map[string]bool)
+	var secrets []string
+	add := func(v string) {
+		v = strings.TrimSpace(v)
+		if len(v) < MinLength || seen[v] || v == Placeholder {
+			return
+		}
+		seen[v] = true
+		secrets = append(secrets, v)
+	}
+	for _, v := range values {
+		add(v)
+		if strings.Contains(v, "\n") {
+			for _, line := range strings.Split(v, "\n") {
+				add(line)
+			}
+		}
+	}
+
+	// strings.Replacer tries the old strings in argument order, so the longest
+	// value wins where one secret contains another
+	sort.Slice(secrets, func(i, j int) bool {
+		if len(secrets[i]) != len(secrets[j]) {
+			return len(secrets[i]) > len(secrets[j])
+		}
+		return secrets[i] < secrets[j]
+	})
+
+	pairs := make([]string, 0, 2*len(secrets))
+	for _, s := range secrets {
+		pairs = append(pairs, s, Placeholder)
+	}
+	return &Masker{replacer: strings.NewReplacer(pairs...), count: len(secrets)}
+}
+
+// Len returns the number of distinct values masked
+func (m *Masker) Len() int {
+	if m == nil {
+		return 0
+	}
+	return m.count
+}
+
+// Mask replaces every known secret in s with Placeholder
+func (m *Masker) Mask(s string) string {
+	if m == nil || m.count == 0 {
+		return s
+	}
+	return m.replacer.Replace(s)
+}
@@ -0,0 +1,57 @@
+package logmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMasker_Mask(t *testing.T) {
+	m := New([]string{
+		"s3cr3t-token",
+		"s3cr3t-token-extended",
+		"postgres://app:hunter22@db:5432/app",
+		"-----BEGIN KEY-----\nMIIEvQIBADANBgkq\n-----END KEY-----",
+		"short",
+		"5432",
+		"123456789",
+		"true",
+		"",
+	})
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"token=s3cr3t-token", "token=********"},
+		{"using s3cr3t-token-extended now", "using ******** now"},
+		{"connecting to postgres://app:hunter22@db:5432/app", "connecting to ********"},
+		{"MIIEvQIBADANBgkq", "********"},
+		{"short port 5432 enabled true", "short port 5432 enabled true"},
+		{"id 123456789", "id ********"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.want, m.Mask(tt.line))
+		})
+	}
+}
+
+func TestMasker_MasksLongNumbers(t *testing.T) {
+	m := New([]string{"482913", "4000123412341234", "90210987654321", "1.5", "8080"})
+
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, "pin ******** account ******** key ********", m.Mask("pin 482913 account 4000123412341234 key 90210987654321"))
+	assert.Equal(t, "ratio 1.5 on port 8080", m.Mask("ratio 1.5 on port 8080"))
+}
+
+func TestMasker_Len(t *testing.T) {
+	assert.Equal(t, 2, New([]string{"abcdefgh", "abcdefgh", " abcdefgh ", "ijklmnop", "abc"}).Len())
+}
+
+func TestMasker_Nil(t *testing.T) {
+	var m *Masker
+	assert.Equal(t, "secret-value", m.Mask("secret-value"))
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, "secret-value", New(nil).Mask("secret-value"))
+}
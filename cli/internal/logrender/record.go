@@ -0,0 +1,246 @@
+// Package logrender parses structured log lines (JSON objects and logfmt) into
+// common fields and renders them as aligned, coloured text or normalised JSON.
+package logrender
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saturn-platform/saturn-cli/internal/logfilter"
+)
+
+// Keys recognised for the common fields, in order of preference
+var (
+	levelKeys   = []string{"level", "lvl", "severity", "loglevel", "log.level"}
+	messageKeys = []string{"msg", "message", "@message"}
+	timeKeys    = []string{"time", "ts", "timestamp", "@timestamp"}
+	errorKeys   = []string{"error", "err", "exception", "error.message"}
+	traceKeys   = []string{"trace_id", "traceId", "traceID", "trace.id"}
+)
+
+// Record is a log line split into the fields most loggers have in common
+type Record struct {
+	// Structured is false for plain text lines, which only have Raw set
+	Structured bool
+	Raw        string
+	Time       time.Time
+	Level      logfilter.Level
+	Message    string
+	Error      string
+	TraceID    string
+	// Fields holds every field of a structured line, the common ones included
+	Fields map[string]any
+
+	common map[string]bool
+}
+
+// Parse reads a log line as a JSON object or logfmt. Anything else is returned as
+// an unstructured record.
+func Parse(line string) Record {
+	fields, ok := parseJSON(line)
+	if !ok {
+		fields, ok = parseLogfmt(line)
+	}
+	if !ok {
+		return Record{Raw: line}
+	}
+
+	r := Record{Structured: true, Raw: line, Fields: fields, common: make(map[string]bool)}
+	if v, ok := r.take(levelKeys); ok {
+		r.Level = parseLevel(v)
+	}
+	if v, ok := r.take(messageKeys); ok {
+		r.Message = FormatValue(v)
+	}
+	if v, ok := r.take(timeKeys); ok {
+		r.Time, _ = parseTime(v)
+	}
+	if v, ok := r.take(errorKeys); ok {
+		r.Error = errorText(v)
+	}
+	if v, ok := r.take(traceKeys); ok {
+		r.TraceID = FormatValue(v)
+	}
+	return r
+}
+
+// take looks up the first of keys present and remembers it as a common field
+func (r *Record) take(keys []string) (any, bool) {
+	key, v, ok := lookupAny(r.Fields, keys)
+	if ok {
+		r.common[key] = true
+	}
+	return v, ok
+}
+
+// Extra returns the fields of a structured line other than the common ones
+func (r Record) Extra() map[string]any {
+	extra := make(map[string]any, len(r.Fields))
+	for k, v := range r.Fields {
+		if !r.common[k] {
+			extra[k] = v
+		}
+	}
+	return extra
+}
+
+// parseJSON decodes a line holding a single JSON object
+func parseJSON(line string) (map[string]any, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") || !strings.HasSuffix(trimmed, "}") {
+		return nil, false
+	}
+	dec := json.NewDecoder(strings.NewReader(trimmed))
+	dec.UseNumber()
+	var fields map[string]any
+	if err := dec.Decode(&fields); err != nil || dec.More() {
+		return nil, false
+	}
+	return fields, true
+}
+
+// parseLogfmt decodes key=value pairs. Lines are only taken for logfmt when every
+// token is a pair and a level or message key is present, so prose containing the
+// odd "a=b" stays plain text.
+func parseLogfmt(line string) (map[string]any, bool) {
+	fields := make(map[string]any)
+	s := strings.TrimSpace(line)
+	for s != "" {
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 || strings.ContainsAny(s[:eq], " \t\"") {
+			return nil, false
+		}
+		key := s[:eq]
+		s = s[eq+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := closingQuote(s)
+			if end < 0 {
+				return nil, false
+			}
+			unquoted, err := strconv.Unquote(s[:end+1])
+			if err != nil {
+				return nil, false
+			}
+			value, s = unquoted, s[end+1:]
+			if s != "" && s[0] != ' ' && s[0] != '\t' {
+				return nil, false
+			}
+		} else if sp := strings.IndexAny(s, " \t"); sp >= 0 {
+			value, s = s[:sp], s[sp:]
+		} else {
+			value, s = s, ""
+		}
+		fields[key] = value
+		s = strings.TrimLeft(s, " \t")
+	}
+
+	if len(fields) < 2 {
+		return nil, false
+	}
+	_, _, hasLevel := lookupAny(fields, levelKeys)
+	_, _, hasMessage := lookupAny(fields, messageKeys)
+	return fields, hasLevel || hasMessage
+}
+
+// closingQuote returns the index of the quote ending the string s starts with
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// Lookup finds a field by key, or by a dotted path into nested objects ("http.status")
+func Lookup(fields map[string]any, key string) (any, bool) {
+	if v, ok := fields[key]; ok {
+		return v, true
+	}
+	head, rest, found := strings.Cut(key, ".")
+	if !found {
+		return nil, false
+	}
+	nested, ok := fields[head].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return Lookup(nested, rest)
+}
+
+func lookupAny(fields map[string]any, keys []string) (string, any, bool) {
+	for _, key := range keys {
+		if v, ok := Lookup(fields, key); ok && v != nil {
+			return key, v, true
+		}
+	}
+	return "", nil, false
+}
+
+func parseLevel(v any) logfilter.Level {
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return logfilter.NumericLevel(int(i))
+		}
+	}
+	level, err := logfilter.ParseLevel(FormatValue(v))
+	if err != nil {
+		return logfilter.LevelUnknown
+	}
+	return level
+}
+
+// parseTime reads a timestamp string or a Unix time in seconds or milliseconds
+func parseTime(v any) (time.Time, bool) {
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		if err != nil || f <= 0 {
+			return time.Time{}, false
+		}
+		if f > 1e12 {
+			f /= 1000
+		}
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true
+	}
+	return logfilter.DetectTimestamp(FormatValue(v))
+}
+
+// errorText reduces an error field to text. Error objects are represented by their message.
+func errorText(v any) string {
+	if obj, ok := v.(map[string]any); ok {
+		if msg, ok := obj["message"]; ok {
+			return FormatValue(msg)
+		}
+	}
+	return FormatValue(v)
+}
+
+// FormatValue renders a field value as text: strings as they are, everything else as compact JSON
+func FormatValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	default:
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(val); err != nil {
+			return ""
+		}
+		return strings.TrimSuffix(buf.String(), "\n")
+	}
+}
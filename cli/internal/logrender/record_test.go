@@ -0,0 +1,84 @@
+package logrender
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saturn-platform/saturn-cli/internal/logfilter"
+)
+
+func TestParse(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		line    string
+		want    Record
+		plain   bool
+		wantExt map[string]any
+	}{
+		{
+			name:    "json",
+			line:    `{"level":"error","msg":"db down","time":"2026-01-02T10:00:00Z","error":"connection refused","trace_id":"abc","user":"u1"}`,
+			want:    Record{Level: logfilter.LevelError, Message: "db down", Time: ts, Error: "connection refused", TraceID: "abc"},
+			wantExt: map[string]any{"user": "u1"},
+		},
+		{
+			name:    "pino",
+			line:    `{"level":40,"time":1767348000000,"msg":"slow","err":{"type":"Error","message":"timeout"}}`,
+			want:    Record{Level: logfilter.LevelWarn, Message: "slow", Time: ts, Error: "timeout"},
+			wantExt: map[string]any{},
+		},
+		{
+			name:    "nested fields",
+			line:    `{"log.level":"info","message":"ok","trace":{"id":"t-1"},"http":{"status":200}}`,
+			want:    Record{Level: logfilter.LevelInfo, Message: "ok", TraceID: "t-1"},
+			wantExt: map[string]any{"trace": map[string]any{"id": "t-1"}, "http": map[string]any{"status": json.Number("200")}},
+		},
+		{
+			name:    "logfmt",
+			line:    `ts=2026-01-02T10:00:00Z level=warn msg="retrying request" attempt=2`,
+			want:    Record{Level: logfilter.LevelWarn, Message: "retrying request", Time: ts},
+			wantExt: map[string]any{"attempt": "2"},
+		},
+		{name: "plain text", line: "Server started on :3000", plain: true},
+		{name: "prose with a pair", line: "retrying with timeout=5s", plain: true},
+		{name: "json array", line: `[1, 2]`, plain: true},
+		{name: "broken json", line: `{"level":"info"`, plain: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.line)
+			assert.Equal(t, tt.line, got.Raw)
+			if tt.plain {
+				assert.False(t, got.Structured)
+				return
+			}
+			assert.True(t, got.Structured)
+			assert.Equal(t, tt.want.Level, got.Level)
+			assert.Equal(t, tt.want.Message, got.Message)
+			assert.True(t, tt.want.Time.Equal(got.Time), "time %v", got.Time)
+			assert.Equal(t, tt.want.Error, got.Error)
+			assert.Equal(t, tt.want.TraceID, got.TraceID)
+			assert.Equal(t, tt.wantExt, got.Extra())
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	fields := map[string]any{"http": map[string]any{"status": 200}, "a.b": "literal"}
+
+	v, ok := Lookup(fields, "http.status")
+	assert.True(t, ok)
+	assert.Equal(t, 200, v)
+
+	v, ok = Lookup(fields, "a.b")
+	assert.True(t, ok)
+	assert.Equal(t, "literal", v)
+
+	_, ok = Lookup(fields, "http.method")
+	assert.False(t, ok)
+}
@@ -0,0 +1,134 @@
+package logrender
+
+import (
+	"strings"
+	"time"
+
+	"github.com/saturn-platform/saturn-cli/internal/logfilter"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+)
+
+// timeLayout is used for the time column; millisecond precision keeps it fixed-width
+const timeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// levelWidth is the width of the level column, long enough for "ERROR" and "DEBUG"
+const levelWidth = 5
+
+var levelColors = map[logfilter.Level]string{
+	logfilter.LevelDebug: output.ColorGray,
+	logfilter.LevelInfo:  output.ColorGreen,
+	logfilter.LevelWarn:  output.ColorYellow,
+	logfilter.LevelError: output.ColorRed,
+}
+
+// Renderer turns log lines into readable text or normalised objects
+type Renderer struct {
+	// Fields are extra fields to show after the message, by key or dotted path
+	Fields []string
+	// Color enables ANSI colours in Text
+	Color bool
+}
+
+// Text renders a line as "time level message error=… trace_id=… field=…" with the level
+// padded to a fixed width. Lines that are not structured are returned unchanged.
+func (r *Renderer) Text(line string) string {
+	rec := Parse(line)
+	if !rec.Structured {
+		return line
+	}
+
+	var b strings.Builder
+	if !rec.Time.IsZero() {
+		b.WriteString(output.Colorize(rec.Time.UTC().Format(timeLayout), output.ColorGray, r.Color))
+		b.WriteString(" ")
+	}
+
+	level := strings.ToUpper(rec.Level.String())
+	if rec.Level == logfilter.LevelUnknown {
+		level = ""
+	}
+	level += strings.Repeat(" ", max(levelWidth-len(level), 0))
+	b.WriteString(output.Colorize(level, levelColors[rec.Level], r.Color))
+	b.WriteString(" ")
+	b.WriteString(rec.Message)
+
+	if rec.Error != "" {
+		b.WriteString(" " + r.pair("error", rec.Error, output.ColorRed))
+	}
+	if rec.TraceID != "" {
+		b.WriteString(" " + r.pair("trace_id", rec.TraceID, ""))
+	}
+	for _, key := range r.Fields {
+		if v, ok := Lookup(rec.Fields, key); ok {
+			b.WriteString(" " + r.pair(key, FormatValue(v), ""))
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// pair formats key=value, quoting values that would otherwise be ambiguous
+func (r *Renderer) pair(key, value, color string) string {
+	if value == "" || strings.ContainsAny(value, " \t\"=") {
+		value = quote(value)
+	}
+	if color != "" {
+		value = output.Colorize(value, color, r.Color)
+	}
+	return output.Colorize(key+"=", output.ColorGray, r.Color) + value
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// Normalized is the machine-readable form of a log line
+type Normalized struct {
+	Time    *time.Time     `json:"time,omitempty"`
+	Level   string         `json:"level,omitempty"`
+	Message string         `json:"message"`
+	Error   string         `json:"error,omitempty"`
+	TraceID string         `json:"trace_id,omitempty"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+// Normalize maps a line to its common fields. Fields holds the fields selected with
+// Fields, or every other field of the line when none were selected. The message of a
+// plain text line is the line itself, and its level is detected from the text.
+func (r *Renderer) Normalize(line string) Normalized {
+	rec := Parse(line)
+	if !rec.Structured {
+		n := Normalized{Message: line}
+		if level := logfilter.DetectLevel(line); level != logfilter.LevelUnknown {
+			n.Level = level.String()
+		}
+		if t, ok := logfilter.DetectTimestamp(line); ok {
+			n.Time = &t
+		}
+		return n
+	}
+
+	n := Normalized{Message: rec.Message, Error: rec.Error, TraceID: rec.TraceID}
+	if rec.Level != logfilter.LevelUnknown {
+		n.Level = rec.Level.String()
+	}
+	if !rec.Time.IsZero() {
+		t := rec.Time.UTC()
+		n.Time = &t
+	}
+
+	if len(r.Fields) == 0 {
+		if extra := rec.Extra(); len(extra) > 0 {
+			n.Fields = extra
+		}
+		return n
+	}
+	for _, key := range r.Fields {
+		if v, ok := Lookup(rec.Fields, key); ok {
+			if n.Fields == nil {
+				n.Fields = make(map[string]any)
+			}
+			n.Fields[key] = v
+		}
+	}
+	return n
+}
@@ -0,0 +1,80 @@
+package logrender
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_Text(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		line   string
+		want   string
+	}{
+		{
+			name: "json",
+			line: `{"level":"error","msg":"db down","time":"2026-01-02T10:00:00Z","error":"connection refused","trace_id":"abc"}`,
+			want: `2026-01-02T10:00:00.000Z ERROR db down error="connection refused" trace_id=abc`,
+		},
+		{
+			name: "level is padded",
+			line: `{"level":"info","msg":"ready"}`,
+			want: `INFO  ready`,
+		},
+		{
+			name:   "extra fields in the order given",
+			fields: []string{"http.status", "user", "missing"},
+			line:   `level=info msg=served user="jane doe" http.status=200`,
+			want:   `INFO  served http.status=200 user="jane doe"`,
+		},
+		{
+			name: "plain text is unchanged",
+			line: "  at handler (server.js:10)",
+			want: "  at handler (server.js:10)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Renderer{Fields: tt.fields}
+			assert.Equal(t, tt.want, r.Text(tt.line))
+		})
+	}
+}
+
+func TestRenderer_TextColor(t *testing.T) {
+	r := &Renderer{Color: true}
+	got := r.Text(`{"level":"warn","msg":"slow"}`)
+	assert.Contains(t, got, "\033[")
+	assert.Contains(t, got, "slow")
+}
+
+func TestRenderer_Normalize(t *testing.T) {
+	line := `{"level":"warn","msg":"slow query","ts":1767348000,"duration_ms":812,"query":"SELECT 1"}`
+
+	t.Run("all extra fields", func(t *testing.T) {
+		n := (&Renderer{}).Normalize(line)
+		assert.Equal(t, "warn", n.Level)
+		assert.Equal(t, "slow query", n.Message)
+		require.NotNil(t, n.Time)
+		assert.Equal(t, time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC), *n.Time)
+		assert.Len(t, n.Fields, 2)
+		assert.Equal(t, "SELECT 1", n.Fields["query"])
+	})
+
+	t.Run("selected fields", func(t *testing.T) {
+		n := (&Renderer{Fields: []string{"query"}}).Normalize(line)
+		assert.Equal(t, map[string]any{"query": "SELECT 1"}, n.Fields)
+	})
+
+	t.Run("plain text", func(t *testing.T) {
+		n := (&Renderer{}).Normalize("2026-01-02 10:00:00 ERROR boom")
+		assert.Equal(t, "2026-01-02 10:00:00 ERROR boom", n.Message)
+		assert.Equal(t, "error", n.Level)
+		require.NotNil(t, n.Time)
+	})
+}
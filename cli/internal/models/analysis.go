@@ -0,0 +1,135 @@
+package models
+
+// Statuses of AI deployment analyses and code reviews
+const (
+	AnalysisStatusPending   = "pending"
+	AnalysisStatusAnalyzing = "analyzing"
+	AnalysisStatusCompleted = "completed"
+	AnalysisStatusFailed    = "failed"
+)
+
+// Severities of analyses and code-review violations, from least to most severe
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// Severities lists the severities from most to least severe
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow}
+
+// SeverityRank orders severities: 4 for critical down to 1 for low, 0 when unknown
+func SeverityRank(severity string) int {
+	for i, s := range Severities {
+		if s == severity {
+			return len(Severities) - i
+		}
+	}
+	return 0
+}
+
+// DeploymentAnalysis is the AI analysis of a failed deployment's logs
+type DeploymentAnalysis struct {
+	ID                int      `json:"id" table:"-"`
+	Status            string   `json:"status"`
+	RootCause         *string  `json:"root_cause,omitempty"`
+	RootCauseDetails  *string  `json:"root_cause_details,omitempty" table:"-"`
+	Solution          []string `json:"solution,omitempty" table:"-"`
+	Prevention        []string `json:"prevention,omitempty" table:"-"`
+	ErrorCategory     *string  `json:"error_category,omitempty" table:"-"`
+	CategoryLabel     *string  `json:"category_label,omitempty"`
+	Severity          *string  `json:"severity,omitempty"`
+	Confidence        float64  `json:"confidence" table:"-"`
+	ConfidencePercent float64  `json:"confidence_percent"`
+	Provider          *string  `json:"provider,omitempty" table:"-"`
+	Model             *string  `json:"model,omitempty" table:"-"`
+	TokensUsed        *int     `json:"tokens_used,omitempty" table:"-"`
+	ErrorMessage      *string  `json:"error_message,omitempty" table:"-"`
+	CreatedAt         string   `json:"created_at" table:"-"`
+	UpdatedAt         string   `json:"updated_at" table:"-"`
+}
+
+// DeploymentAnalysisResponse is returned when an analysis is fetched or requested
+type DeploymentAnalysisResponse struct {
+	Status   string              `json:"status"`
+	Message  string              `json:"message,omitempty"`
+	Analysis *DeploymentAnalysis `json:"analysis,omitempty"`
+}
+
+// AIStatus reports whether AI analysis is available on the instance
+type AIStatus struct {
+	Enabled   bool    `json:"enabled"`
+	Available bool    `json:"available"`
+	Provider  *string `json:"provider,omitempty"`
+	Model     *string `json:"model,omitempty"`
+}
+
+// CodeReview is the automated review of the commit a deployment built
+type CodeReview struct {
+	ID                   int                   `json:"id" table:"-"`
+	CommitSHA            string                `json:"commit_sha"`
+	BaseCommitSHA        *string               `json:"base_commit_sha,omitempty" table:"-"`
+	Status               string                `json:"status"`
+	StatusLabel          string                `json:"status_label"`
+	FilesAnalyzed        []string              `json:"files_analyzed,omitempty" table:"-"`
+	FilesCount           int                   `json:"files_count"`
+	ViolationsCount      int                   `json:"violations_count"`
+	CriticalCount        int                   `json:"critical_count"`
+	ViolationsBySeverity map[string]int        `json:"violations_by_severity,omitempty" table:"-"`
+	LLMProvider          *string               `json:"llm_provider,omitempty" table:"-"`
+	LLMModel             *string               `json:"llm_model,omitempty" table:"-"`
+	LLMFailed            bool                  `json:"llm_failed" table:"-"`
+	DurationMs           *int                  `json:"duration_ms,omitempty" table:"-"`
+	StartedAt            *string               `json:"started_at,omitempty" table:"-"`
+	FinishedAt           *string               `json:"finished_at,omitempty" table:"-"`
+	ErrorMessage         *string               `json:"error_message,omitempty" table:"-"`
+	Violations           []CodeReviewViolation `json:"violations,omitempty" table:"-"`
+}
+
+// CodeReviewResponse is returned when a code review is fetched or requested
+type CodeReviewResponse struct {
+	Status  string      `json:"status"`
+	Message string      `json:"message,omitempty"`
+	Review  *CodeReview `json:"review,omitempty"`
+}
+
+// CodeReviewViolation is a single finding of a code review
+type CodeReviewViolation struct {
+	ID              int      `json:"id" table:"-"`
+	RuleID          string   `json:"rule_id"`
+	RuleDescription string   `json:"rule_description" table:"-"`
+	RuleCategory    string   `json:"rule_category" table:"-"`
+	Source          string   `json:"source" table:"-"`
+	Severity        string   `json:"severity"`
+	Confidence      *float64 `json:"confidence,omitempty" table:"-"`
+	FilePath        string   `json:"file_path"`
+	LineNumber      *int     `json:"line_number,omitempty"`
+	Location        string   `json:"location" table:"-"`
+	Message         string   `json:"message"`
+	Snippet         *string  `json:"snippet,omitempty" table:"-"`
+	Suggestion      *string  `json:"suggestion,omitempty" table:"-"`
+	ContainsSecret  bool     `json:"contains_secret" table:"-"`
+}
+
+// CodeReviewViolations lists the violations of a code review. Violations revealing secrets
+// are hidden from users who cannot update the application.
+type CodeReviewViolations struct {
+	Violations    []CodeReviewViolation `json:"violations"`
+	TotalCount    int                   `json:"total_count"`
+	VisibleCount  int                   `json:"visible_count"`
+	SecretsHidden bool                  `json:"secrets_hidden"`
+}
+
+// CodeReviewStatus reports whether code review is enabled on the instance
+type CodeReviewStatus struct {
+	Enabled   bool            `json:"enabled"`
+	Mode      string          `json:"mode"`
+	Detectors map[string]bool `json:"detectors,omitempty"`
+	LLM       struct {
+		Enabled   bool    `json:"enabled"`
+		Available bool    `json:"available"`
+		Provider  *string `json:"provider,omitempty"`
+		Model     *string `json:"model,omitempty"`
+	} `json:"llm"`
+}
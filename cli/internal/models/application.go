@@ -9,8 +9,12 @@ type Application struct {
 	Status      string  `json:"status"`
 	GitBranch   *string `json:"git_branch,omitempty"`
 	FQDN        *string `json:"fqdn,omitempty"`
-	CreatedAt   string  `json:"-" table:"-"`
-	UpdatedAt   string  `json:"-" table:"-"`
+	// Commit ("HEAD" for the branch tip) and image tag new deployments are built from
+	GitCommitSHA           *string `json:"git_commit_sha,omitempty" table:"-"`
+	DockerRegistryImageTag *string `json:"docker_registry_image_tag,omitempty" table:"-"`
+	HealthCheckEnabled     *bool   `json:"health_check_enabled,omitempty" table:"-"`
+	CreatedAt              string  `json:"-" table:"-"`
+	UpdatedAt              string  `json:"-" table:"-"`
 }
 
 // ApplicationListItem represents a simplified application for list view
@@ -0,0 +1,24 @@
+package models
+
+// DeploymentApproval is a request to approve a deployment to a protected environment
+type DeploymentApproval struct {
+	UUID            string  `json:"uuid" table:"-"`
+	DeploymentUUID  string  `json:"deployment_uuid"`
+	Status          string  `json:"status"`
+	ApplicationName *string `json:"application_name,omitempty"`
+	ProjectName     *string `json:"project_name,omitempty"`
+	EnvironmentName *string `json:"environment_name,omitempty"`
+	RequestedBy     *string `json:"requested_by,omitempty"`
+	RequestedAt     string  `json:"requested_at"`
+	ApprovedBy      *string `json:"approved_by,omitempty"`
+	Comment         *string `json:"comment,omitempty"`
+	DecidedAt       *string `json:"decided_at,omitempty"`
+}
+
+// ApprovalResponse is returned when an approval is requested, granted or rejected
+type ApprovalResponse struct {
+	Message        string `json:"message"`
+	DeploymentUUID string `json:"deployment_uuid,omitempty"`
+	ApprovalUUID   string `json:"approval_uuid,omitempty"`
+	Status         string `json:"status,omitempty"`
+}
@@ -164,6 +164,14 @@ type DatabaseLifecycleResponse struct {
 	Message string `json:"message"`
 }
 
+// DatabaseLogsResponse represents the response from the database logs endpoint
+type DatabaseLogsResponse struct {
+	DatabaseUUID  string `json:"database_uuid"`
+	ContainerName string `json:"container_name"`
+	Status        string `json:"status"`
+	Logs          string `json:"logs"`
+}
+
 // DatabaseBackup represents a scheduled database backup configuration
 type DatabaseBackup struct {
 	ID                                       int     `json:"-" table:"-"`
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"strings"
+	"time"
+)
+
 // Deployment represents a deployment operation
 type Deployment struct {
 	ID              int     `json:"id" table:"-"`
@@ -11,6 +16,8 @@ type Deployment struct {
 	Commit          *string `json:"commit,omitempty"`
 	CommitMessage   *string `json:"commit_message,omitempty" table:"-"`
 	Rollback        *bool   `json:"rollback,omitempty" table:"-"`
+	// Application is the application the deployment belongs to, as embedded by the API
+	Application *DeploymentApplication `json:"application,omitempty" table:"-"`
 	// Additional fields from API that we want to ignore
 	DeploymentURL *string `json:"deployment_url,omitempty" table:"-"`
 	FinishedAt    *string `json:"finished_at,omitempty" table:"-"`
@@ -19,15 +26,134 @@ type Deployment struct {
 	UpdatedAt     *string `json:"updated_at,omitempty" table:"-"`
 }
 
+// DeploymentApplication identifies the application of a deployment
+type DeploymentApplication struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// CreatedTime returns when the deployment was queued, reporting false when the API left it out
+func (d Deployment) CreatedTime() (time.Time, bool) {
+	return parseAPITime(d.CreatedAt)
+}
+
+// FinishedTime returns when the deployment ended, reporting false while it is still running
+func (d Deployment) FinishedTime() (time.Time, bool) {
+	return parseAPITime(d.FinishedAt)
+}
+
+// BuildDuration returns how long the deployment took from being queued to ending,
+// reporting false while it is still running
+func (d Deployment) BuildDuration() (time.Duration, bool) {
+	created, ok := d.CreatedTime()
+	if !ok {
+		return 0, false
+	}
+	finished, ok := d.FinishedTime()
+	if !ok {
+		return 0, false
+	}
+	return finished.Sub(created), true
+}
+
+// ApplicationUUID returns the UUID of the deployed application, taken from the deployment
+// URL ("/applications/<uuid>/deployment/<deployment_uuid>"), or "" when it is unknown
+func (d Deployment) ApplicationUUID() string {
+	if d.DeploymentURL == nil {
+		return ""
+	}
+	_, rest, found := strings.Cut(*d.DeploymentURL, "/applications/")
+	if !found {
+		return ""
+	}
+	uuid, _, _ := strings.Cut(rest, "/")
+	return uuid
+}
+
+// ShortSHA abbreviates a commit SHA to 7 characters, the way git does
+func ShortSHA(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
+func parseAPITime(s *string) (time.Time, bool) {
+	if s == nil || *s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, *s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
 // DeployResponse wraps deployment trigger responses
 type DeployResponse struct {
 	Message        string `json:"message"`
 	DeploymentUUID string `json:"deployment_uuid,omitempty"`
 }
 
+// DeploymentLogPage is one page of entries from the deployment logs endpoint.
+// Hidden entries are never included.
+type DeploymentLogPage struct {
+	DeploymentUUID string     `json:"deployment_uuid"`
+	Status         string     `json:"status"`
+	Total          int        `json:"total"`
+	Logs           []LogEntry `json:"logs"`
+	Offset         int        `json:"offset"`
+	Limit          int        `json:"limit"`
+	HasMore        bool       `json:"has_more"`
+	Message        string     `json:"message,omitempty"`
+}
+
 // DeploymentLogsResponse represents the response from the deployment logs endpoint
 type DeploymentLogsResponse struct {
 	DeploymentUUID string `json:"deployment_uuid"`
 	Status         string `json:"status"`
 	Logs           string `json:"logs"`
 }
+
+// PromotionPlan describes what promoting a deployment to another environment will do
+type PromotionPlan struct {
+	DeploymentUUID        string `json:"deployment_uuid"`
+	Application           string `json:"application"`
+	Project               string `json:"project"`
+	SourceEnvironment     string `json:"source_environment"`
+	TargetEnvironment     string `json:"target_environment"`
+	TargetEnvironmentUUID string `json:"target_environment_uuid" table:"-"`
+	TargetApplicationUUID string `json:"target_application_uuid"`
+	// Commit is the commit whose image is promoted
+	Commit string `json:"commit"`
+	// CurrentCommit is the commit of the last successful deployment in the target
+	// environment, empty when it was never deployed
+	CurrentCommit string `json:"current_commit"`
+}
+
+// PromoteResponse is returned when a promotion is queued
+type PromoteResponse struct {
+	Message        string `json:"message"`
+	DeploymentUUID string `json:"deployment_uuid"`
+	PromotedImage  string `json:"promoted_image"`
+}
+
+// GitCommit is a commit from the local git log
+type GitCommit struct {
+	SHA     string `json:"sha"`
+	Author  string `json:"author"`
+	Subject string `json:"subject"`
+}
+
+// DeploymentDiff compares two deployments of an application
+type DeploymentDiff struct {
+	From Deployment `json:"from"`
+	To   Deployment `json:"to"`
+	// Commits and Files come from the local git repository; GitError explains why they
+	// are missing when it could not be used
+	Commits    []GitCommit         `json:"commits,omitempty"`
+	Files      []string            `json:"files,omitempty"`
+	Components map[string][]string `json:"components,omitempty"`
+	GitError   string              `json:"git_error,omitempty"`
+}
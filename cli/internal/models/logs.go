@@ -15,6 +15,7 @@ type LogEntry struct {
 	Hidden    bool    `json:"hidden"`
 	Batch     int     `json:"batch"`
 	Order     int     `json:"order,omitempty"`
+	Stage     string  `json:"stage,omitempty"`
 }
 
 // ParseAndFormatLogs parses the JSON logs string and formats it as human-readable text
@@ -147,6 +147,14 @@ func TestDeployment_MarshalUnmarshal(t *testing.T) {
 	assert.Equal(t, deployment.Status, unmarshaled.Status)
 }
 
+func TestDeployment_ApplicationUUID(t *testing.T) {
+	url := "/applications/app-uuid/deployment/dep-uuid"
+	other := "/project/p/environment/e"
+	assert.Equal(t, "app-uuid", Deployment{DeploymentURL: &url}.ApplicationUUID())
+	assert.Empty(t, Deployment{DeploymentURL: &other}.ApplicationUUID())
+	assert.Empty(t, Deployment{}.ApplicationUUID())
+}
+
 func TestDomain_MarshalUnmarshal(t *testing.T) {
 	domain := Domain{
 		IP:      "192.168.1.100",
@@ -357,3 +365,9 @@ func TestEnvironmentVariableCreateRequest_Marshal(t *testing.T) {
 	assert.NotNil(t, unmarshaled.IsBuildTime)
 	assert.True(t, *unmarshaled.IsBuildTime)
 }
+
+func TestShortSHA(t *testing.T) {
+	assert.Equal(t, "3f2a9c1", ShortSHA("3f2a9c1b8e0d4f5a6b7c8d9e0f1a2b3c4d5e6f7a"))
+	assert.Equal(t, "3f2a", ShortSHA("3f2a"))
+	assert.Equal(t, "", ShortSHA(""))
+}
@@ -33,3 +33,49 @@ type ProjectCreateRequest struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description,omitempty"`
 }
+
+// EnvironmentResources is an environment together with the resources deployed in it
+type EnvironmentResources struct {
+	UUID         string                `json:"uuid"`
+	Name         string                `json:"name"`
+	Applications []EnvironmentResource `json:"applications,omitempty"`
+	Services     []EnvironmentResource `json:"services,omitempty"`
+	Postgresqls  []EnvironmentResource `json:"postgresqls,omitempty"`
+	Redis        []EnvironmentResource `json:"redis,omitempty"`
+	Mongodbs     []EnvironmentResource `json:"mongodbs,omitempty"`
+	Mysqls       []EnvironmentResource `json:"mysqls,omitempty"`
+	Mariadbs     []EnvironmentResource `json:"mariadbs,omitempty"`
+}
+
+// EnvironmentResource is a single application, service or database within an environment
+type EnvironmentResource struct {
+	UUID   string `json:"uuid"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Type   string `json:"type,omitempty"`
+}
+
+// Resource type names used by EnvironmentResources.All
+const (
+	ResourceTypeApplication = "application"
+	ResourceTypeService     = "service"
+	ResourceTypeDatabase    = "database"
+)
+
+// All returns every resource in the environment with its Type set, applications first
+func (e *EnvironmentResources) All() []EnvironmentResource {
+	var all []EnvironmentResource
+	add := func(resources []EnvironmentResource, resourceType string) {
+		for _, r := range resources {
+			r.Type = resourceType
+			all = append(all, r)
+		}
+	}
+
+	add(e.Applications, ResourceTypeApplication)
+	add(e.Services, ResourceTypeService)
+	for _, dbs := range [][]EnvironmentResource{e.Postgresqls, e.Redis, e.Mongodbs, e.Mysqls, e.Mariadbs} {
+		add(dbs, ResourceTypeDatabase)
+	}
+	return all
+}
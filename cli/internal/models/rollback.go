@@ -1,17 +1,45 @@
 package models
 
+import "time"
+
 // RollbackEvent represents a rollback event for an application
 type RollbackEvent struct {
-	ID            int           `json:"id"`
-	ApplicationID int           `json:"application_id" table:"-"`
-	TriggerReason string        `json:"trigger_reason"`
-	TriggerType   string        `json:"trigger_type"`
-	Status        string        `json:"status"`
-	FromCommit    *string       `json:"from_commit,omitempty"`
-	ToCommit      *string       `json:"to_commit,omitempty"`
-	User          *RollbackUser `json:"user,omitempty" table:"-"`
-	CreatedAt     *string       `json:"created_at,omitempty" table:"-"`
-	UpdatedAt     *string       `json:"updated_at,omitempty" table:"-"`
+	ID                   int           `json:"id"`
+	ApplicationID        int           `json:"application_id" table:"-"`
+	FailedDeploymentID   *int          `json:"failed_deployment_id,omitempty" table:"-"`
+	RollbackDeploymentID *int          `json:"rollback_deployment_id,omitempty" table:"-"`
+	TriggerReason        string        `json:"trigger_reason"`
+	TriggerType          string        `json:"trigger_type"`
+	Status               string        `json:"status"`
+	FromCommit           *string       `json:"from_commit,omitempty"`
+	ToCommit             *string       `json:"to_commit,omitempty"`
+	User                 *RollbackUser `json:"user,omitempty" table:"-"`
+	TriggeredAt          *string       `json:"triggered_at,omitempty" table:"-"`
+	CompletedAt          *string       `json:"completed_at,omitempty" table:"-"`
+	CreatedAt            *string       `json:"created_at,omitempty" table:"-"`
+	UpdatedAt            *string       `json:"updated_at,omitempty" table:"-"`
+}
+
+// Rollback event statuses
+const (
+	RollbackStatusTriggered  = "triggered"
+	RollbackStatusInProgress = "in_progress"
+	RollbackStatusSuccess    = "success"
+	RollbackStatusFailed     = "failed"
+	RollbackStatusSkipped    = "skipped"
+)
+
+// TriggeredTime returns when the rollback was triggered, reporting false when the API left it out
+func (e RollbackEvent) TriggeredTime() (time.Time, bool) {
+	if t, ok := parseAPITime(e.TriggeredAt); ok {
+		return t, true
+	}
+	return parseAPITime(e.CreatedAt)
+}
+
+// CompletedTime returns when the rollback ended, reporting false while it is still running
+func (e RollbackEvent) CompletedTime() (time.Time, bool) {
+	return parseAPITime(e.CompletedAt)
 }
 
 // RollbackUser represents the user who triggered a rollback
@@ -17,6 +17,18 @@ type Settings struct {
 	IsUsable    bool `json:"is_usable"`
 }
 
+// Status summarises the settings as reachable:usable, reachable:unusable or unreachable
+func (s Server) Status() string {
+	switch {
+	case !s.Settings.IsReachable:
+		return "unreachable"
+	case s.Settings.IsUsable:
+		return "reachable:usable"
+	default:
+		return "reachable:unusable"
+	}
+}
+
 // ServerCreateRequest for creating servers
 type ServerCreateRequest struct {
 	Name            string `json:"name"`
@@ -65,11 +65,39 @@ type ServiceUpdateRequest struct {
 	DockerCompose *string `json:"docker_compose,omitempty"`
 }
 
+// ServiceHealthcheck is the health check configuration of a service, with its current status
+type ServiceHealthcheck struct {
+	Enabled     bool    `json:"enabled"`
+	Type        string  `json:"type"`
+	Test        string  `json:"test"`
+	Interval    int     `json:"interval"`
+	Timeout     int     `json:"timeout"`
+	Retries     int     `json:"retries"`
+	StartPeriod int     `json:"start_period"`
+	ServiceName *string `json:"service_name,omitempty"`
+	Status      string  `json:"status"`
+}
+
 // ServiceLifecycleResponse represents the response from lifecycle operations
 type ServiceLifecycleResponse struct {
 	Message string `json:"message"`
 }
 
+// ServiceLogsResponse represents the response from the service logs endpoint, keyed by container name
+type ServiceLogsResponse struct {
+	ServiceUUID string                          `json:"service_uuid"`
+	Containers  map[string]ServiceContainerLogs `json:"containers"`
+}
+
+// ServiceContainerLogs holds the logs of one container of a service.
+// Logs is nil when the container is not running.
+type ServiceContainerLogs struct {
+	Type   string  `json:"type"`
+	Name   string  `json:"name"`
+	Status string  `json:"status"`
+	Logs   *string `json:"logs"`
+}
+
 // ServiceEnvironmentVariable represents an environment variable for a service
 // Services don't have preview deployments, so IsPreview is excluded from output
 type ServiceEnvironmentVariable struct {
@@ -1,17 +1,64 @@
 package models
 
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
 // SmartConfig represents the .saturn.yml configuration file
 type SmartConfig struct {
-	Version    int                       `yaml:"version" json:"version"`
-	BaseBranch string                    `yaml:"base_branch" json:"base_branch"`
+	Version    int    `yaml:"version" json:"version"`
+	BaseBranch string `yaml:"base_branch" json:"base_branch"`
+	// Ignore lists the files that never trigger a deployment, e.g. **/*.md
+	Ignore     Patterns                  `yaml:"ignore,omitempty" json:"ignore,omitempty"`
 	Components map[string]SmartComponent `yaml:"components" json:"components"`
 }
 
 // SmartComponent defines a monorepo component mapping
 type SmartComponent struct {
-	Path     string   `yaml:"path" json:"path"`
+	Path Patterns `yaml:"path" json:"path"`
+	// Ignore lists the files under Path that do not concern the component
+	Ignore   Patterns `yaml:"ignore,omitempty" json:"ignore,omitempty"`
 	Resource string   `yaml:"resource,omitempty" json:"resource,omitempty"`
+	// Triggers lists the components deployed along with this one, transitively
 	Triggers []string `yaml:"triggers,omitempty" json:"triggers,omitempty"`
+	// DependsOn lists the components that finish deploying before this one starts
+	DependsOn []string `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
+}
+
+// Patterns is a list of glob patterns. In YAML it is written as a single string when it
+// holds one pattern, and may be given either way.
+type Patterns []string
+
+// UnmarshalYAML accepts a single pattern or a list of patterns
+func (p *Patterns) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		var pattern string
+		if err := node.Decode(&pattern); err != nil {
+			return err
+		}
+		*p = Patterns{pattern}
+		return nil
+	case yaml.SequenceNode:
+		var patterns []string
+		if err := node.Decode(&patterns); err != nil {
+			return err
+		}
+		*p = patterns
+		return nil
+	default:
+		return fmt.Errorf("line %d: expected a pattern or a list of patterns", node.Line)
+	}
+}
+
+// MarshalYAML writes a single pattern as a string
+func (p Patterns) MarshalYAML() (any, error) {
+	if len(p) == 1 {
+		return p[0], nil
+	}
+	return []string(p), nil
 }
 
 // SmartDeployPlan is the result of analyzing changed files against components
@@ -19,6 +66,9 @@ type SmartDeployPlan struct {
 	BaseBranch string                 `json:"base_branch"`
 	Components []SmartDeployComponent `json:"components"`
 	FilesTotal int                    `json:"files_total"`
+	// Waves lists the component names deployed together, in order; each wave starts once
+	// the deployments of the previous one finished
+	Waves [][]string `json:"waves"`
 }
 
 // SmartDeployComponent represents a single component matched for deployment
@@ -29,6 +79,8 @@ type SmartDeployComponent struct {
 	FilesChanged int      `json:"files_changed"`
 	Reason       string   `json:"reason"` // "direct" or "triggered"
 	TriggerBy    string   `json:"trigger_by,omitempty"`
+	Since        string   `json:"since,omitempty"` // commit the changes are measured from
+	Wave         int      `json:"wave"`            // 1-based index into SmartDeployPlan.Waves
 	Files        []string `json:"-"`
 }
 
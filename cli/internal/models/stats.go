@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// DeploymentStats summarises the deployments of one or more applications over a period.
+// Rates are percentages; metrics without data to compute them from are nil.
+type DeploymentStats struct {
+	Scope        string    `json:"scope"`
+	Since        time.Time `json:"since"`
+	Until        time.Time `json:"until"`
+	Applications int       `json:"applications"`
+
+	Deployments int `json:"deployments"`
+	Succeeded   int `json:"succeeded"`
+	Failed      int `json:"failed"`
+	Cancelled   int `json:"cancelled"`
+	InProgress  int `json:"in_progress"`
+
+	DeploysPerDay  float64  `json:"deploys_per_day"`
+	DeploysPerWeek float64  `json:"deploys_per_week"`
+	SuccessRate    *float64 `json:"success_rate"`
+	FailureRate    *float64 `json:"failure_rate"`
+
+	BuildP50Seconds *float64 `json:"build_p50_seconds"`
+	BuildP95Seconds *float64 `json:"build_p95_seconds"`
+
+	Rollbacks         int      `json:"rollbacks"`
+	ChangeFailureRate *float64 `json:"change_failure_rate"`
+	Restores          int      `json:"restores"`
+	MTTRSeconds       *float64 `json:"mean_time_to_restore_seconds"`
+
+	Weeks []WeeklyDeployments `json:"weeks"`
+}
+
+// WeeklyDeployments counts the deployments queued in the week starting on Monday Start
+type WeeklyDeployments struct {
+	Start     time.Time `json:"start"`
+	Succeeded int       `json:"succeeded"`
+	Failed    int       `json:"failed"`
+	Other     int       `json:"other"`
+}
@@ -11,6 +11,7 @@ const (
 	FormatTable  = "table"
 	FormatJSON   = "json"
 	FormatPretty = "pretty"
+	FormatNDJSON = "ndjson"
 )
 
 // Formatter is the interface for output formatting
@@ -39,6 +40,8 @@ func NewFormatter(format string, opts Options) (Formatter, error) {
 		return NewJSONFormatter(opts), nil
 	case FormatPretty:
 		return NewPrettyFormatter(opts), nil
+	case FormatNDJSON:
+		return NewNDJSONFormatter(opts), nil
 	default:
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Event types emitted by long-running commands in ndjson format
+const (
+	EventPlan          = "plan"
+	EventDeployStarted = "deploy_started"
+	EventStatusChanged = "status_changed"
+	EventLog           = "log"
+	EventFinished      = "finished"
+	EventError         = "error"
+)
+
+// NDJSONFormatter formats output as newline-delimited JSON, one object per line
+type NDJSONFormatter struct {
+	opts Options
+}
+
+// NewNDJSONFormatter creates a new NDJSON formatter
+func NewNDJSONFormatter(opts Options) *NDJSONFormatter {
+	return &NDJSONFormatter{opts: opts}
+}
+
+// Format writes each element of a slice on its own line, or a single line for any other value
+func (f *NDJSONFormatter) Format(data interface{}) error {
+	encoder := json.NewEncoder(f.opts.Writer)
+
+	val := reflect.ValueOf(data)
+	if val.Kind() == reflect.Ptr && !val.IsNil() && val.Elem().Kind() == reflect.Slice {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return encoder.Encode(data)
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		if err := encoder.Encode(val.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Event is a single machine-readable progress event
+type Event struct {
+	Type           string    `json:"type"`
+	Timestamp      time.Time `json:"timestamp"`
+	Resource       string    `json:"resource,omitempty"`
+	DeploymentUUID string    `json:"deployment_uuid,omitempty"`
+	Status         string    `json:"status,omitempty"`
+	Message        string    `json:"message,omitempty"`
+	Data           any       `json:"data,omitempty"`
+}
+
+// EventWriter writes events as NDJSON. It is safe for concurrent use.
+type EventWriter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	now     func() time.Time
+}
+
+// NewEventWriter creates an event writer on w
+func NewEventWriter(w io.Writer) *EventWriter {
+	return &EventWriter{
+		encoder: json.NewEncoder(w),
+		now:     time.Now,
+	}
+}
+
+// Emit writes one event, filling in the timestamp if it is not set
+func (w *EventWriter) Emit(event Event) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = w.now().UTC()
+	}
+	return w.encoder.Encode(event)
+}
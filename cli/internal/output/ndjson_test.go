@@ -0,0 +1,79 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSONFormatter_Slice(t *testing.T) {
+	buf := &bytes.Buffer{}
+	formatter, err := NewFormatter(FormatNDJSON, Options{Writer: buf})
+	require.NoError(t, err)
+
+	servers := []TestServer{
+		{UUID: "uuid-1", Name: "server-1", Status: "running"},
+		{UUID: "uuid-2", Name: "server-2", Status: "stopped"},
+	}
+	require.NoError(t, formatter.Format(servers))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var got TestServer
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &got))
+	assert.Equal(t, "uuid-2", got.UUID)
+}
+
+func TestNDJSONFormatter_Single(t *testing.T) {
+	buf := &bytes.Buffer{}
+	formatter := NewNDJSONFormatter(Options{Writer: buf})
+
+	require.NoError(t, formatter.Format(TestServer{UUID: "uuid-1"}))
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), `"uuid":"uuid-1"`)
+}
+
+func TestEventWriter_Emit(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := NewEventWriter(buf)
+	w.now = fixedNow
+
+	require.NoError(t, w.Emit(Event{Type: EventStatusChanged, Resource: "api", DeploymentUUID: "dep-1", Status: "in_progress"}))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "status_changed", got["type"])
+	assert.Equal(t, "2026-01-02T10:30:00Z", got["timestamp"])
+	assert.Equal(t, "api", got["resource"])
+	assert.Equal(t, "dep-1", got["deployment_uuid"])
+	assert.Equal(t, "in_progress", got["status"])
+	assert.NotContains(t, got, "message")
+	assert.NotContains(t, got, "data")
+}
+
+func TestEventWriter_Concurrent(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := NewEventWriter(buf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = w.Emit(Event{Type: EventLog, Message: "line"})
+		}()
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 20)
+	for _, line := range lines {
+		assert.True(t, json.Valid([]byte(line)), line)
+	}
+}
@@ -3,6 +3,7 @@ package output
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"text/tabwriter"
 )
@@ -279,3 +280,54 @@ func (f *TableFormatter) formatValue(val reflect.Value) string {
 		return fmt.Sprintf("%v", val.Interface())
 	}
 }
+
+// Rows returns the column headers and cell values that Format would print,
+// without the leading row number column. It is used by renderers that need
+// to lay out or compare rows themselves (e.g. --watch).
+func (f *TableFormatter) Rows(data any) ([]string, [][]string, error) {
+	val := reflect.ValueOf(data)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		elemType := val.Type().Elem()
+		if elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() != reflect.Struct {
+			rows := make([][]string, 0, val.Len())
+			for i := 0; i < val.Len(); i++ {
+				rows = append(rows, []string{f.formatValue(val.Index(i))})
+			}
+			return []string{"value"}, rows, nil
+		}
+
+		headers := f.getHeaders(elemType)
+		rows := make([][]string, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			elem := val.Index(i)
+			if elem.Kind() == reflect.Ptr {
+				if elem.IsNil() {
+					continue
+				}
+				elem = elem.Elem()
+			}
+			rows = append(rows, f.formatStructRow(elem))
+		}
+		return headers, rows, nil
+	case reflect.Struct:
+		return f.getHeaders(val.Type()), [][]string{f.formatStructRow(val)}, nil
+	case reflect.Map:
+		rows := make([][]string, 0, val.Len())
+		iter := val.MapRange()
+		for iter.Next() {
+			rows = append(rows, []string{fmt.Sprintf("%v", iter.Key().Interface()), f.formatValue(iter.Value())})
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+		return []string{"Key", "Value"}, rows, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported data type for table format: %v", val.Kind())
+	}
+}
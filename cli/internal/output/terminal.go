@@ -0,0 +1,66 @@
+package output
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences used for terminal output
+const (
+	ColorReset   = "\033[0m"
+	ColorBold    = "\033[1m"
+	ColorReverse = "\033[7m"
+	ColorRed     = "\033[31m"
+	ColorGreen   = "\033[32m"
+	ColorYellow  = "\033[33m"
+	ColorBlue    = "\033[34m"
+	ColorMagenta = "\033[35m"
+	ColorCyan    = "\033[36m"
+	ColorGray    = "\033[90m"
+
+	// ClearScreen moves the cursor home and clears the visible screen
+	ClearScreen = "\033[H\033[2J"
+)
+
+// IsTerminal reports whether w is an interactive terminal. Other character devices,
+// like /dev/null, are not.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// ColorEnabled reports whether colored output should be written to w.
+// Colors are used only on terminals and can be disabled with NO_COLOR.
+func ColorEnabled(w io.Writer) bool {
+	return os.Getenv("NO_COLOR") == "" && IsTerminal(w)
+}
+
+// Colorize wraps s in the given ANSI color when enabled is true
+func Colorize(s, color string, enabled bool) string {
+	if !enabled || color == "" || s == "" {
+		return s
+	}
+	return color + s + ColorReset
+}
+
+// StatusColor returns the color conventionally used for a resource or deployment status
+func StatusColor(status string) string {
+	state := strings.ToLower(status)
+	if i := strings.Index(state, ":"); i >= 0 {
+		state = state[:i]
+	}
+
+	switch state {
+	case "running", "finished", "healthy", "approved", "completed", "success":
+		return ColorGreen
+	case "failed", "error", "exited", "degraded", "timed-out", "cancelled-by-user", "rejected", "unhealthy":
+		return ColorRed
+	case "":
+		return ""
+	default:
+		return ColorYellow
+	}
+}
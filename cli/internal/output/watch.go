@@ -0,0 +1,299 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UntilCondition is a field=value condition that ends a watch once every row matches
+type UntilCondition struct {
+	Field  string
+	Values []string
+}
+
+// ParseUntil parses --until conditions of the form field=value[|value...]
+func ParseUntil(specs []string) ([]UntilCondition, error) {
+	conds := make([]UntilCondition, 0, len(specs))
+	for _, spec := range specs {
+		field, value, ok := strings.Cut(spec, "=")
+		field = strings.TrimSpace(field)
+		value = strings.TrimSpace(value)
+		if !ok || field == "" || value == "" {
+			return nil, fmt.Errorf("invalid --until condition %q: expected field=value", spec)
+		}
+		conds = append(conds, UntilCondition{Field: field, Values: strings.Split(value, "|")})
+	}
+	return conds, nil
+}
+
+// Matches checks whether a cell value satisfies the condition. A value also
+// matches on the part before ":" so that "running" matches "running:healthy".
+func (c UntilCondition) Matches(cell string) bool {
+	state, _, _ := strings.Cut(cell, ":")
+	for _, want := range c.Values {
+		if strings.EqualFold(cell, want) || strings.EqualFold(state, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// WatchRenderer renders successive snapshots of the same data set. On a
+// terminal it redraws the table in place; otherwise it prints the full table
+// once and then only rows that were added, changed or removed. Status
+// transitions between snapshots are highlighted.
+type WatchRenderer struct {
+	opts     Options
+	format   string
+	title    string
+	redraw   bool
+	table    *TableFormatter
+	headers  []string
+	rows     [][]string
+	previous map[string][]string
+	lastRaw  string
+	frames   int
+	now      func() time.Time
+}
+
+// NewWatchRenderer creates a renderer for the given format. When redraw is
+// true the screen is cleared before each frame and title is printed on top.
+func NewWatchRenderer(format string, opts Options, redraw bool, title string) *WatchRenderer {
+	return &WatchRenderer{
+		opts:   opts,
+		format: format,
+		title:  title,
+		redraw: redraw,
+		table:  NewTableFormatter(opts),
+		now:    time.Now,
+	}
+}
+
+// Render writes one snapshot of data
+func (r *WatchRenderer) Render(data any) error {
+	defer func() { r.frames++ }()
+
+	if r.format != FormatTable {
+		return r.renderRaw(data)
+	}
+
+	headers, rows, err := r.table.Rows(data)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string][]string, len(rows))
+	for _, row := range rows {
+		current[rowKey(headers, row)] = row
+	}
+
+	if r.redraw {
+		err = r.renderFrame(headers, rows)
+	} else {
+		err = r.renderChanges(data, headers, rows, current)
+	}
+
+	r.headers = headers
+	r.rows = rows
+	r.previous = current
+	return err
+}
+
+// Satisfied reports whether every row of the last snapshot matches all conditions
+func (r *WatchRenderer) Satisfied(conds []UntilCondition) bool {
+	if len(conds) == 0 || len(r.rows) == 0 {
+		return false
+	}
+	for _, cond := range conds {
+		col := columnIndex(r.headers, cond.Field)
+		if col < 0 {
+			return false
+		}
+		for _, row := range r.rows {
+			if col >= len(row) || !cond.Matches(row[col]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// renderRaw prints JSON output whenever it differs from the previous snapshot
+func (r *WatchRenderer) renderRaw(data any) error {
+	var buf bytes.Buffer
+	opts := r.opts
+	opts.Writer = &buf
+	formatter, err := NewFormatter(r.format, opts)
+	if err != nil {
+		return err
+	}
+	if err := formatter.Format(data); err != nil {
+		return err
+	}
+
+	if r.frames > 0 && buf.String() == r.lastRaw {
+		return nil
+	}
+	r.lastRaw = buf.String()
+
+	if err := r.writeTitle(); err != nil {
+		return err
+	}
+	_, err = r.opts.Writer.Write(buf.Bytes())
+	return err
+}
+
+// renderFrame clears the screen and prints the whole table
+func (r *WatchRenderer) renderFrame(headers []string, rows [][]string) error {
+	if err := r.writeTitle(); err != nil {
+		return err
+	}
+
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(r.opts.Writer, "No data")
+		return err
+	}
+
+	statusCol := columnIndex(headers, "status")
+	widths := columnWidths(headers, rows)
+
+	if _, err := fmt.Fprintln(r.opts.Writer, padRow(headers, widths, nil)); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		colors := make([]string, len(row))
+		if statusCol >= 0 && statusCol < len(row) {
+			colors[statusCol] = r.statusColor(headers, row, statusCol)
+		}
+		if _, err := fmt.Fprintln(r.opts.Writer, padRow(row, widths, colors)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderChanges prints the full table on the first frame and a line per changed row afterwards
+func (r *WatchRenderer) renderChanges(data any, headers []string, rows [][]string, current map[string][]string) error {
+	if r.frames == 0 {
+		return r.table.Format(data)
+	}
+
+	stamp := r.now().Format("15:04:05")
+	statusCol := columnIndex(headers, "status")
+
+	for _, row := range rows {
+		key := rowKey(headers, row)
+		prev, existed := r.previous[key]
+		switch {
+		case !existed:
+			if _, err := fmt.Fprintf(r.opts.Writer, "[%s] + %s\n", stamp, strings.Join(row, "  ")); err != nil {
+				return err
+			}
+		case !equalRows(prev, row):
+			line := strings.Join(row, "  ")
+			if statusCol >= 0 && statusCol < len(row) && statusCol < len(prev) && prev[statusCol] != row[statusCol] {
+				line += fmt.Sprintf("  (%s: %s -> %s)", headers[statusCol], prev[statusCol], row[statusCol])
+			}
+			if _, err := fmt.Fprintf(r.opts.Writer, "[%s] ~ %s\n", stamp, line); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, row := range r.rows {
+		if _, ok := current[rowKey(r.headers, row)]; !ok {
+			if _, err := fmt.Fprintf(r.opts.Writer, "[%s] - %s\n", stamp, strings.Join(row, "  ")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// statusColor picks the color for a status cell, highlighting it if it changed since the last frame
+func (r *WatchRenderer) statusColor(headers, row []string, col int) string {
+	if !r.opts.Color {
+		return ""
+	}
+	color := StatusColor(row[col])
+	if prev, ok := r.previous[rowKey(headers, row)]; ok && col < len(prev) && prev[col] != row[col] {
+		return ColorReverse + ColorBold + color
+	}
+	return color
+}
+
+func (r *WatchRenderer) writeTitle() error {
+	if !r.redraw {
+		return nil
+	}
+	title := fmt.Sprintf("%s    %s", r.title, r.now().Format("15:04:05"))
+	_, err := fmt.Fprintf(r.opts.Writer, "%s%s\n\n", ClearScreen, Colorize(title, ColorGray, r.opts.Color))
+	return err
+}
+
+// rowKey identifies a row across snapshots by its uuid column, falling back to the first column
+func rowKey(headers, row []string) string {
+	for _, name := range []string{"uuid", "deployment_uuid", "Key"} {
+		if col := columnIndex(headers, name); col >= 0 && col < len(row) {
+			return row[col]
+		}
+	}
+	if len(row) > 0 {
+		return row[0]
+	}
+	return ""
+}
+
+func columnIndex(headers []string, name string) int {
+	for i, h := range headers {
+		if strings.EqualFold(h, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func columnWidths(headers []string, rows [][]string) []int {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) && len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+	return widths
+}
+
+// padRow aligns cells to the column widths, coloring cells without affecting alignment
+func padRow(cells []string, widths []int, colors []string) string {
+	parts := make([]string, len(cells))
+	for i, cell := range cells {
+		padded := cell
+		if i < len(widths) && i < len(cells)-1 {
+			padded += strings.Repeat(" ", widths[i]-len(cell))
+		}
+		if i < len(colors) && colors[i] != "" {
+			padded = Colorize(cell, colors[i], true) + padded[len(cell):]
+		}
+		parts[i] = padded
+	}
+	return strings.Join(parts, "  ")
+}
+
+func equalRows(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
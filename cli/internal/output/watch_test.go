@@ -0,0 +1,165 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixedNow() time.Time {
+	return time.Date(2026, 1, 2, 10, 30, 0, 0, time.UTC)
+}
+
+func TestParseUntil(t *testing.T) {
+	conds, err := ParseUntil([]string{"status=running", "name = api|web"})
+	require.NoError(t, err)
+	require.Len(t, conds, 2)
+	assert.Equal(t, "status", conds[0].Field)
+	assert.Equal(t, []string{"running"}, conds[0].Values)
+	assert.Equal(t, "name", conds[1].Field)
+	assert.Equal(t, []string{"api", "web"}, conds[1].Values)
+
+	for _, invalid := range []string{"status", "=running", "status="} {
+		_, err := ParseUntil([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestUntilCondition_Matches(t *testing.T) {
+	cond := UntilCondition{Field: "status", Values: []string{"running"}}
+	assert.True(t, cond.Matches("running"))
+	assert.True(t, cond.Matches("running:healthy"))
+	assert.True(t, cond.Matches("Running"))
+	assert.False(t, cond.Matches("exited:unhealthy"))
+
+	exact := UntilCondition{Field: "status", Values: []string{"running:healthy"}}
+	assert.True(t, exact.Matches("running:healthy"))
+	assert.False(t, exact.Matches("running:unhealthy"))
+}
+
+func TestTableFormatter_Rows(t *testing.T) {
+	servers := []TestServer{
+		{UUID: "uuid-1", Name: "server-1", Status: "running"},
+		{UUID: "uuid-2", Name: "server-2", Status: "stopped"},
+	}
+
+	headers, rows, err := NewTableFormatter(Options{}).Rows(servers)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"uuid", "name", "status"}, headers)
+	assert.Equal(t, [][]string{{"uuid-1", "server-1", "running"}, {"uuid-2", "server-2", "stopped"}}, rows)
+
+	headers, rows, err = NewTableFormatter(Options{}).Rows(&servers[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"uuid", "name", "status"}, headers)
+	assert.Len(t, rows, 1)
+
+	headers, rows, err = NewTableFormatter(Options{}).Rows([]TestServer{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"uuid", "name", "status"}, headers)
+	assert.Empty(t, rows)
+}
+
+func TestWatchRenderer_ChangesOnly(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r := NewWatchRenderer(FormatTable, Options{Writer: buf}, false, "")
+	r.now = fixedNow
+
+	require.NoError(t, r.Render([]TestServer{
+		{UUID: "uuid-1", Name: "api", Status: "starting"},
+		{UUID: "uuid-2", Name: "web", Status: "running"},
+	}))
+	first := buf.String()
+	assert.Contains(t, first, "uuid-1")
+	assert.Contains(t, first, "uuid-2")
+
+	// Identical snapshot prints nothing
+	buf.Reset()
+	require.NoError(t, r.Render([]TestServer{
+		{UUID: "uuid-1", Name: "api", Status: "starting"},
+		{UUID: "uuid-2", Name: "web", Status: "running"},
+	}))
+	assert.Empty(t, buf.String())
+
+	buf.Reset()
+	require.NoError(t, r.Render([]TestServer{
+		{UUID: "uuid-1", Name: "api", Status: "running"},
+		{UUID: "uuid-3", Name: "worker", Status: "starting"},
+	}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "[10:30:00] ~ uuid-1  api  running  (status: starting -> running)", lines[0])
+	assert.Equal(t, "[10:30:00] + uuid-3  worker  starting", lines[1])
+	assert.Equal(t, "[10:30:00] - uuid-2  web  running", lines[2])
+}
+
+func TestWatchRenderer_Redraw(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r := NewWatchRenderer(FormatTable, Options{Writer: buf, Color: true}, true, "Every 2s: saturn app list")
+	r.now = fixedNow
+
+	require.NoError(t, r.Render([]TestServer{{UUID: "uuid-1", Name: "api", Status: "starting"}}))
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, ClearScreen))
+	assert.Contains(t, out, "Every 2s: saturn app list")
+	assert.Contains(t, out, ColorYellow+"starting"+ColorReset)
+
+	// A status transition is highlighted on the next frame
+	buf.Reset()
+	require.NoError(t, r.Render([]TestServer{{UUID: "uuid-1", Name: "api", Status: "running"}}))
+	assert.Contains(t, buf.String(), ColorReverse+ColorBold+ColorGreen+"running"+ColorReset)
+
+	// Once stable, the status is no longer highlighted
+	buf.Reset()
+	require.NoError(t, r.Render([]TestServer{{UUID: "uuid-1", Name: "api", Status: "running"}}))
+	assert.NotContains(t, buf.String(), ColorReverse)
+	assert.Contains(t, buf.String(), ColorGreen+"running"+ColorReset)
+}
+
+func TestWatchRenderer_Satisfied(t *testing.T) {
+	r := NewWatchRenderer(FormatTable, Options{Writer: &bytes.Buffer{}}, false, "")
+	conds, err := ParseUntil([]string{"status=running"})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Render([]TestServer{
+		{UUID: "uuid-1", Status: "running:healthy"},
+		{UUID: "uuid-2", Status: "starting"},
+	}))
+	assert.False(t, r.Satisfied(conds))
+
+	require.NoError(t, r.Render([]TestServer{
+		{UUID: "uuid-1", Status: "running:healthy"},
+		{UUID: "uuid-2", Status: "running:unknown"},
+	}))
+	assert.True(t, r.Satisfied(conds))
+
+	unknown, err := ParseUntil([]string{"phase=done"})
+	require.NoError(t, err)
+	assert.False(t, r.Satisfied(unknown))
+}
+
+func TestWatchRenderer_JSONPrintsOnlyOnChange(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r := NewWatchRenderer(FormatJSON, Options{Writer: buf}, false, "")
+
+	data := []TestServer{{UUID: "uuid-1", Status: "running"}}
+	require.NoError(t, r.Render(data))
+	require.NoError(t, r.Render(data))
+	assert.Equal(t, 1, strings.Count(buf.String(), "uuid-1"))
+
+	require.NoError(t, r.Render([]TestServer{{UUID: "uuid-1", Status: "stopped"}}))
+	assert.Equal(t, 2, strings.Count(buf.String(), "uuid-1"))
+}
+
+func TestIsTerminal_NotForOtherDevices(t *testing.T) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	defer devNull.Close()
+
+	assert.False(t, IsTerminal(devNull))
+	assert.False(t, IsTerminal(&bytes.Buffer{}))
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// AnalysisStatusNotFound is reported while a deployment has no analysis or code review yet
+const AnalysisStatusNotFound = "not_found"
+
+// AnalysisService handles AI analyses of failed deployments
+type AnalysisService struct {
+	client *api.Client
+}
+
+// NewAnalysisService creates a new analysis service
+func NewAnalysisService(client *api.Client) *AnalysisService {
+	return &AnalysisService{client: client}
+}
+
+// Status reports whether AI analysis is enabled and has a provider on the instance
+func (s *AnalysisService) Status(ctx context.Context) (*models.AIStatus, error) {
+	var status models.AIStatus
+	if err := s.client.Get(ctx, "ai/status", &status); err != nil {
+		return nil, fmt.Errorf("failed to get AI status: %w", err)
+	}
+	return &status, nil
+}
+
+// Get retrieves the analysis of a deployment. A deployment without analysis is reported
+// with status AnalysisStatusNotFound rather than an error.
+func (s *AnalysisService) Get(ctx context.Context, deploymentUUID string) (*models.DeploymentAnalysisResponse, error) {
+	var response models.DeploymentAnalysisResponse
+	if err := s.client.Get(ctx, fmt.Sprintf("deployments/%s/analysis", deploymentUUID), &response); err != nil {
+		if api.IsNotFound(err) {
+			return &models.DeploymentAnalysisResponse{Status: AnalysisStatusNotFound}, nil
+		}
+		return nil, fmt.Errorf("failed to get analysis of deployment %s: %w", deploymentUUID, err)
+	}
+	return &response, nil
+}
+
+// Analyze queues an analysis of a failed deployment
+func (s *AnalysisService) Analyze(ctx context.Context, deploymentUUID string) (*models.DeploymentAnalysisResponse, error) {
+	var response models.DeploymentAnalysisResponse
+	if err := s.client.Post(ctx, fmt.Sprintf("deployments/%s/analyze", deploymentUUID), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to analyze deployment %s: %w", deploymentUUID, err)
+	}
+	return &response, nil
+}
+
+// WaitForAnalysis polls until the analysis of a deployment is completed or failed.
+// previous is the analysis that existed before a new one was requested; it is skipped
+// until the server has replaced it.
+func (s *AnalysisService) WaitForAnalysis(ctx context.Context, deploymentUUID string, pollInterval time.Duration, previous *models.DeploymentAnalysis) (*models.DeploymentAnalysis, error) {
+	if pollInterval <= 0 {
+		pollInterval = 3 * time.Second
+	}
+
+	for {
+		response, err := s.Get(ctx, deploymentUUID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+
+		if a := response.Analysis; a != nil && analysisDone(a.Status) {
+			if previous == nil || a.ID != previous.ID || a.UpdatedAt != previous.UpdatedAt {
+				return a, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// analysisDone reports whether an analysis or code review reached a final status
+func analysisDone(status string) bool {
+	return status == models.AnalysisStatusCompleted || status == models.AnalysisStatusFailed
+}
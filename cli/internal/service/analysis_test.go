@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+func TestAnalysisService_Get_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/deployments/dep-1/analysis", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status":"not_found","message":"No analysis available for this deployment"}`))
+	}))
+	defer server.Close()
+
+	svc := NewAnalysisService(api.NewClient(server.URL, "test-token"))
+	response, err := svc.Get(context.Background(), "dep-1")
+	require.NoError(t, err)
+	assert.Equal(t, AnalysisStatusNotFound, response.Status)
+	assert.Nil(t, response.Analysis)
+}
+
+func TestAnalysisService_WaitForAnalysis(t *testing.T) {
+	// The old analysis is returned until the new one is picked up and completed
+	responses := []string{
+		`{"status":"completed","analysis":{"id":1,"status":"completed","updated_at":"2026-01-01T00:00:00Z"}}`,
+		`{"status":"analyzing","analysis":{"id":1,"status":"analyzing","updated_at":"2026-01-02T00:00:00Z"}}`,
+		`{"status":"completed","analysis":{"id":1,"status":"completed","root_cause":"Missing dependency","solution":["Add it"],"confidence":0.85,"confidence_percent":85.0,"updated_at":"2026-01-02T00:01:00Z"}}`,
+	}
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/deployments/dep-1/analysis", r.URL.Path)
+		i := min(int(calls.Add(1))-1, len(responses)-1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(responses[i]))
+	}))
+	defer server.Close()
+
+	svc := NewAnalysisService(api.NewClient(server.URL, "test-token"))
+	previous := &models.DeploymentAnalysis{ID: 1, Status: "completed", UpdatedAt: "2026-01-01T00:00:00Z"}
+	analysis, err := svc.WaitForAnalysis(context.Background(), "dep-1", time.Millisecond, previous)
+	require.NoError(t, err)
+	assert.Equal(t, "Missing dependency", *analysis.RootCause)
+	assert.Equal(t, []string{"Add it"}, analysis.Solution)
+	assert.InDelta(t, 85, analysis.ConfidencePercent, 0.001)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestAnalysisService_WaitForAnalysis_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status":"not_found"}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	svc := NewAnalysisService(api.NewClient(server.URL, "test-token"))
+	_, err := svc.WaitForAnalysis(ctx, "dep-1", 5*time.Millisecond, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCodeReviewService_TriggerAndViolations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/deployments/dep-1/code-review":
+			assert.Equal(t, http.MethodPost, r.Method)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"status":"completed","message":"Code review already exists for this commit","review":{"id":7,"commit_sha":"abc","status":"completed","status_label":"Issues Found","violations_count":2,"violations_by_severity":{"high":1,"low":1}}}`))
+		case "/api/v1/deployments/dep-1/code-review/violations":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"violations":[{"rule_id":"SEC001","severity":"high","file_path":"app.py","line_number":3,"message":"Hardcoded key","confidence":1.0}],"total_count":2,"visible_count":1,"secrets_hidden":true}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc := NewCodeReviewService(api.NewClient(server.URL, "test-token"))
+	triggered, err := svc.Trigger(context.Background(), "dep-1")
+	require.NoError(t, err)
+	require.NotNil(t, triggered.Review)
+	assert.Equal(t, 1, triggered.Review.ViolationsBySeverity["high"])
+
+	violations, err := svc.Violations(context.Background(), "dep-1")
+	require.NoError(t, err)
+	require.Len(t, violations.Violations, 1)
+	assert.Equal(t, "SEC001", violations.Violations[0].RuleID)
+	assert.Equal(t, 3, *violations.Violations[0].LineNumber)
+	assert.True(t, violations.SecretsHidden)
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/saturn-platform/saturn-cli/internal/api"
 	"github.com/saturn-platform/saturn-cli/internal/models"
@@ -40,6 +41,33 @@ func (s *ApplicationService) Get(ctx context.Context, uuid string) (*models.Appl
 	return &app, nil
 }
 
+// Find returns the application with the given UUID or, failing that, the one with the given
+// name. A name shared by several applications is an error.
+func (s *ApplicationService) Find(ctx context.Context, ref string) (*models.Application, error) {
+	apps, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range apps {
+		if apps[i].UUID == ref {
+			return &apps[i], nil
+		}
+	}
+	var match *models.Application
+	for i := range apps {
+		if apps[i].Name == ref {
+			if match != nil {
+				return nil, fmt.Errorf("several applications are named '%s'; use the UUID instead", ref)
+			}
+			match = &apps[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("application '%s' not found", ref)
+	}
+	return match, nil
+}
+
 // Update updates an application
 func (s *ApplicationService) Update(ctx context.Context, uuid string, req models.ApplicationUpdateRequest) (*models.Application, error) {
 	var app models.Application
@@ -105,6 +133,15 @@ func (s *ApplicationService) Restart(ctx context.Context, uuid string) (*models.
 	return &resp, nil
 }
 
+// Status returns the status of an application for WaitForResource
+func (s *ApplicationService) Status(ctx context.Context, uuid string) (ResourceStatus, error) {
+	app, err := s.Get(ctx, uuid)
+	if err != nil {
+		return ResourceStatus{}, err
+	}
+	return ResourceStatus{Status: app.Status, HealthChecked: app.HealthCheckEnabled != nil && *app.HealthCheckEnabled}, nil
+}
+
 // Logs retrieves logs for an application
 func (s *ApplicationService) Logs(ctx context.Context, uuid string, lines int) (*models.ApplicationLogsResponse, error) {
 	url := fmt.Sprintf("applications/%s/logs", uuid)
@@ -122,6 +159,39 @@ func (s *ApplicationService) Logs(ctx context.Context, uuid string, lines int) (
 	return &resp, nil
 }
 
+// NewLogLines returns the lines of curr that were not already in prev, where both are
+// tail windows of the same log. The window may have slid between the two fetches, so
+// the longest suffix of prev that is also a prefix of curr is treated as already seen.
+func NewLogLines(prev, curr string) string {
+	if prev == "" || curr == "" {
+		return curr
+	}
+	prevLines := strings.Split(strings.TrimSuffix(prev, "\n"), "\n")
+	currLines := strings.Split(strings.TrimSuffix(curr, "\n"), "\n")
+
+	for start := range prevLines {
+		overlap := prevLines[start:]
+		if len(overlap) > len(currLines) || !equalLines(overlap, currLines[:len(overlap)]) {
+			continue
+		}
+		fresh := currLines[len(overlap):]
+		if len(fresh) == 0 {
+			return ""
+		}
+		return strings.Join(fresh, "\n") + "\n"
+	}
+	return curr
+}
+
+func equalLines(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // ListEnvs retrieves all environment variables for an application
 func (s *ApplicationService) ListEnvs(ctx context.Context, uuid string) ([]models.EnvironmentVariable, error) {
 	var envs []models.EnvironmentVariable
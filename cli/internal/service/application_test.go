@@ -590,6 +590,28 @@ func TestApplicationService_Logs_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to get logs for application")
 }
 
+func TestNewLogLines(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		curr string
+		want string
+	}{
+		{name: "first fetch", prev: "", curr: "a\nb\n", want: "a\nb\n"},
+		{name: "unchanged", prev: "a\nb\n", curr: "a\nb\n", want: ""},
+		{name: "appended", prev: "a\nb\n", curr: "a\nb\nc\n", want: "c\n"},
+		{name: "window slid", prev: "a\nb\nc\n", curr: "b\nc\nd\ne\n", want: "d\ne\n"},
+		{name: "repeated lines", prev: "x\nx\n", curr: "x\nx\nx\n", want: "x\n"},
+		{name: "no overlap", prev: "a\nb\n", curr: "c\nd\n", want: "c\nd\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewLogLines(tt.prev, tt.curr))
+		})
+	}
+}
+
 func TestApplicationService_ListEnvs(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v1/applications/app-uuid-123/envs", r.URL.Path)
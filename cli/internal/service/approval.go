@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// StatusPendingApproval is the deployment status while a deployment waits for approval
+const StatusPendingApproval = "pending_approval"
+
+// ApprovalService handles deployment approval operations
+type ApprovalService struct {
+	client *api.Client
+}
+
+// NewApprovalService creates a new approval service
+func NewApprovalService(client *api.Client) *ApprovalService {
+	return &ApprovalService{client: client}
+}
+
+// ListPending retrieves the pending approvals the current user can decide on
+func (s *ApprovalService) ListPending(ctx context.Context) ([]models.DeploymentApproval, error) {
+	var approvals []models.DeploymentApproval
+	if err := s.client.Get(ctx, "approvals/pending", &approvals); err != nil {
+		return nil, fmt.Errorf("failed to list pending approvals: %w", err)
+	}
+	return approvals, nil
+}
+
+// ListPendingForProject retrieves the pending approvals of a project
+func (s *ApprovalService) ListPendingForProject(ctx context.Context, projectUUID string) ([]models.DeploymentApproval, error) {
+	var approvals []models.DeploymentApproval
+	if err := s.client.Get(ctx, fmt.Sprintf("projects/%s/pending-approvals", projectUUID), &approvals); err != nil {
+		return nil, fmt.Errorf("failed to list pending approvals for project %s: %w", projectUUID, err)
+	}
+	return approvals, nil
+}
+
+// Status retrieves the approval of a deployment
+func (s *ApprovalService) Status(ctx context.Context, deploymentUUID string) (*models.DeploymentApproval, error) {
+	var approval models.DeploymentApproval
+	if err := s.client.Get(ctx, fmt.Sprintf("deployments/%s/approval-status", deploymentUUID), &approval); err != nil {
+		return nil, fmt.Errorf("failed to get approval status for deployment %s: %w", deploymentUUID, err)
+	}
+	return &approval, nil
+}
+
+// Request asks for approval of a deployment
+func (s *ApprovalService) Request(ctx context.Context, deploymentUUID string) (*models.ApprovalResponse, error) {
+	var response models.ApprovalResponse
+	if err := s.client.Post(ctx, fmt.Sprintf("deployments/%s/request-approval", deploymentUUID), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to request approval for deployment %s: %w", deploymentUUID, err)
+	}
+	return &response, nil
+}
+
+// Approve approves a deployment, optionally with a comment
+func (s *ApprovalService) Approve(ctx context.Context, deploymentUUID, comment string) (*models.ApprovalResponse, error) {
+	body := map[string]string{}
+	if comment != "" {
+		body["comment"] = comment
+	}
+	var response models.ApprovalResponse
+	if err := s.client.Post(ctx, fmt.Sprintf("deployments/%s/approve", deploymentUUID), body, &response); err != nil {
+		return nil, fmt.Errorf("failed to approve deployment %s: %w", deploymentUUID, err)
+	}
+	return &response, nil
+}
+
+// Reject rejects a deployment, optionally with a reason. The deployment is cancelled.
+func (s *ApprovalService) Reject(ctx context.Context, deploymentUUID, reason string) (*models.ApprovalResponse, error) {
+	body := map[string]string{}
+	if reason != "" {
+		body["reason"] = reason
+	}
+	var response models.ApprovalResponse
+	if err := s.client.Post(ctx, fmt.Sprintf("deployments/%s/reject", deploymentUUID), body, &response); err != nil {
+		return nil, fmt.Errorf("failed to reject deployment %s: %w", deploymentUUID, err)
+	}
+	return &response, nil
+}
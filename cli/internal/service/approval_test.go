@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+)
+
+func TestApprovalService_ListPending(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/approvals/pending", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"uuid":"appr-1","status":"pending","deployment_uuid":"dep-1","application_name":"api","environment_name":"production","project_name":"shop","requested_by":"dev@example.com","requested_at":"2026-01-02T03:04:05Z"}]`))
+	}))
+	defer server.Close()
+
+	svc := NewApprovalService(api.NewClient(server.URL, "test-token"))
+	approvals, err := svc.ListPending(context.Background())
+	require.NoError(t, err)
+	require.Len(t, approvals, 1)
+	assert.Equal(t, "dep-1", approvals[0].DeploymentUUID)
+	assert.Equal(t, "shop", *approvals[0].ProjectName)
+	assert.Equal(t, "dev@example.com", *approvals[0].RequestedBy)
+}
+
+func TestApprovalService_ListPendingForProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/projects/proj-1/pending-approvals", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	svc := NewApprovalService(api.NewClient(server.URL, "test-token"))
+	approvals, err := svc.ListPendingForProject(context.Background(), "proj-1")
+	require.NoError(t, err)
+	assert.Empty(t, approvals)
+}
+
+func TestApprovalService_Status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/deployments/dep-1/approval-status", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"uuid":"appr-1","status":"rejected","deployment_uuid":"dep-1","approved_by":"owner@example.com","comment":"not on a friday","decided_at":"2026-01-02T04:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	svc := NewApprovalService(api.NewClient(server.URL, "test-token"))
+	approval, err := svc.Status(context.Background(), "dep-1")
+	require.NoError(t, err)
+	assert.Equal(t, "rejected", approval.Status)
+	assert.Equal(t, "not on a friday", *approval.Comment)
+	assert.Equal(t, "owner@example.com", *approval.ApprovedBy)
+}
+
+func TestApprovalService_Decide(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(*ApprovalService) error
+		path     string
+		wantBody map[string]string
+	}{
+		{
+			name: "approve with comment",
+			call: func(s *ApprovalService) error {
+				_, err := s.Approve(context.Background(), "dep-1", "looks good")
+				return err
+			},
+			path:     "/api/v1/deployments/dep-1/approve",
+			wantBody: map[string]string{"comment": "looks good"},
+		},
+		{
+			name: "approve without comment",
+			call: func(s *ApprovalService) error {
+				_, err := s.Approve(context.Background(), "dep-1", "")
+				return err
+			},
+			path:     "/api/v1/deployments/dep-1/approve",
+			wantBody: map[string]string{},
+		},
+		{
+			name: "reject with reason",
+			call: func(s *ApprovalService) error {
+				_, err := s.Reject(context.Background(), "dep-1", "freeze")
+				return err
+			},
+			path:     "/api/v1/deployments/dep-1/reject",
+			wantBody: map[string]string{"reason": "freeze"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, http.MethodPost, r.Method)
+				var body map[string]string
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, tt.wantBody, body)
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"message":"ok","deployment_uuid":"dep-1","status":"approved"}`))
+			}))
+			defer server.Close()
+
+			require.NoError(t, tt.call(NewApprovalService(api.NewClient(server.URL, "test-token"))))
+		})
+	}
+}
+
+func TestApprovalService_Reject_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"You do not have permission to reject this deployment"}`))
+	}))
+	defer server.Close()
+
+	svc := NewApprovalService(api.NewClient(server.URL, "test-token"))
+	_, err := svc.Reject(context.Background(), "dep-1", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to reject deployment dep-1")
+}
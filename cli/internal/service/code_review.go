@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// CodeReviewService handles automated code reviews of deployed commits
+type CodeReviewService struct {
+	client *api.Client
+}
+
+// NewCodeReviewService creates a new code review service
+func NewCodeReviewService(client *api.Client) *CodeReviewService {
+	return &CodeReviewService{client: client}
+}
+
+// Status reports whether code review is enabled on the instance
+func (s *CodeReviewService) Status(ctx context.Context) (*models.CodeReviewStatus, error) {
+	var status models.CodeReviewStatus
+	if err := s.client.Get(ctx, "code-review/status", &status); err != nil {
+		return nil, fmt.Errorf("failed to get code review status: %w", err)
+	}
+	return &status, nil
+}
+
+// Get retrieves the code review of a deployment, or of another deployment of the same
+// commit. A commit without review is reported with status AnalysisStatusNotFound.
+func (s *CodeReviewService) Get(ctx context.Context, deploymentUUID string) (*models.CodeReviewResponse, error) {
+	var response models.CodeReviewResponse
+	if err := s.client.Get(ctx, fmt.Sprintf("deployments/%s/code-review", deploymentUUID), &response); err != nil {
+		if api.IsNotFound(err) {
+			return &models.CodeReviewResponse{Status: AnalysisStatusNotFound}, nil
+		}
+		return nil, fmt.Errorf("failed to get code review of deployment %s: %w", deploymentUUID, err)
+	}
+	return &response, nil
+}
+
+// Trigger queues a code review of the commit a deployment built. When the commit was
+// already reviewed, the existing review is returned instead.
+func (s *CodeReviewService) Trigger(ctx context.Context, deploymentUUID string) (*models.CodeReviewResponse, error) {
+	var response models.CodeReviewResponse
+	if err := s.client.Post(ctx, fmt.Sprintf("deployments/%s/code-review", deploymentUUID), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to start code review of deployment %s: %w", deploymentUUID, err)
+	}
+	return &response, nil
+}
+
+// Violations retrieves the violations found by the code review of a deployment
+func (s *CodeReviewService) Violations(ctx context.Context, deploymentUUID string) (*models.CodeReviewViolations, error) {
+	var response models.CodeReviewViolations
+	if err := s.client.Get(ctx, fmt.Sprintf("deployments/%s/code-review/violations", deploymentUUID), &response); err != nil {
+		return nil, fmt.Errorf("failed to get code review violations of deployment %s: %w", deploymentUUID, err)
+	}
+	return &response, nil
+}
+
+// WaitForReview polls until the code review of a deployment is completed or failed.
+// previous is the review that existed before a new one was requested; it is skipped
+// until the server has replaced it.
+func (s *CodeReviewService) WaitForReview(ctx context.Context, deploymentUUID string, pollInterval time.Duration, previous *models.CodeReview) (*models.CodeReview, error) {
+	if pollInterval <= 0 {
+		pollInterval = 3 * time.Second
+	}
+
+	for {
+		response, err := s.Get(ctx, deploymentUUID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+
+		if r := response.Review; r != nil && analysisDone(r.Status) {
+			if previous == nil || r.ID != previous.ID || !sameTime(r.FinishedAt, previous.FinishedAt) {
+				return r, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func sameTime(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
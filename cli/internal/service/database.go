@@ -113,6 +113,31 @@ func (s *DatabaseService) Restart(ctx context.Context, uuid string) (*models.Dat
 	return &response, nil
 }
 
+// Status returns the status of a database for WaitForResource. Databases always
+// run with a health check.
+func (s *DatabaseService) Status(ctx context.Context, uuid string) (ResourceStatus, error) {
+	db, err := s.Get(ctx, uuid)
+	if err != nil {
+		return ResourceStatus{}, err
+	}
+	return ResourceStatus{Status: db.Status, HealthChecked: true}, nil
+}
+
+// Logs retrieves container logs for a running database
+func (s *DatabaseService) Logs(ctx context.Context, uuid string, lines int) (*models.DatabaseLogsResponse, error) {
+	endpoint := fmt.Sprintf("databases/%s/logs", uuid)
+	if lines > 0 {
+		endpoint = fmt.Sprintf("%s?lines=%d", endpoint, lines)
+	}
+
+	var resp models.DatabaseLogsResponse
+	err := s.client.Get(ctx, endpoint, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs for database %s: %w", uuid, err)
+	}
+	return &resp, nil
+}
+
 // ListBackups retrieves all backup configurations for a database
 func (s *DatabaseService) ListBackups(ctx context.Context, uuid string) ([]models.DatabaseBackup, error) {
 	var backups []models.DatabaseBackup
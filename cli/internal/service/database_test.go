@@ -855,3 +855,21 @@ func stringPtr(s string) *string {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func TestDatabaseService_Logs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/databases/db-uuid-1/logs", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "200", r.URL.Query().Get("lines"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"database_uuid": "db-uuid-1", "container_name": "db-uuid-1", "status": "running", "logs": "ready to accept connections\n"}`))
+	}))
+	defer server.Close()
+
+	svc := NewDatabaseService(api.NewClient(server.URL, "test-token"))
+	result, err := svc.Logs(context.Background(), "db-uuid-1", 200)
+	require.NoError(t, err)
+	assert.Equal(t, "running", result.Status)
+	assert.Equal(t, "ready to accept connections\n", result.Logs)
+}
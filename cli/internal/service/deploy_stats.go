@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// maxRollbackEvents is the most rollback events the API returns in one request
+const maxRollbackEvents = 500
+
+// DeploymentHistory holds the deployments and rollback events of one application,
+// which deployment statistics are computed from
+type DeploymentHistory struct {
+	Deployments []models.Deployment
+	Rollbacks   []models.RollbackEvent
+}
+
+// History returns the deployments and rollback events of an application since the given time
+func (s *DeploymentService) History(ctx context.Context, appUUID string, since time.Time) (*DeploymentHistory, error) {
+	deployments, err := s.ListByApplicationSince(ctx, appUUID, since)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.GetRollbackEvents(ctx, appUUID, maxRollbackEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &DeploymentHistory{Deployments: deployments}
+	for _, e := range events {
+		if triggered, ok := e.TriggeredTime(); ok && triggered.Before(since) {
+			continue
+		}
+		history.Rollbacks = append(history.Rollbacks, e)
+	}
+	return history, nil
+}
+
+// ComputeDeploymentStats computes deployment statistics over the period from since to until:
+//   - deployment frequency counts releases: successful deployments other than rollbacks
+//   - success and failure rates leave out cancelled and running deployments
+//   - build durations run from queueing to the end of successful deployments
+//   - the change-failure rate is the share of releases that had to be rolled back; rollbacks
+//     of deployments outside the period, or of unknown deployments, are not counted
+//   - the time to restore runs from when a rolled back deployment went live, or from the
+//     rollback being triggered when that deployment is unknown, until the rollback completed
+func ComputeDeploymentStats(histories []DeploymentHistory, since, until time.Time) models.DeploymentStats {
+	stats := models.DeploymentStats{
+		Since:        since,
+		Until:        until,
+		Applications: len(histories),
+		Weeks:        emptyWeeks(since, until),
+	}
+
+	var builds []time.Duration
+	var restores []time.Duration
+	released := make(map[int]bool)
+	rolledBack := make(map[int]bool)
+	for _, h := range histories {
+		finishedByID := make(map[int]time.Time)
+		for _, d := range h.Deployments {
+			stats.Deployments++
+			week := weekFor(stats.Weeks, d)
+			switch d.Status {
+			case "finished":
+				stats.Succeeded++
+				if week != nil {
+					week.Succeeded++
+				}
+				if d.Rollback == nil || !*d.Rollback {
+					released[d.ID] = true
+				}
+				if duration, ok := d.BuildDuration(); ok {
+					builds = append(builds, duration)
+				}
+				if finished, ok := d.FinishedTime(); ok {
+					finishedByID[d.ID] = finished
+				}
+			case "failed", "timed-out":
+				stats.Failed++
+				if week != nil {
+					week.Failed++
+				}
+			case "cancelled", "cancelled-by-user":
+				stats.Cancelled++
+				if week != nil {
+					week.Other++
+				}
+			default:
+				stats.InProgress++
+				if week != nil {
+					week.Other++
+				}
+			}
+		}
+
+		for _, e := range h.Rollbacks {
+			if e.Status == models.RollbackStatusSkipped {
+				continue
+			}
+			stats.Rollbacks++
+			if e.FailedDeploymentID != nil {
+				rolledBack[*e.FailedDeploymentID] = true
+			}
+
+			if e.Status != models.RollbackStatusSuccess {
+				continue
+			}
+			completed, ok := e.CompletedTime()
+			if !ok {
+				continue
+			}
+			start, ok := e.TriggeredTime()
+			if e.FailedDeploymentID != nil {
+				if live, found := finishedByID[*e.FailedDeploymentID]; found {
+					start, ok = live, true
+				}
+			}
+			if ok && !completed.Before(start) {
+				restores = append(restores, completed.Sub(start))
+			}
+		}
+	}
+
+	days := until.Sub(since).Hours() / 24
+	if days > 0 {
+		stats.DeploysPerDay = float64(len(released)) / days
+		stats.DeploysPerWeek = stats.DeploysPerDay * 7
+	}
+	stats.SuccessRate = percent(stats.Succeeded, stats.Succeeded+stats.Failed)
+	stats.FailureRate = percent(stats.Failed, stats.Succeeded+stats.Failed)
+
+	if len(builds) > 0 {
+		sort.Slice(builds, func(i, j int) bool { return builds[i] < builds[j] })
+		stats.BuildP50Seconds = seconds(percentile(builds, 50))
+		stats.BuildP95Seconds = seconds(percentile(builds, 95))
+	}
+
+	failedChanges := 0
+	for id := range rolledBack {
+		if released[id] {
+			failedChanges++
+		}
+	}
+	stats.ChangeFailureRate = percent(failedChanges, len(released))
+
+	stats.Restores = len(restores)
+	if len(restores) > 0 {
+		var total time.Duration
+		for _, r := range restores {
+			total += r
+		}
+		stats.MTTRSeconds = seconds(total / time.Duration(len(restores)))
+	}
+	return stats
+}
+
+// emptyWeeks returns one bucket per week, starting on Mondays, covering since to until
+func emptyWeeks(since, until time.Time) []models.WeeklyDeployments {
+	var weeks []models.WeeklyDeployments
+	for start := weekStart(since); !start.After(until); start = start.AddDate(0, 0, 7) {
+		weeks = append(weeks, models.WeeklyDeployments{Start: start})
+	}
+	return weeks
+}
+
+// weekStart returns midnight of the Monday starting the week t falls in
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// weekFor returns the bucket of the week a deployment was queued in, or nil when it is unknown
+func weekFor(weeks []models.WeeklyDeployments, d models.Deployment) *models.WeeklyDeployments {
+	created, ok := d.CreatedTime()
+	if !ok || len(weeks) == 0 {
+		return nil
+	}
+	start := weekStart(created.In(weeks[0].Start.Location()))
+	for i := range weeks {
+		if weeks[i].Start.Equal(start) {
+			return &weeks[i]
+		}
+	}
+	return nil
+}
+
+// percentile returns the nearest-rank percentile p of sorted, which must not be empty
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+func percent(n, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	v := float64(n) / float64(total) * 100
+	return &v
+}
+
+func seconds(d time.Duration) *float64 {
+	v := d.Seconds()
+	return &v
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+func statsDeployment(id int, status, created string, buildMinutes int) models.Deployment {
+	d := models.Deployment{ID: id, Status: status, CreatedAt: &created}
+	if buildMinutes > 0 {
+		start, _ := time.Parse(time.RFC3339, created)
+		finished := start.Add(time.Duration(buildMinutes) * time.Minute).Format(time.RFC3339)
+		d.FinishedAt = &finished
+	}
+	return d
+}
+
+func TestComputeDeploymentStats(t *testing.T) {
+	// Monday 2026-03-02 to Sunday 2026-03-15: two full weeks
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	rollback := true
+	rollbackDeployment := statsDeployment(7, "finished", "2026-03-10T12:00:00Z", 2)
+	rollbackDeployment.Rollback = &rollback
+	failedID := 6
+	triggered := "2026-03-10T11:30:00Z"
+	completed := "2026-03-10T12:02:00Z"
+
+	histories := []DeploymentHistory{
+		{
+			Deployments: []models.Deployment{
+				statsDeployment(1, "finished", "2026-03-02T10:00:00Z", 4),
+				statsDeployment(2, "failed", "2026-03-03T10:00:00Z", 0),
+				statsDeployment(3, "finished", "2026-03-04T10:00:00Z", 6),
+				statsDeployment(4, "cancelled-by-user", "2026-03-05T10:00:00Z", 0),
+				statsDeployment(6, "finished", "2026-03-10T10:00:00Z", 10),
+				rollbackDeployment,
+				statsDeployment(8, "in_progress", "2026-03-15T10:00:00Z", 0),
+			},
+			Rollbacks: []models.RollbackEvent{
+				{ID: 1, Status: models.RollbackStatusSuccess, FailedDeploymentID: &failedID, TriggeredAt: &triggered, CompletedAt: &completed},
+				{ID: 2, Status: models.RollbackStatusSkipped},
+			},
+		},
+		{
+			Deployments: []models.Deployment{
+				statsDeployment(11, "finished", "2026-03-11T10:00:00Z", 8),
+				statsDeployment(12, "timed-out", "2026-03-12T10:00:00Z", 0),
+			},
+		},
+	}
+
+	stats := ComputeDeploymentStats(histories, since, until)
+
+	assert.Equal(t, 2, stats.Applications)
+	assert.Equal(t, 9, stats.Deployments)
+	assert.Equal(t, 5, stats.Succeeded)
+	assert.Equal(t, 2, stats.Failed)
+	assert.Equal(t, 1, stats.Cancelled)
+	assert.Equal(t, 1, stats.InProgress)
+
+	// The rollback deployment is not a release
+	assert.InDelta(t, 4.0/14, stats.DeploysPerDay, 0.001)
+	assert.InDelta(t, 2.0, stats.DeploysPerWeek, 0.001)
+	require.NotNil(t, stats.SuccessRate)
+	assert.InDelta(t, 5.0/7*100, *stats.SuccessRate, 0.001)
+	require.NotNil(t, stats.FailureRate)
+	assert.InDelta(t, 2.0/7*100, *stats.FailureRate, 0.001)
+
+	// Build durations 2, 4, 6, 8, 10 minutes
+	require.NotNil(t, stats.BuildP50Seconds)
+	assert.Equal(t, 360.0, *stats.BuildP50Seconds)
+	require.NotNil(t, stats.BuildP95Seconds)
+	assert.Equal(t, 600.0, *stats.BuildP95Seconds)
+
+	// One of four releases (the rollback deployment is not one) was rolled back
+	assert.Equal(t, 1, stats.Rollbacks)
+	require.NotNil(t, stats.ChangeFailureRate)
+	assert.Equal(t, 25.0, *stats.ChangeFailureRate)
+
+	// Deployment 6 went live at 10:10, the rollback completed at 12:02
+	assert.Equal(t, 1, stats.Restores)
+	require.NotNil(t, stats.MTTRSeconds)
+	assert.Equal(t, (112 * time.Minute).Seconds(), *stats.MTTRSeconds)
+
+	require.Len(t, stats.Weeks, 3)
+	assert.Equal(t, since, stats.Weeks[0].Start)
+	assert.Equal(t, models.WeeklyDeployments{Start: since, Succeeded: 2, Failed: 1, Other: 1}, stats.Weeks[0])
+	assert.Equal(t, models.WeeklyDeployments{Start: since.AddDate(0, 0, 7), Succeeded: 3, Failed: 1, Other: 1}, stats.Weeks[1])
+	assert.Equal(t, models.WeeklyDeployments{Start: until}, stats.Weeks[2])
+}
+
+func TestComputeDeploymentStats_ChangeFailureRateCountsPeriodReleasesOnly(t *testing.T) {
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	rollback := true
+	rollbackDeployment := statsDeployment(5, "finished", "2026-03-04T10:00:00Z", 1)
+	rollbackDeployment.Rollback = &rollback
+	// Deployment 1 went live before the period, deployment 2 failed to build
+	olderID, failedID, releaseID := 1, 2, 3
+
+	stats := ComputeDeploymentStats([]DeploymentHistory{{
+		Deployments: []models.Deployment{
+			statsDeployment(2, "failed", "2026-03-03T10:00:00Z", 0),
+			statsDeployment(3, "finished", "2026-03-03T12:00:00Z", 2),
+			statsDeployment(4, "finished", "2026-03-04T09:00:00Z", 2),
+			rollbackDeployment,
+		},
+		Rollbacks: []models.RollbackEvent{
+			{ID: 1, Status: models.RollbackStatusSuccess, FailedDeploymentID: &olderID},
+			{ID: 2, Status: models.RollbackStatusSuccess, FailedDeploymentID: &failedID},
+			{ID: 3, Status: models.RollbackStatusSuccess, FailedDeploymentID: &releaseID},
+			{ID: 4, Status: models.RollbackStatusSuccess},
+		},
+	}}, since, until)
+
+	assert.Equal(t, 4, stats.Rollbacks)
+	// Only deployment 3 is a release of the period that was rolled back
+	require.NotNil(t, stats.ChangeFailureRate)
+	assert.Equal(t, 50.0, *stats.ChangeFailureRate)
+	assert.InDelta(t, 2.0/14, stats.DeploysPerDay, 0.001)
+}
+
+func TestComputeDeploymentStats_NoDeployments(t *testing.T) {
+	since := time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC)
+	stats := ComputeDeploymentStats(nil, since, since.AddDate(0, 0, 3))
+
+	assert.Zero(t, stats.Deployments)
+	assert.Nil(t, stats.SuccessRate)
+	assert.Nil(t, stats.BuildP50Seconds)
+	assert.Nil(t, stats.ChangeFailureRate)
+	assert.Nil(t, stats.MTTRSeconds)
+	require.Len(t, stats.Weeks, 1)
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), stats.Weeks[0].Start)
+}
+
+func TestDeploymentService_History(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/deployments/applications/app-1":
+			_, _ = w.Write([]byte(`{"deployments": [
+				{"deployment_uuid": "dep-2", "status": "finished", "created_at": "2026-03-10T00:00:00Z"},
+				{"deployment_uuid": "dep-1", "status": "finished", "created_at": "2026-02-01T00:00:00Z"}
+			]}`))
+		case "/api/v1/applications/app-1/rollback-events":
+			assert.Equal(t, "500", r.URL.Query().Get("take"))
+			_, _ = w.Write([]byte(`[
+				{"id": 2, "status": "success", "triggered_at": "2026-03-11T00:00:00Z"},
+				{"id": 1, "status": "success", "triggered_at": "2026-02-02T00:00:00Z"}
+			]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc := NewDeploymentService(api.NewClient(server.URL, "test-token"))
+	history, err := svc.History(context.Background(), "app-1", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, history.Deployments, 1)
+	assert.Equal(t, "dep-2", history.Deployments[0].UUID)
+	require.Len(t, history.Rollbacks, 1)
+	assert.Equal(t, 2, history.Rollbacks[0].ID)
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/saturn-platform/saturn-cli/internal/api"
@@ -17,6 +19,8 @@ var terminalStatuses = map[string]bool{
 	"failed":            true,
 	"cancelled-by-user": true,
 	"timed-out":         true,
+	// Rejected approvals cancel the deployment with this status
+	"cancelled": true,
 }
 
 // IsTerminalStatus checks if a deployment status is terminal (done)
@@ -111,6 +115,83 @@ func (s *DeploymentService) Cancel(ctx context.Context, uuid string) (*CancelRes
 	return &response, nil
 }
 
+// maxConcurrentCancels bounds how many cancel requests CancelMany sends at once
+const maxConcurrentCancels = 8
+
+// CancelResult is the outcome of cancelling one of several deployments
+type CancelResult struct {
+	DeploymentUUID string `json:"deployment_uuid"`
+	Status         string `json:"status,omitempty"`
+	Message        string `json:"message,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// CancelMany cancels deployments concurrently. Results are in the same order as uuids;
+// a failed cancellation is reported in its result rather than as an error.
+func (s *DeploymentService) CancelMany(ctx context.Context, uuids []string) []CancelResult {
+	results := make([]CancelResult, len(uuids))
+	sem := make(chan struct{}, maxConcurrentCancels)
+	var wg sync.WaitGroup
+	for i, uuid := range uuids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result := CancelResult{DeploymentUUID: uuid}
+			if resp, err := s.Cancel(ctx, uuid); err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Status = resp.Status
+				result.Message = resp.Message
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// InProgress returns the team's queued and running deployments
+func (s *DeploymentService) InProgress(ctx context.Context) ([]models.Deployment, error) {
+	deployments, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return FilterInProgress(deployments), nil
+}
+
+// inProgressHistorySize is how many recent deployments of an application are searched for unfinished ones
+const inProgressHistorySize = 20
+
+// InProgressByApplication returns the queued and running deployments of an application
+func (s *DeploymentService) InProgressByApplication(ctx context.Context, appUUID string) ([]models.Deployment, error) {
+	deployments, err := s.ListByApplicationWithPagination(ctx, appUUID, 0, inProgressHistorySize)
+	if err != nil {
+		return nil, err
+	}
+	return FilterInProgress(deployments), nil
+}
+
+// Deployment statuses of a deployment that has been queued or is running
+const (
+	StatusQueued     = "queued"
+	StatusInProgress = "in_progress"
+)
+
+// FilterInProgress returns the queued and running deployments. Those pending approval are
+// left out: they are settled with an approval decision, not cancelled.
+func FilterInProgress(deployments []models.Deployment) []models.Deployment {
+	var inProgress []models.Deployment
+	for _, d := range deployments {
+		if d.Status == StatusQueued || d.Status == StatusInProgress {
+			inProgress = append(inProgress, d)
+		}
+	}
+	return inProgress
+}
+
 // DeploymentsListResponse represents the response from listing deployments
 type DeploymentsListResponse struct {
 	Count       int                 `json:"count"`
@@ -148,6 +229,31 @@ func (s *DeploymentService) ListByApplicationWithPagination(ctx context.Context,
 	return response.Deployments, nil
 }
 
+// deploymentHistoryPageSize is the page size used to walk the deployment history of an application
+const deploymentHistoryPageSize = 100
+
+// ListByApplicationSince retrieves every deployment of an application queued at or after since,
+// most recent first. A zero since returns the whole history.
+func (s *DeploymentService) ListByApplicationSince(ctx context.Context, appUUID string, since time.Time) ([]models.Deployment, error) {
+	var deployments []models.Deployment
+	for skip := 0; ; skip += deploymentHistoryPageSize {
+		page, err := s.ListByApplicationWithPagination(ctx, appUUID, skip, deploymentHistoryPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range page {
+			// The API lists deployments newest first, so the first older one ends the walk
+			if created, ok := d.CreatedTime(); ok && !since.IsZero() && created.Before(since) {
+				return deployments, nil
+			}
+			deployments = append(deployments, d)
+		}
+		if len(page) < deploymentHistoryPageSize {
+			return deployments, nil
+		}
+	}
+}
+
 // GetLogsByApplication retrieves deployment logs for a specific application
 // This gets the latest deployment and returns its logs
 func (s *DeploymentService) GetLogsByApplication(ctx context.Context, appUUID string, lines int) (string, error) {
@@ -181,6 +287,17 @@ func (s *DeploymentService) GetLogsByApplicationWithFormat(ctx context.Context,
 	}
 
 	// Sort deployments by UpdatedAt in descending order to get the most recent first
+	SortByMostRecent(deployments)
+
+	// Get the latest deployment (first after sorting)
+	latestDeployment := deployments[0]
+
+	// Get logs for this deployment only
+	return s.GetLogsByDeploymentWithFormat(ctx, latestDeployment.UUID, showHidden, format)
+}
+
+// SortByMostRecent sorts deployments so that the most recent one comes first
+func SortByMostRecent(deployments []models.Deployment) {
 	sort.Slice(deployments, func(i, j int) bool {
 		// If UpdatedAt is available, use it
 		if deployments[i].UpdatedAt != nil && deployments[j].UpdatedAt != nil {
@@ -193,12 +310,96 @@ func (s *DeploymentService) GetLogsByApplicationWithFormat(ctx context.Context,
 		// Fall back to ID if timestamps are not available (higher ID = more recent)
 		return deployments[i].ID > deployments[j].ID
 	})
+}
 
-	// Get the latest deployment (first after sorting)
-	latestDeployment := deployments[0]
+// LatestFinished returns the most recent successful deployment of an application, i.e. the
+// one currently running, or nil when the application has none. The history is paged through
+// until one is found, so a run of failed deployments does not hide it.
+func (s *DeploymentService) LatestFinished(ctx context.Context, appUUID string) (*models.Deployment, error) {
+	for skip := 0; ; skip += deploymentHistoryPageSize {
+		page, err := s.ListByApplicationWithPagination(ctx, appUUID, skip, deploymentHistoryPageSize)
+		if err != nil {
+			return nil, err
+		}
+		SortByMostRecent(page)
+		for i := range page {
+			if page[i].Status == "finished" {
+				return &page[i], nil
+			}
+		}
+		if len(page) < deploymentHistoryPageSize {
+			return nil, nil
+		}
+	}
+}
 
-	// Get logs for this deployment only
-	return s.GetLogsByDeploymentWithFormat(ctx, latestDeployment.UUID, showHidden, format)
+// rollbackHistorySize is how many recent deployments are searched for rollback targets
+const rollbackHistorySize = 50
+
+// RollbackCandidates returns the successful deployments an application can be rolled back to,
+// most recent first, leaving out the one currently running
+func (s *DeploymentService) RollbackCandidates(ctx context.Context, appUUID string) ([]models.Deployment, error) {
+	deployments, err := s.ListByApplicationWithPagination(ctx, appUUID, 0, rollbackHistorySize)
+	if err != nil {
+		return nil, err
+	}
+	SortByMostRecent(deployments)
+
+	var candidates []models.Deployment
+	seenCurrent := false
+	for _, d := range deployments {
+		if d.Status != "finished" {
+			continue
+		}
+		if !seenCurrent {
+			seenCurrent = true
+			continue
+		}
+		candidates = append(candidates, d)
+	}
+	return candidates, nil
+}
+
+// FindRollbackTarget returns the last successful deployment before the one currently running,
+// i.e. the deployment a rollback to the previous version should use
+func (s *DeploymentService) FindRollbackTarget(ctx context.Context, appUUID string) (*models.Deployment, error) {
+	candidates, err := s.RollbackCandidates(ctx, appUUID)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no previous successful deployment found for application %s", appUUID)
+	}
+	return &candidates[0], nil
+}
+
+// FindRollbackTargetByCommit returns the most recent previous successful deployment whose commit
+// starts with prefix. A prefix matching several different commits is an error.
+func (s *DeploymentService) FindRollbackTargetByCommit(ctx context.Context, appUUID, prefix string) (*models.Deployment, error) {
+	candidates, err := s.RollbackCandidates(ctx, appUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix = strings.ToLower(prefix)
+	var match *models.Deployment
+	for i := range candidates {
+		commit := candidates[i].Commit
+		if commit == nil || !strings.HasPrefix(strings.ToLower(*commit), prefix) {
+			continue
+		}
+		if match == nil {
+			match = &candidates[i]
+			continue
+		}
+		if *match.Commit != *commit {
+			return nil, fmt.Errorf("commit %s is ambiguous: it matches %s and %s", prefix, *match.Commit, *commit)
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no previous successful deployment of commit %s found for application %s", prefix, appUUID)
+	}
+	return match, nil
 }
 
 // GetRollbackEvents retrieves rollback events for an application
@@ -283,11 +484,17 @@ func (s *DeploymentService) WaitForCompletion(ctx context.Context, deploymentUUI
 		pollInterval = 3 * time.Second
 	}
 
+	lastStatus := ""
 	for {
 		deployment, err := s.Get(ctx, deploymentUUID)
 		if err != nil {
+			// A deadline hit mid-request is a timeout, not a polling failure
+			if ctx.Err() != nil {
+				return &WaitResult{DeploymentUUID: deploymentUUID, Status: lastStatus}, ctx.Err()
+			}
 			return nil, fmt.Errorf("failed to poll deployment %s: %w", deploymentUUID, err)
 		}
+		lastStatus = deployment.Status
 
 		if onStatus != nil {
 			onStatus(deploymentUUID, deployment.Status)
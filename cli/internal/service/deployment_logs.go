@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// deploymentLogPageSize is the largest page the deployment logs endpoint serves
+const deploymentLogPageSize = 500
+
+// DeploymentLogRequestsPerMinute is the request budget of deployment log followers that
+// share a RequestLimiter, below the 60 requests a minute the logs endpoint allows
+const DeploymentLogRequestsPerMinute = 50
+
+// rateLimitBackoff is how long log followers pause after the server answered 429
+const rateLimitBackoff = 10 * time.Second
+
+// RequestLimiter spaces out the requests of several pollers, so that together they stay
+// within a rate limit. A nil RequestLimiter does not limit.
+type RequestLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRequestLimiter returns a limiter allowing perMinute requests a minute
+func NewRequestLimiter(perMinute int) *RequestLimiter {
+	return &RequestLimiter{interval: time.Minute / time.Duration(perMinute)}
+}
+
+// Wait blocks until the next request may be made, or ctx ends
+func (l *RequestLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(at)):
+		return nil
+	}
+}
+
+// Backoff holds every request back for d, after the server refused one as too many
+func (l *RequestLimiter) Backoff(d time.Duration) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); l.next.Before(until) {
+		l.next = until
+	}
+}
+
+// LogFollowOptions controls FollowLogs
+type LogFollowOptions struct {
+	// PollInterval is the delay between fetches (default 2s)
+	PollInterval time.Duration
+	// ShowHidden includes hidden entries. They are only available in the full log
+	// stored on the deployment, which is re-read on every poll.
+	ShowHidden bool
+	// OnStatus is called with the deployment status after every fetch
+	OnStatus StatusCallback
+	// Limiter is shared by followers polling at the same time, to stay within the rate
+	// limit of the logs endpoint
+	Limiter *RequestLimiter
+}
+
+// LogCursor is the position of the last log entry seen, ordered by (batch, order)
+type LogCursor struct {
+	Batch int
+	Order int
+	valid bool
+}
+
+// Advance moves the cursor to entry if it comes after the cursor and reports whether it did
+func (c *LogCursor) Advance(entry models.LogEntry) bool {
+	if c.valid && (entry.Batch < c.Batch || (entry.Batch == c.Batch && entry.Order <= c.Order)) {
+		return false
+	}
+	c.Batch, c.Order, c.valid = entry.Batch, entry.Order, true
+	return true
+}
+
+// GetDeploymentLogPage retrieves a page of log entries from the deployment logs endpoint
+func (s *DeploymentService) GetDeploymentLogPage(ctx context.Context, deploymentUUID string, offset, limit int) (*models.DeploymentLogPage, error) {
+	var page models.DeploymentLogPage
+	endpoint := fmt.Sprintf("deployments/%s/logs?offset=%d&limit=%d", deploymentUUID, offset, limit)
+	if err := s.client.Get(ctx, endpoint, &page); err != nil {
+		return nil, fmt.Errorf("failed to get logs for deployment %s: %w", deploymentUUID, err)
+	}
+	return &page, nil
+}
+
+// FollowLogs polls the logs of a deployment and calls onEntry once for every entry, in
+// (batch, order) sequence, until the deployment reaches a terminal status. It returns
+// that status, or the last known status together with ctx.Err() when ctx ends first.
+// Rate limited requests are retried after a pause rather than ending the follow.
+func (s *DeploymentService) FollowLogs(ctx context.Context, deploymentUUID string, opts LogFollowOptions, onEntry func(models.LogEntry)) (string, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 2 * time.Second
+	}
+
+	var cursor LogCursor
+	offset := 0
+	status := ""
+	for {
+		var entries []models.LogEntry
+		var err error
+		var fetched string
+		if opts.ShowHidden {
+			entries, fetched, err = s.fullLogEntries(ctx, deploymentUUID, opts.Limiter)
+		} else {
+			entries, fetched, err = s.newLogEntries(ctx, deploymentUUID, &offset, opts.Limiter)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return status, ctx.Err()
+			}
+			if !api.IsRateLimited(err) {
+				return status, err
+			}
+			opts.Limiter.Backoff(rateLimitBackoff)
+		} else {
+			status = fetched
+		}
+
+		for _, entry := range entries {
+			if cursor.Advance(entry) {
+				onEntry(entry)
+			}
+		}
+		if opts.OnStatus != nil && err == nil {
+			opts.OnStatus(deploymentUUID, status)
+		}
+
+		// Logs are read after the status, so a terminal status means nothing is left to print
+		if err == nil && IsTerminalStatus(status) {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-time.After(opts.PollInterval):
+		}
+	}
+}
+
+// newLogEntries pages through the logs endpoint from *offset, advancing it past the entries read
+func (s *DeploymentService) newLogEntries(ctx context.Context, deploymentUUID string, offset *int, limiter *RequestLimiter) ([]models.LogEntry, string, error) {
+	var entries []models.LogEntry
+	for {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, "", err
+		}
+		page, err := s.GetDeploymentLogPage(ctx, deploymentUUID, *offset, deploymentLogPageSize)
+		if err != nil {
+			// The entries read so far are returned too, since *offset already moved past them
+			return entries, "", err
+		}
+		if page.Message != "" && page.Total == 0 && len(page.Logs) == 0 {
+			// Tokens without the read:sensitive ability get an explanation instead of logs
+			return nil, page.Status, fmt.Errorf("failed to get logs for deployment %s: %s", deploymentUUID, page.Message)
+		}
+		entries = append(entries, page.Logs...)
+		*offset += len(page.Logs)
+		if !page.HasMore || len(page.Logs) == 0 {
+			return entries, page.Status, nil
+		}
+	}
+}
+
+// fullLogEntries reads every entry, hidden ones included, from the deployment record
+func (s *DeploymentService) fullLogEntries(ctx context.Context, deploymentUUID string, limiter *RequestLimiter) ([]models.LogEntry, string, error) {
+	if err := limiter.Wait(ctx); err != nil {
+		return nil, "", err
+	}
+	deployment, err := s.Get(ctx, deploymentUUID)
+	if err != nil {
+		return nil, "", err
+	}
+	if deployment.Logs == nil || *deployment.Logs == "" {
+		return nil, deployment.Status, nil
+	}
+
+	var entries []models.LogEntry
+	if err := json.Unmarshal([]byte(*deployment.Logs), &entries); err != nil {
+		return nil, deployment.Status, fmt.Errorf("failed to parse logs for deployment %s: %w", deploymentUUID, err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Batch != entries[j].Batch {
+			return entries[i].Batch < entries[j].Batch
+		}
+		return entries[i].Order < entries[j].Order
+	})
+	return entries, deployment.Status, nil
+}
+
+// LogEntries retrieves every log entry of a deployment once, along with its status
+func (s *DeploymentService) LogEntries(ctx context.Context, deploymentUUID string, showHidden bool) ([]models.LogEntry, string, error) {
+	if showHidden {
+		return s.fullLogEntries(ctx, deploymentUUID, nil)
+	}
+	offset := 0
+	return s.newLogEntries(ctx, deploymentUUID, &offset, nil)
+}
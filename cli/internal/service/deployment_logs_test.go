@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+func TestLogCursor_Advance(t *testing.T) {
+	var cursor LogCursor
+	assert.True(t, cursor.Advance(models.LogEntry{Batch: 1, Order: 1}))
+	assert.True(t, cursor.Advance(models.LogEntry{Batch: 1, Order: 2}))
+	assert.False(t, cursor.Advance(models.LogEntry{Batch: 1, Order: 2}))
+	assert.False(t, cursor.Advance(models.LogEntry{Batch: 1, Order: 1}))
+	assert.True(t, cursor.Advance(models.LogEntry{Batch: 2, Order: 1}))
+	assert.False(t, cursor.Advance(models.LogEntry{Batch: 1, Order: 9}))
+}
+
+func TestDeploymentService_FollowLogs(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/deployments/dep-1/logs", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		var page models.DeploymentLogPage
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			assert.Equal(t, "0", r.URL.Query().Get("offset"))
+			page = models.DeploymentLogPage{Status: "in_progress", Total: 2, Logs: []models.LogEntry{
+				{Order: 1, Output: "Cloning repository"},
+				{Order: 2, Output: "Building image"},
+			}}
+		default:
+			assert.Equal(t, "2", r.URL.Query().Get("offset"))
+			page = models.DeploymentLogPage{Status: "finished", Total: 3, Logs: []models.LogEntry{
+				{Order: 3, Output: "Deployment finished"},
+			}}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	svc := NewDeploymentService(api.NewClient(server.URL, "test-token"))
+
+	var lines []string
+	var statuses []string
+	status, err := svc.FollowLogs(context.Background(), "dep-1", LogFollowOptions{
+		PollInterval: 10 * time.Millisecond,
+		OnStatus:     func(_, status string) { statuses = append(statuses, status) },
+	}, func(entry models.LogEntry) {
+		lines = append(lines, entry.Output)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "finished", status)
+	assert.Equal(t, []string{"Cloning repository", "Building image", "Deployment finished"}, lines)
+	assert.Equal(t, []string{"in_progress", "finished"}, statuses)
+}
+
+func TestDeploymentService_FollowLogs_ShowHidden(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/deployments/dep-1", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		logs := `[{"output":"docker build","hidden":true,"batch":1,"order":2},{"output":"start","batch":1,"order":1}]`
+		status := "in_progress"
+		if atomic.AddInt32(&calls, 1) > 1 {
+			logs = `[{"output":"start","batch":1,"order":1},{"output":"docker build","hidden":true,"batch":1,"order":2},{"output":"done","batch":2,"order":1}]`
+			status = "failed"
+		}
+		_ = json.NewEncoder(w).Encode(models.Deployment{UUID: "dep-1", Status: status, Logs: &logs})
+	}))
+	defer server.Close()
+
+	svc := NewDeploymentService(api.NewClient(server.URL, "test-token"))
+
+	var lines []string
+	status, err := svc.FollowLogs(context.Background(), "dep-1", LogFollowOptions{PollInterval: 10 * time.Millisecond, ShowHidden: true},
+		func(entry models.LogEntry) { lines = append(lines, entry.Output) })
+
+	require.NoError(t, err)
+	assert.Equal(t, "failed", status)
+	assert.Equal(t, []string{"start", "docker build", "done"}, lines)
+}
+
+func TestDeploymentService_FollowLogs_RequiresSensitiveAbility(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"deployment_uuid":"dep-1","status":"in_progress","logs":[],"total":0,"message":"Logs require read:sensitive token ability."}`))
+	}))
+	defer server.Close()
+
+	svc := NewDeploymentService(api.NewClient(server.URL, "test-token"))
+
+	_, err := svc.FollowLogs(context.Background(), "dep-1", LogFollowOptions{}, func(models.LogEntry) {})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read:sensitive")
+}
+
+func TestDeploymentService_FollowLogs_RateLimited(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var page models.DeploymentLogPage
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			page = models.DeploymentLogPage{Status: "in_progress", Total: 1, Logs: []models.LogEntry{{Order: 1, Output: "Cloning repository"}}}
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"Too Many Attempts."}`))
+			return
+		default:
+			assert.Equal(t, "1", r.URL.Query().Get("offset"))
+			page = models.DeploymentLogPage{Status: "finished", Total: 2, Logs: []models.LogEntry{{Order: 2, Output: "Deployment finished"}}}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	svc := NewDeploymentService(api.NewClient(server.URL, "test-token", api.WithRetries(0)))
+
+	var lines []string
+	var statuses []string
+	status, err := svc.FollowLogs(context.Background(), "dep-1", LogFollowOptions{
+		PollInterval: 10 * time.Millisecond,
+		OnStatus:     func(_, status string) { statuses = append(statuses, status) },
+	}, func(entry models.LogEntry) {
+		lines = append(lines, entry.Output)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "finished", status)
+	assert.Equal(t, []string{"Cloning repository", "Deployment finished"}, lines)
+	assert.Equal(t, []string{"in_progress", "finished"}, statuses)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRequestLimiter(t *testing.T) {
+	limiter := NewRequestLimiter(6000)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, limiter.Wait(ctx))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	limiter.Backoff(50 * time.Millisecond)
+	start = time.Now()
+	require.NoError(t, limiter.Wait(ctx))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	limiter.Backoff(time.Minute)
+	assert.ErrorIs(t, limiter.Wait(cancelled), context.Canceled)
+
+	var unlimited *RequestLimiter
+	assert.NoError(t, unlimited.Wait(ctx))
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -871,7 +873,219 @@ func TestIsTerminalStatus(t *testing.T) {
 	assert.True(t, IsTerminalStatus("failed"))
 	assert.True(t, IsTerminalStatus("cancelled-by-user"))
 	assert.True(t, IsTerminalStatus("timed-out"))
+	assert.True(t, IsTerminalStatus("cancelled"))
+	assert.False(t, IsTerminalStatus("pending_approval"))
 	assert.False(t, IsTerminalStatus("in_progress"))
 	assert.False(t, IsTerminalStatus("queued"))
 	assert.False(t, IsTerminalStatus(""))
 }
+
+func TestDeploymentService_FindRollbackTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		wantUUID string
+		wantErr  bool
+	}{
+		{
+			name: "skips current and failed deployments",
+			response: `{"deployments": [
+				{"id": 4, "deployment_uuid": "dep-4", "status": "failed", "created_at": "2026-01-04T00:00:00Z"},
+				{"id": 3, "deployment_uuid": "dep-3", "status": "finished", "created_at": "2026-01-03T00:00:00Z"},
+				{"id": 1, "deployment_uuid": "dep-1", "status": "finished", "created_at": "2026-01-01T00:00:00Z"},
+				{"id": 2, "deployment_uuid": "dep-2", "status": "failed", "created_at": "2026-01-02T00:00:00Z"}
+			]}`,
+			wantUUID: "dep-1",
+		},
+		{
+			name:     "only one successful deployment",
+			response: `{"deployments": [{"id": 1, "deployment_uuid": "dep-1", "status": "finished"}]}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/deployments/applications/app-123", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			svc := NewDeploymentService(api.NewClient(server.URL, "test-token"))
+			result, err := svc.FindRollbackTarget(context.Background(), "app-123")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUUID, result.UUID)
+		})
+	}
+}
+
+func TestDeploymentService_CancelMany(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/deployments/dep-1/cancel", "/api/v1/deployments/dep-3/cancel":
+			_, _ = w.Write([]byte(`{"message":"Deployment cancelled successfully.","status":"cancelled-by-user"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Deployment cannot be cancelled. Current status: finished"}`))
+		}
+	}))
+	defer server.Close()
+
+	svc := NewDeploymentService(api.NewClient(server.URL, "test-token"))
+	results := svc.CancelMany(context.Background(), []string{"dep-1", "dep-2", "dep-3"})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, CancelResult{DeploymentUUID: "dep-1", Status: "cancelled-by-user", Message: "Deployment cancelled successfully."}, results[0])
+	assert.Equal(t, "dep-2", results[1].DeploymentUUID)
+	assert.Contains(t, results[1].Error, "cannot be cancelled")
+	assert.Equal(t, "cancelled-by-user", results[2].Status)
+}
+
+func TestDeploymentService_InProgressByApplication(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/deployments/applications/app-1", r.URL.Path)
+		assert.Equal(t, "take=20", r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"deployments": [
+			{"deployment_uuid": "dep-5", "status": "pending_approval"},
+			{"deployment_uuid": "dep-4", "status": "queued"},
+			{"deployment_uuid": "dep-3", "status": "in_progress"},
+			{"deployment_uuid": "dep-2", "status": "cancelled-by-user"},
+			{"deployment_uuid": "dep-1", "status": "finished"}
+		]}`))
+	}))
+	defer server.Close()
+
+	svc := NewDeploymentService(api.NewClient(server.URL, "test-token"))
+	deployments, err := svc.InProgressByApplication(context.Background(), "app-1")
+	require.NoError(t, err)
+	require.Len(t, deployments, 2)
+	assert.Equal(t, "dep-4", deployments[0].UUID)
+	assert.Equal(t, "dep-3", deployments[1].UUID)
+}
+
+func TestDeploymentService_FindRollbackTargetByCommit(t *testing.T) {
+	response := `{"deployments": [
+		{"id": 4, "deployment_uuid": "dep-4", "status": "finished", "commit": "abc9999", "created_at": "2026-01-04T00:00:00Z"},
+		{"id": 3, "deployment_uuid": "dep-3", "status": "finished", "commit": "abc1234", "created_at": "2026-01-03T00:00:00Z"},
+		{"id": 2, "deployment_uuid": "dep-2", "status": "failed", "commit": "def5678", "created_at": "2026-01-02T00:00:00Z"},
+		{"id": 1, "deployment_uuid": "dep-1", "status": "finished", "commit": "abc1234", "created_at": "2026-01-01T00:00:00Z"},
+		{"id": 0, "deployment_uuid": "dep-0", "status": "finished", "commit": "abd0000", "created_at": "2025-12-31T00:00:00Z"}
+	]}`
+
+	tests := []struct {
+		name     string
+		prefix   string
+		wantUUID string
+		wantErr  string
+	}{
+		{name: "most recent deployment of the commit", prefix: "abc1", wantUUID: "dep-3"},
+		{name: "case insensitive", prefix: "ABD", wantUUID: "dep-0"},
+		{name: "current deployment is not a target", prefix: "abc9", wantErr: "no previous successful deployment"},
+		{name: "failed deployments are not targets", prefix: "def", wantErr: "no previous successful deployment"},
+		{name: "ambiguous prefix", prefix: "ab", wantErr: "ambiguous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(response))
+			}))
+			defer server.Close()
+
+			svc := NewDeploymentService(api.NewClient(server.URL, "test-token"))
+			result, err := svc.FindRollbackTargetByCommit(context.Background(), "app-123", tt.prefix)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUUID, result.UUID)
+		})
+	}
+}
+
+func TestDeploymentService_ListByApplicationSince(t *testing.T) {
+	base := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	// 150 deployments, one per hour, newest first
+	all := make([]map[string]any, 150)
+	for i := range all {
+		all[i] = map[string]any{
+			"deployment_uuid": fmt.Sprintf("dep-%d", i),
+			"status":          "finished",
+			"created_at":      base.Add(-time.Duration(i) * time.Hour).Format(time.RFC3339),
+		}
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "/api/v1/deployments/applications/app-123", r.URL.Path)
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		take, _ := strconv.Atoi(r.URL.Query().Get("take"))
+		end := min(skip+take, len(all))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"count": len(all), "deployments": all[min(skip, end):end]})
+	}))
+	defer server.Close()
+
+	svc := NewDeploymentService(api.NewClient(server.URL, "test-token"))
+
+	t.Run("whole history", func(t *testing.T) {
+		requests.Store(0)
+		result, err := svc.ListByApplicationSince(context.Background(), "app-123", time.Time{})
+		require.NoError(t, err)
+		assert.Len(t, result, 150)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("stops at since", func(t *testing.T) {
+		requests.Store(0)
+		result, err := svc.ListByApplicationSince(context.Background(), "app-123", base.Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.Len(t, result, 25)
+		assert.Equal(t, "dep-24", result[24].UUID)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+}
+
+func TestDeploymentService_LatestFinished_PagesPastFailures(t *testing.T) {
+	// 120 failed deployments, newest first, before the one that last succeeded
+	all := make([]map[string]any, 130)
+	for i := range all {
+		status := "failed"
+		if i >= 120 {
+			status = "finished"
+		}
+		all[i] = map[string]any{"id": len(all) - i, "deployment_uuid": fmt.Sprintf("dep-%d", i), "status": status}
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		take, _ := strconv.Atoi(r.URL.Query().Get("take"))
+		assert.Equal(t, 100, take)
+		end := min(skip+take, len(all))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"count": len(all), "deployments": all[min(skip, end):end]})
+	}))
+	defer server.Close()
+
+	svc := NewDeploymentService(api.NewClient(server.URL, "test-token"))
+	latest, err := svc.LatestFinished(context.Background(), "app-123")
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, "dep-120", latest.UUID)
+	assert.Equal(t, int32(2), requests.Load())
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"fmt"
+	pathpkg "path"
+	"strings"
+)
+
+// GlobMatch reports whether a slash-separated file path matches a glob pattern, with
+// doublestar semantics:
+//   - * matches any characters within a path segment, ? a single one
+//   - [abc], [a-z] and [!abc] (or [^abc]) match one character of a class
+//   - {api,web} matches any of the alternatives, which may contain / and nest
+//   - ** as a whole segment matches any number of segments, none included
+//   - \ escapes the character that follows it
+//
+// A malformed pattern matches nothing; ValidateGlob reports what is wrong with it.
+func GlobMatch(pattern, name string) bool {
+	patterns, err := expandBraces(pattern)
+	if err != nil {
+		return false
+	}
+	segments := strings.Split(name, "/")
+	for _, p := range patterns {
+		if globMatch(strings.Split(normalizeClasses(p), "/"), segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateGlob returns an error when pattern is malformed
+func ValidateGlob(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	patterns, err := expandBraces(pattern)
+	if err != nil {
+		return err
+	}
+	for _, p := range patterns {
+		for _, seg := range strings.Split(normalizeClasses(p), "/") {
+			if _, err := pathpkg.Match(seg, ""); err != nil {
+				return fmt.Errorf("malformed pattern %q", pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// MatchAny reports whether name matches any of patterns
+func MatchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if GlobMatch(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func globMatch(pattern, path []string) bool {
+	for len(pattern) > 0 {
+		seg := pattern[0]
+		pattern = pattern[1:]
+
+		if seg == "**" {
+			// ** at the end matches everything
+			if len(pattern) == 0 {
+				return true
+			}
+			// Try matching the rest of pattern at every position in path
+			for i := 0; i <= len(path); i++ {
+				if globMatch(pattern, path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(path) == 0 {
+			return false
+		}
+
+		if !segmentMatch(seg, path[0]) {
+			return false
+		}
+		path = path[1:]
+	}
+
+	return len(path) == 0
+}
+
+// segmentMatch matches a single path segment against a pattern segment without braces
+func segmentMatch(pattern, s string) bool {
+	ok, err := pathpkg.Match(pattern, s)
+	return err == nil && ok
+}
+
+// expandBraces expands the {a,b} alternatives of pattern into the patterns they stand for
+func expandBraces(pattern string) ([]string, error) {
+	open, depth := -1, 0
+	var commas []int
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\':
+			i++
+		case inClass:
+			inClass = c != ']'
+		case c == '[':
+			inClass = true
+		case c == '{':
+			if depth == 0 {
+				open = i
+				commas = commas[:0]
+			}
+			depth++
+		case c == ',' && depth == 1:
+			commas = append(commas, i)
+		case c == '}' && depth > 0:
+			depth--
+			if depth > 0 {
+				continue
+			}
+			bounds := append(append([]int{open}, commas...), i)
+			var expanded []string
+			for j := 0; j+1 < len(bounds); j++ {
+				alternative := pattern[bounds[j]+1 : bounds[j+1]]
+				rest, err := expandBraces(pattern[:open] + alternative + pattern[i+1:])
+				if err != nil {
+					return nil, err
+				}
+				expanded = append(expanded, rest...)
+			}
+			return expanded, nil
+		}
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("unclosed { in pattern %q", pattern)
+	}
+	return []string{pattern}, nil
+}
+
+// normalizeClasses rewrites the [!abc] negated classes of pattern to the [^abc] that path.Match understands
+func normalizeClasses(pattern string) string {
+	if !strings.Contains(pattern, "[!") {
+		return pattern
+	}
+	b := []byte(pattern)
+	for i := 0; i < len(b); i++ {
+		switch {
+		case b[i] == '\\':
+			i++
+		case b[i] == '[' && i+1 < len(b) && b[i+1] == '!':
+			b[i+1] = '^'
+		}
+	}
+	return string(b)
+}
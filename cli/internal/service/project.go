@@ -40,6 +40,16 @@ func (s *ProjectService) Get(ctx context.Context, uuid string) (*models.Project,
 	return &project, nil
 }
 
+// GetEnvironment retrieves an environment of a project, by name or UUID, with its resources
+func (s *ProjectService) GetEnvironment(ctx context.Context, projectUUID, environment string) (*models.EnvironmentResources, error) {
+	var env models.EnvironmentResources
+	err := s.client.Get(ctx, fmt.Sprintf("projects/%s/%s", projectUUID, environment), &env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get environment %s of project %s: %w", environment, projectUUID, err)
+	}
+	return &env, nil
+}
+
 // Create creates a new project
 func (s *ProjectService) Create(ctx context.Context, req *models.ProjectCreateRequest) (*models.Project, error) {
 	var project models.Project
@@ -142,3 +142,33 @@ func TestProjectService_Create_NameOnly(t *testing.T) {
 	assert.Equal(t, "proj-minimal", result.UUID)
 	assert.Equal(t, "Minimal Project", result.Name)
 }
+
+func TestProjectService_GetEnvironment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/projects/proj-1/production", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"uuid": "env-1",
+			"name": "production",
+			"applications": [{"uuid": "app-1", "name": "api", "status": "running:healthy"}],
+			"services": [{"uuid": "svc-1", "name": "plausible", "status": "running"}],
+			"postgresqls": [{"uuid": "db-1", "name": "main-db", "status": "exited"}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test-token")
+	svc := NewProjectService(client)
+
+	result, err := svc.GetEnvironment(context.Background(), "proj-1", "production")
+	require.NoError(t, err)
+	assert.Equal(t, "env-1", result.UUID)
+
+	all := result.All()
+	require.Len(t, all, 3)
+	assert.Equal(t, models.EnvironmentResource{UUID: "app-1", Name: "api", Status: "running:healthy", Type: models.ResourceTypeApplication}, all[0])
+	assert.Equal(t, models.ResourceTypeService, all[1].Type)
+	assert.Equal(t, models.ResourceTypeDatabase, all[2].Type)
+}
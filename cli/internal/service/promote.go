@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// PromotionService promotes the image of a finished deployment to another environment
+// of the same project, without rebuilding it
+type PromotionService struct {
+	client     *api.Client
+	deploySvc  *DeploymentService
+	projectSvc *ProjectService
+}
+
+// NewPromotionService creates a new promotion service
+func NewPromotionService(client *api.Client) *PromotionService {
+	return &PromotionService{
+		client:     client,
+		deploySvc:  NewDeploymentService(client),
+		projectSvc: NewProjectService(client),
+	}
+}
+
+// DeploymentService returns the underlying deployment service for wait operations
+func (s *PromotionService) DeploymentService() *DeploymentService {
+	return s.deploySvc
+}
+
+// Plan resolves what promoting a deployment to targetEnv (name or UUID) would deploy where.
+// The server only promotes finished deployments with a commit, to an application of the
+// same name in another environment of the same project; Plan checks the same up front.
+func (s *PromotionService) Plan(ctx context.Context, deploymentUUID, targetEnv string) (*models.PromotionPlan, error) {
+	source, err := s.deploySvc.Get(ctx, deploymentUUID)
+	if err != nil {
+		return nil, err
+	}
+	if source.Status != "finished" {
+		return nil, fmt.Errorf("deployment %s has status %s; only finished deployments can be promoted", deploymentUUID, source.Status)
+	}
+	if source.Commit == nil || *source.Commit == "" {
+		return nil, fmt.Errorf("deployment %s has no commit to promote", deploymentUUID)
+	}
+	appUUID := source.ApplicationUUID()
+	if appUUID == "" {
+		return nil, fmt.Errorf("cannot determine the application of deployment %s", deploymentUUID)
+	}
+
+	app, project, err := s.locate(ctx, appUUID)
+	if err != nil {
+		return nil, err
+	}
+	sourceEnv := app.Environment
+
+	var target *models.EnvironmentResources
+	for _, e := range project.Environments {
+		if e.UUID != targetEnv && e.Name != targetEnv {
+			continue
+		}
+		if e.UUID == sourceEnv.UUID {
+			return nil, fmt.Errorf("deployment %s already runs in environment '%s'", deploymentUUID, e.Name)
+		}
+		target, err = s.projectSvc.GetEnvironment(ctx, project.UUID, e.UUID)
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+	if target == nil {
+		return nil, fmt.Errorf("environment '%s' not found in project '%s'", targetEnv, project.Name)
+	}
+
+	appName := app.Name
+	plan := &models.PromotionPlan{
+		DeploymentUUID:        deploymentUUID,
+		Application:           appName,
+		Project:               project.Name,
+		SourceEnvironment:     sourceEnv.Name,
+		TargetEnvironment:     target.Name,
+		TargetEnvironmentUUID: target.UUID,
+		Commit:                *source.Commit,
+	}
+	for _, a := range target.Applications {
+		if a.Name == appName {
+			plan.TargetApplicationUUID = a.UUID
+			break
+		}
+	}
+	if plan.TargetApplicationUUID == "" {
+		return nil, fmt.Errorf("application '%s' not found in environment '%s'; create it first, then promote", appName, target.Name)
+	}
+
+	current, err := s.deploySvc.LatestFinished(ctx, plan.TargetApplicationUUID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.Commit != nil {
+		plan.CurrentCommit = *current.Commit
+	}
+	return plan, nil
+}
+
+// applicationLocation is the part of an application payload that tells where it runs
+type applicationLocation struct {
+	Name        string `json:"name"`
+	Environment *struct {
+		UUID      string `json:"uuid"`
+		Name      string `json:"name"`
+		ProjectID int64  `json:"project_id"`
+	} `json:"environment"`
+}
+
+// locate finds an application and the project holding it. The application payload
+// carries its environment, whose project is matched by ID in the project list.
+func (s *PromotionService) locate(ctx context.Context, appUUID string) (*applicationLocation, *models.Project, error) {
+	var app applicationLocation
+	if err := s.client.Get(ctx, fmt.Sprintf("applications/%s", appUUID), &app); err != nil {
+		return nil, nil, fmt.Errorf("failed to get application %s: %w", appUUID, err)
+	}
+	if app.Environment == nil {
+		return nil, nil, fmt.Errorf("cannot determine the environment of application %s", appUUID)
+	}
+
+	var projects []struct {
+		ID   int64  `json:"id"`
+		UUID string `json:"uuid"`
+	}
+	if err := s.client.Get(ctx, "projects", &projects); err != nil {
+		return nil, nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	for _, p := range projects {
+		if p.ID == app.Environment.ProjectID {
+			project, err := s.projectSvc.Get(ctx, p.UUID)
+			if err != nil {
+				return nil, nil, err
+			}
+			return &app, project, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("project of application %s not found", appUUID)
+}
+
+// Promote queues a deployment of the image built by deploymentUUID to the target environment
+func (s *PromotionService) Promote(ctx context.Context, deploymentUUID, targetEnvUUID string) (*models.PromoteResponse, error) {
+	body := map[string]string{"target_environment_uuid": targetEnvUUID}
+	var response models.PromoteResponse
+	if err := s.client.Post(ctx, fmt.Sprintf("deployments/%s/promote", deploymentUUID), body, &response); err != nil {
+		return nil, fmt.Errorf("failed to promote deployment %s: %w", deploymentUUID, err)
+	}
+	return &response, nil
+}
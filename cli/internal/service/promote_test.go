@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+)
+
+// promotionServer serves a project with a staging and a production environment, each
+// holding an "api" application
+func promotionServer(t *testing.T, sourceStatus string) *httptest.Server {
+	t.Helper()
+	routes := map[string]string{
+		"/api/v1/deployments/dep-1":        `{"deployment_uuid":"dep-1","status":"` + sourceStatus + `","commit":"0123456789abcdef","deployment_url":"/applications/app-staging/deployment/dep-1"}`,
+		"/api/v1/applications/app-staging": `{"uuid":"app-staging","name":"api","environment":{"uuid":"env-staging","name":"staging","project_id":2}}`,
+		"/api/v1/projects":                 `[{"id":1,"uuid":"proj-other","name":"other"},{"id":2,"uuid":"proj-1","name":"shop"}]`,
+		"/api/v1/projects/proj-1":          `{"uuid":"proj-1","name":"shop","environments":[{"uuid":"env-staging","name":"staging"},{"uuid":"env-prod","name":"production"}]}`,
+		"/api/v1/projects/proj-1/env-prod": `{"uuid":"env-prod","name":"production","applications":[{"uuid":"app-prod","name":"api"}]}`,
+		"/api/v1/deployments/applications/app-prod": `{"count":2,"deployments":[
+			{"deployment_uuid":"p-2","status":"failed","commit":"bbbbbbb","created_at":"2026-01-02T00:00:00Z"},
+			{"deployment_uuid":"p-1","status":"finished","commit":"aaaaaaa","created_at":"2026-01-01T00:00:00Z"}]}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestPromotionService_Plan(t *testing.T) {
+	server := promotionServer(t, "finished")
+	defer server.Close()
+
+	svc := NewPromotionService(api.NewClient(server.URL, "test-token"))
+	plan, err := svc.Plan(context.Background(), "dep-1", "production")
+	require.NoError(t, err)
+
+	assert.Equal(t, "api", plan.Application)
+	assert.Equal(t, "shop", plan.Project)
+	assert.Equal(t, "staging", plan.SourceEnvironment)
+	assert.Equal(t, "production", plan.TargetEnvironment)
+	assert.Equal(t, "env-prod", plan.TargetEnvironmentUUID)
+	assert.Equal(t, "app-prod", plan.TargetApplicationUUID)
+	assert.Equal(t, "0123456789abcdef", plan.Commit)
+	assert.Equal(t, "aaaaaaa", plan.CurrentCommit, "the failed deployment is not what runs")
+}
+
+func TestPromotionService_Plan_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		target  string
+		wantErr string
+	}{
+		{name: "not finished", status: "failed", target: "production", wantErr: "only finished deployments"},
+		{name: "same environment", status: "finished", target: "staging", wantErr: "already runs in environment"},
+		{name: "unknown environment", status: "finished", target: "qa", wantErr: "environment 'qa' not found in project 'shop'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := promotionServer(t, tt.status)
+			defer server.Close()
+
+			svc := NewPromotionService(api.NewClient(server.URL, "test-token"))
+			_, err := svc.Plan(context.Background(), "dep-1", tt.target)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPromotionService_Promote(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/deployments/dep-1/promote", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		var body map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "env-prod", body["target_environment_uuid"])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"message":"Image promotion queued.","deployment_uuid":"dep-2","promoted_image":"app-staging:0123456"}`))
+	}))
+	defer server.Close()
+
+	svc := NewPromotionService(api.NewClient(server.URL, "test-token"))
+	result, err := svc.Promote(context.Background(), "dep-1", "env-prod")
+	require.NoError(t, err)
+	assert.Equal(t, "dep-2", result.DeploymentUUID)
+	assert.Equal(t, "app-staging:0123456", result.PromotedImage)
+}
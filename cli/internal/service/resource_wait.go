@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Statuses resource waits commonly end at. Resource statuses are "state[:health]",
+// e.g. "running:healthy", "running:unknown" or "exited", and may carry further segments,
+// like the "running:healthy:excluded" of a service with containers excluded from its status.
+const (
+	ResourceRunning        = "running"
+	ResourceRunningHealthy = "running:healthy"
+	ResourceExited         = "exited"
+	// Servers report reachable:usable once validated, reachable:unusable or unreachable otherwise
+	ServerReachableUsable = "reachable:usable"
+)
+
+// ResourceStatus is the status of an application, database, service or server
+type ResourceStatus struct {
+	Status string
+	// HealthChecked is false when the resource has no health check configured, in which
+	// case a running resource counts as healthy
+	HealthChecked bool
+}
+
+// ResourceStatusFunc fetches the current status of a resource
+type ResourceStatusFunc func(ctx context.Context) (ResourceStatus, error)
+
+// ResourceWaitOptions configure WaitForResource
+type ResourceWaitOptions struct {
+	// Until is the status to wait for; "running" matches any health, "running:healthy" only healthy
+	Until        string
+	PollInterval time.Duration
+	// Settle ignores a matching status until the resource was seen in another status or
+	// this long has passed. Restarts need it, as the resource still reports its old status
+	// until the restart is picked up.
+	Settle time.Duration
+	// OnStatus is called whenever the polled status changes
+	OnStatus func(status string)
+}
+
+// ParseUntil validates a status to wait for
+func ParseUntil(until string) error {
+	for _, segment := range strings.Split(until, ":") {
+		if segment == "" || strings.ContainsAny(segment, " \t") {
+			return fmt.Errorf("invalid status %q: expected state[:health], e.g. running or running:healthy", until)
+		}
+	}
+	return nil
+}
+
+// StatusMatches reports whether status satisfies until. The segments of until must
+// match the leading segments of the status, so a state without health matches every
+// health and "running:healthy" matches "running:healthy:excluded". A wanted "healthy"
+// also matches a running resource without a health check.
+func StatusMatches(status ResourceStatus, until string) bool {
+	have := strings.Split(status.Status, ":")
+	want := strings.Split(until, ":")
+	for i, w := range want {
+		if i < len(have) && strings.EqualFold(have[i], w) {
+			continue
+		}
+		if i == 1 && strings.EqualFold(w, "healthy") && !status.HealthChecked {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// WaitForResource polls a resource until its status matches opts.Until or the context
+// ends. It returns the last status seen, along with the context error on a timeout.
+func WaitForResource(ctx context.Context, fetch ResourceStatusFunc, opts ResourceWaitOptions) (string, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 3 * time.Second
+	}
+
+	settleUntil := time.Now().Add(opts.Settle)
+	left := opts.Settle <= 0
+	lastStatus := ""
+	for {
+		status, err := fetch(ctx)
+		if err != nil {
+			// A deadline hit mid-request is a timeout, not a polling failure
+			if ctx.Err() != nil {
+				return lastStatus, ctx.Err()
+			}
+			return lastStatus, err
+		}
+		if status.Status != lastStatus {
+			lastStatus = status.Status
+			if opts.OnStatus != nil {
+				opts.OnStatus(status.Status)
+			}
+		}
+
+		matches := StatusMatches(status, opts.Until)
+		if !matches {
+			left = true
+		}
+		if matches && (left || !time.Now().Before(settleUntil)) {
+			return lastStatus, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return lastStatus, ctx.Err()
+		case <-time.After(opts.PollInterval):
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+)
+
+func TestStatusMatches(t *testing.T) {
+	tests := []struct {
+		status ResourceStatus
+		until  string
+		want   bool
+	}{
+		{ResourceStatus{Status: "running:healthy", HealthChecked: true}, "running", true},
+		{ResourceStatus{Status: "running:unhealthy", HealthChecked: true}, "running", true},
+		{ResourceStatus{Status: "running:healthy", HealthChecked: true}, "running:healthy", true},
+		{ResourceStatus{Status: "running:unhealthy", HealthChecked: true}, "running:healthy", false},
+		{ResourceStatus{Status: "running:unknown"}, "running:healthy", true},
+		{ResourceStatus{Status: "exited", HealthChecked: true}, "running", false},
+		{ResourceStatus{Status: "exited:unhealthy"}, "exited", true},
+		{ResourceStatus{Status: "reachable:unusable", HealthChecked: true}, "reachable:usable", false},
+		{ResourceStatus{Status: "Running:Healthy", HealthChecked: true}, "running:healthy", true},
+		{ResourceStatus{Status: "running:healthy:excluded", HealthChecked: true}, "running:healthy", true},
+		{ResourceStatus{Status: "running:unhealthy:excluded", HealthChecked: true}, "running:healthy", false},
+		{ResourceStatus{Status: "running:healthy:excluded", HealthChecked: true}, "running:healthy:excluded", true},
+		{ResourceStatus{Status: "running:healthy", HealthChecked: true}, "running:healthy:excluded", false},
+		{ResourceStatus{Status: "running"}, "running:healthy", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, StatusMatches(tt.status, tt.until), "%s until %s", tt.status.Status, tt.until)
+	}
+}
+
+func TestParseUntil(t *testing.T) {
+	assert.NoError(t, ParseUntil("running"))
+	assert.NoError(t, ParseUntil("running:healthy"))
+	assert.Error(t, ParseUntil(""))
+	assert.Error(t, ParseUntil(":healthy"))
+	assert.NoError(t, ParseUntil("running:healthy:excluded"))
+	assert.Error(t, ParseUntil("running::excluded"))
+	assert.Error(t, ParseUntil("running:"))
+	assert.Error(t, ParseUntil("running healthy"))
+}
+
+// statusSequence returns the statuses in order, repeating the last one
+func statusSequence(statuses ...string) ResourceStatusFunc {
+	i := 0
+	return func(context.Context) (ResourceStatus, error) {
+		s := statuses[min(i, len(statuses)-1)]
+		i++
+		return ResourceStatus{Status: s, HealthChecked: true}, nil
+	}
+}
+
+func TestWaitForResource(t *testing.T) {
+	var seen []string
+	status, err := WaitForResource(context.Background(), statusSequence("exited", "running:starting", "running:healthy"), ResourceWaitOptions{
+		Until:        ResourceRunningHealthy,
+		PollInterval: time.Millisecond,
+		OnStatus:     func(s string) { seen = append(seen, s) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "running:healthy", status)
+	assert.Equal(t, []string{"exited", "running:starting", "running:healthy"}, seen)
+}
+
+func TestWaitForResource_Settle(t *testing.T) {
+	// The old status is ignored until the resource was seen restarting
+	polls := 0
+	fetch := statusSequence("running:healthy", "running:healthy", "restarting", "running:healthy")
+	status, err := WaitForResource(context.Background(), func(ctx context.Context) (ResourceStatus, error) {
+		polls++
+		return fetch(ctx)
+	}, ResourceWaitOptions{Until: ResourceRunningHealthy, PollInterval: time.Millisecond, Settle: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, "running:healthy", status)
+	assert.Equal(t, 4, polls)
+
+	// Once the settle period is over the old status counts
+	start := time.Now()
+	_, err = WaitForResource(context.Background(), statusSequence("running:healthy"),
+		ResourceWaitOptions{Until: ResourceRunningHealthy, PollInterval: 5 * time.Millisecond, Settle: 20 * time.Millisecond})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestWaitForResource_Timeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	status, err := WaitForResource(ctx, statusSequence("running:unhealthy"), ResourceWaitOptions{Until: ResourceRunningHealthy, PollInterval: 5 * time.Millisecond})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "running:unhealthy", status)
+}
+
+func TestService_Status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/services/svc-checked/healthcheck":
+			_, _ = w.Write([]byte(`{"enabled":true,"type":"http","status":"running:unhealthy"}`))
+		case "/api/v1/services/svc-unchecked/healthcheck":
+			_, _ = w.Write([]byte(`{"enabled":false,"status":"running:unknown"}`))
+		case "/api/v1/services/svc-old/healthcheck":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/v1/services/svc-old":
+			_, _ = w.Write([]byte(`{"uuid":"svc-old","status":"running:unknown"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc := NewService(api.NewClient(server.URL, "test-token"))
+
+	status, err := svc.Status(context.Background(), "svc-checked")
+	require.NoError(t, err)
+	assert.Equal(t, ResourceStatus{Status: "running:unhealthy", HealthChecked: true}, status)
+
+	status, err = svc.Status(context.Background(), "svc-unchecked")
+	require.NoError(t, err)
+	assert.True(t, StatusMatches(status, ResourceRunningHealthy))
+
+	// Servers without the healthcheck endpoint fall back to the service status
+	status, err = svc.Status(context.Background(), "svc-old")
+	require.NoError(t, err)
+	assert.Equal(t, ResourceStatus{Status: "running:unknown"}, status)
+}
+
+func TestServerService_Status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/servers/srv-1", r.URL.Path)
+		_, _ = w.Write([]byte(`{"uuid":"srv-1","settings":{"is_reachable":true,"is_usable":false}}`))
+	}))
+	defer server.Close()
+
+	status, err := NewServerService(api.NewClient(server.URL, "test-token")).Status(context.Background(), "srv-1")
+	require.NoError(t, err)
+	assert.Equal(t, "reachable:unusable", status.Status)
+}
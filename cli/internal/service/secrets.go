@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// SecretService collects the environment variable values that are masked in logs
+type SecretService struct {
+	appSvc     *ApplicationService
+	serviceSvc *Service
+	deploySvc  *DeploymentService
+}
+
+// NewSecretService creates a new secret service
+func NewSecretService(client *api.Client) *SecretService {
+	return &SecretService{
+		appSvc:     NewApplicationService(client),
+		serviceSvc: NewService(client),
+		deploySvc:  NewDeploymentService(client),
+	}
+}
+
+// ResourceSecrets returns the environment variable values of the applications and services
+// among resources, both as stored and with references to shared variables resolved.
+// Databases have no environment variables in the API. A resource without a type, such as
+// the resource of a deployment, is tried as an application and then as a service.
+func (s *SecretService) ResourceSecrets(ctx context.Context, resources []models.EnvironmentResource) ([]string, error) {
+	var values []string
+	for _, res := range resources {
+		var envs []string
+		var err error
+		switch res.Type {
+		case models.ResourceTypeApplication:
+			envs, err = s.applicationSecrets(ctx, res.UUID)
+		case models.ResourceTypeService:
+			envs, err = s.serviceSecrets(ctx, res.UUID)
+		case "":
+			if envs, err = s.applicationSecrets(ctx, res.UUID); err != nil {
+				envs, err = s.serviceSecrets(ctx, res.UUID)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, envs...)
+	}
+	return values, nil
+}
+
+// DeploymentSecrets returns the environment variable values of the application a deployment belongs to
+func (s *SecretService) DeploymentSecrets(ctx context.Context, deploymentUUID string) ([]string, error) {
+	deployment, err := s.deploySvc.Get(ctx, deploymentUUID)
+	if err != nil {
+		return nil, err
+	}
+	if deployment.Application == nil || deployment.Application.UUID == "" {
+		return nil, fmt.Errorf("deployment %s does not identify its application", deploymentUUID)
+	}
+	return s.applicationSecrets(ctx, deployment.Application.UUID)
+}
+
+func (s *SecretService) applicationSecrets(ctx context.Context, uuid string) ([]string, error) {
+	envs, err := s.appSvc.ListEnvs(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, 2*len(envs))
+	for _, env := range envs {
+		values = appendSecret(values, env.Value, env.RealValue)
+	}
+	return values, nil
+}
+
+func (s *SecretService) serviceSecrets(ctx context.Context, uuid string) ([]string, error) {
+	envs, err := s.serviceSvc.ListEnvs(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, 2*len(envs))
+	for _, env := range envs {
+		values = appendSecret(values, env.Value, env.RealValue)
+	}
+	return values, nil
+}
+
+// appendSecret appends the stored value of a variable and, when set, its resolved value
+func appendSecret(values []string, value string, realValue *string) []string {
+	values = append(values, value)
+	if realValue != nil {
+		values = append(values, *realValue)
+	}
+	return values
+}
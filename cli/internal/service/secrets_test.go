@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+func TestSecretService_ResourceSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/applications/app-uuid-123/envs":
+			_, _ = w.Write([]byte(`[
+				{"uuid": "env-1", "key": "API_KEY", "value": "sk-live-123", "real_value": "sk-live-123"},
+				{"uuid": "env-2", "key": "DATABASE_URL", "value": "{{ environment.DB_URL }}", "real_value": "postgres://db"},
+				{"uuid": "env-3", "key": "PORT", "value": "3000"}
+			]`))
+		case "/api/v1/services/svc-uuid-456/envs":
+			_, _ = w.Write([]byte(`[{"uuid": "env-4", "key": "SMTP_PASSWORD", "value": "hunter22"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found."}`))
+		}
+	}))
+	defer server.Close()
+
+	svc := NewSecretService(api.NewClient(server.URL, "test-token", api.WithRetries(0)))
+
+	values, err := svc.ResourceSecrets(context.Background(), []models.EnvironmentResource{
+		{UUID: "app-uuid-123", Type: models.ResourceTypeApplication},
+		{UUID: "db-uuid-789", Type: "postgresql"},
+		// Without a type the service is found after the application lookup fails
+		{UUID: "svc-uuid-456"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sk-live-123", "sk-live-123", "{{ environment.DB_URL }}", "postgres://db", "3000", "hunter22"}, values)
+}
+
+func TestSecretService_DeploymentSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/deployments/dep-1":
+			_, _ = w.Write([]byte(`{"deployment_uuid": "dep-1", "application_name": "web", "application": {"uuid": "app-2", "name": "web"}}`))
+		case "/api/v1/deployments/dep-2":
+			_, _ = w.Write([]byte(`{"deployment_uuid": "dep-2", "application_name": "web"}`))
+		case "/api/v1/applications/app-2/envs":
+			_, _ = w.Write([]byte(`[{"uuid": "env-1", "key": "API_KEY", "value": "sk-live-456"}]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc := NewSecretService(api.NewClient(server.URL, "test-token"))
+
+	values, err := svc.DeploymentSecrets(context.Background(), "dep-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sk-live-456"}, values)
+
+	_, err = svc.DeploymentSecrets(context.Background(), "dep-2")
+	assert.EqualError(t, err, "deployment dep-2 does not identify its application")
+}
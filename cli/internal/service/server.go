@@ -57,6 +57,15 @@ func (s *ServerService) Delete(ctx context.Context, uuid string) error {
 	return s.client.Delete(ctx, "servers/"+uuid)
 }
 
+// Status returns the status of a server for WaitForResource
+func (s *ServerService) Status(ctx context.Context, uuid string) (ResourceStatus, error) {
+	server, err := s.Get(ctx, uuid)
+	if err != nil {
+		return ResourceStatus{}, err
+	}
+	return ResourceStatus{Status: server.Status(), HealthChecked: true}, nil
+}
+
 // Validate validates a server by UUID
 func (s *ServerService) Validate(ctx context.Context, uuid string) (*models.Response, error) {
 	var response models.Response
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
 
 	"github.com/saturn-platform/saturn-cli/internal/api"
 	"github.com/saturn-platform/saturn-cli/internal/models"
@@ -100,6 +102,51 @@ func (s *Service) Restart(ctx context.Context, uuid string) (*models.ServiceLife
 	return &resp, nil
 }
 
+// GetHealthcheck retrieves the health check configuration and status of a service
+func (s *Service) GetHealthcheck(ctx context.Context, uuid string) (*models.ServiceHealthcheck, error) {
+	var hc models.ServiceHealthcheck
+	err := s.client.Get(ctx, fmt.Sprintf("services/%s/healthcheck", uuid), &hc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get healthcheck for service %s: %w", uuid, err)
+	}
+	return &hc, nil
+}
+
+// Status returns the status of a service for WaitForResource, taken from its health
+// check when the server provides one
+func (s *Service) Status(ctx context.Context, uuid string) (ResourceStatus, error) {
+	if hc, err := s.GetHealthcheck(ctx, uuid); err == nil && hc.Status != "" {
+		return ResourceStatus{Status: hc.Status, HealthChecked: hc.Enabled}, nil
+	}
+	svc, err := s.Get(ctx, uuid)
+	if err != nil {
+		return ResourceStatus{}, err
+	}
+	return ResourceStatus{Status: svc.Status}, nil
+}
+
+// Logs retrieves container logs for a service. When container is set, only that container is returned.
+func (s *Service) Logs(ctx context.Context, uuid string, lines int, container string) (*models.ServiceLogsResponse, error) {
+	params := url.Values{}
+	if lines > 0 {
+		params.Set("lines", strconv.Itoa(lines))
+	}
+	if container != "" {
+		params.Set("container", container)
+	}
+	endpoint := fmt.Sprintf("services/%s/logs", uuid)
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	var resp models.ServiceLogsResponse
+	err := s.client.Get(ctx, endpoint, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs for service %s: %w", uuid, err)
+	}
+	return &resp, nil
+}
+
 // ListEnvs retrieves all environment variables for a service
 func (s *Service) ListEnvs(ctx context.Context, uuid string) ([]models.ServiceEnvironmentVariable, error) {
 	var envs []models.ServiceEnvironmentVariable
@@ -436,3 +436,29 @@ func TestService_Create_Error(t *testing.T) {
 
 	require.Error(t, err)
 }
+
+func TestService_Logs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/services/svc-uuid-123/logs", r.URL.Path)
+		assert.Equal(t, "50", r.URL.Query().Get("lines"))
+		assert.Equal(t, "web-svc-uuid-123", r.URL.Query().Get("container"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"service_uuid": "svc-uuid-123",
+			"containers": {
+				"web-svc-uuid-123": {"type": "application", "name": "web", "status": "running", "logs": "listening on :80\n"}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	svc := NewService(api.NewClient(server.URL, "test-token"))
+	result, err := svc.Logs(context.Background(), "svc-uuid-123", 50, "web-svc-uuid-123")
+	require.NoError(t, err)
+	require.Contains(t, result.Containers, "web-svc-uuid-123")
+	container := result.Containers["web-svc-uuid-123"]
+	assert.Equal(t, "web", container.Name)
+	require.NotNil(t, container.Logs)
+	assert.Equal(t, "listening on :80\n", *container.Logs)
+}
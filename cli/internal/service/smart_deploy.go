@@ -6,7 +6,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v3"
 
@@ -59,9 +62,42 @@ func LoadConfig(dir string) (*models.SmartConfig, error) {
 		return nil, fmt.Errorf("unsupported %s version: %d (expected 1)", configFileName, cfg.Version)
 	}
 
+	if err := validatePatterns(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", configFileName, err)
+	}
+	if err := ValidateGraph(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", configFileName, err)
+	}
+
 	return &cfg, nil
 }
 
+// validatePatterns checks the path and ignore patterns of cfg, so that a typo fails
+// loudly instead of silently matching nothing
+func validatePatterns(cfg *models.SmartConfig) error {
+	for _, p := range cfg.Ignore {
+		if err := ValidateGlob(p); err != nil {
+			return fmt.Errorf("ignore: %w", err)
+		}
+	}
+	for name, comp := range cfg.Components {
+		if len(comp.Path) == 0 {
+			return fmt.Errorf("component %s: path is required", name)
+		}
+		for _, p := range comp.Path {
+			if err := ValidateGlob(p); err != nil {
+				return fmt.Errorf("component %s: path: %w", name, err)
+			}
+		}
+		for _, p := range comp.Ignore {
+			if err := ValidateGlob(p); err != nil {
+				return fmt.Errorf("component %s: ignore: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // WriteConfig serializes a SmartConfig to .saturn.yml in the given directory.
 func WriteConfig(dir string, cfg *models.SmartConfig) error {
 	path := filepath.Join(dir, configFileName)
@@ -98,9 +134,9 @@ func GenerateConfig(resources []models.Resource) *models.SmartConfig {
 		if r.BaseDirectory != nil && *r.BaseDirectory != "" && *r.BaseDirectory != "/" {
 			dir := strings.TrimPrefix(*r.BaseDirectory, "/")
 			dir = strings.TrimSuffix(dir, "/")
-			comp.Path = dir + "/**"
+			comp.Path = models.Patterns{dir + "/**"}
 		} else {
-			comp.Path = "**"
+			comp.Path = models.Patterns{"**"}
 		}
 
 		// Use a sanitized resource name as key
@@ -130,23 +166,55 @@ func sanitizeKey(name string) string {
 
 // --- Git operations ---
 
-// GetChangedFiles returns files changed between base branch and HEAD.
-func GetChangedFiles(ctx context.Context, base string) ([]string, error) {
-	// #nosec G204 - base is from config, not user input
-	cmd := exec.CommandContext(ctx, "git", "diff", "--name-only", base+"..HEAD")
-	out, err := cmd.Output()
+// CommitSHAPattern matches an abbreviated or full commit SHA, which is what the server
+// accepts as a commit to deploy. Commits the API returns are checked against it before
+// they reach git.
+var CommitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+
+// GitCommitExists reports whether a commit is present in the local repository
+func GitCommitExists(ctx context.Context, commit string) bool {
+	// #nosec G204 - the commit is passed as a single argument after --end-of-options, never through a shell
+	return exec.CommandContext(ctx, "git", "cat-file", "-e", "--end-of-options", commit+"^{commit}").Run() == nil
+}
+
+// GetChangedFilesBetween returns the files that differ between two commits
+func GetChangedFilesBetween(ctx context.Context, from, to string) ([]string, error) {
+	// #nosec G204 - the commits are passed after --end-of-options, never through a shell
+	out, err := exec.CommandContext(ctx, "git", "diff", "--name-only", "--end-of-options", from, to).Output()
 	if err != nil {
 		return nil, fmt.Errorf("git diff failed: %w", err)
 	}
+	return splitLines(string(out)), nil
+}
+
+// GetCommitLog returns the commits reachable from to but not from, newest first
+func GetCommitLog(ctx context.Context, from, to string) ([]models.GitCommit, error) {
+	// #nosec G204 - the range is passed after --end-of-options, never through a shell
+	out, err := exec.CommandContext(ctx, "git", "log", "--format=%H%x09%an%x09%s", "--end-of-options", from+".."+to).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
 
-	var files []string
-	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+	var commits []models.GitCommit
+	for _, line := range splitLines(string(out)) {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		commits = append(commits, models.GitCommit{SHA: parts[0], Author: parts[1], Subject: parts[2]})
+	}
+	return commits, nil
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
 		line = strings.TrimSpace(line)
 		if line != "" {
-			files = append(files, line)
+			lines = append(lines, line)
 		}
 	}
-	return files, nil
+	return lines
 }
 
 // GetGitRemoteURL returns the origin remote URL for the current repo.
@@ -183,61 +251,38 @@ func NormalizeGitURL(rawURL string) string {
 
 // --- Matching ---
 
-// GlobMatch checks if a file path matches a glob pattern.
-// Supports ** for recursive directory matching, * for single segment wildcard.
-func GlobMatch(pattern, path string) bool {
-	return globMatch(strings.Split(pattern, "/"), strings.Split(path, "/"))
-}
-
-func globMatch(pattern, path []string) bool {
-	for len(pattern) > 0 {
-		seg := pattern[0]
-		pattern = pattern[1:]
-
-		if seg == "**" {
-			// ** at the end matches everything
-			if len(pattern) == 0 {
-				return true
-			}
-			// Try matching the rest of pattern at every position in path
-			for i := 0; i <= len(path); i++ {
-				if globMatch(pattern, path[i:]) {
-					return true
-				}
-			}
-			return false
-		}
-
-		if len(path) == 0 {
-			return false
+// MatchComponents maps each component of cfg to the files among files that match one of its
+// path patterns and none of its ignore patterns. Files matching the top-level ignore patterns
+// belong to no component. Components without matching files are left out.
+func MatchComponents(cfg *models.SmartConfig, files []string) map[string][]string {
+	matches := make(map[string][]string) // component name -> matched files
+	for _, file := range files {
+		if MatchAny(cfg.Ignore, file) {
+			continue
 		}
-
-		if !segmentMatch(seg, path[0]) {
-			return false
+		for name, comp := range cfg.Components {
+			if MatchAny(comp.Path, file) && !MatchAny(comp.Ignore, file) {
+				matches[name] = append(matches[name], file)
+			}
 		}
-		path = path[1:]
 	}
+	return matches
+}
 
-	return len(path) == 0
+// BuildDeployPlan matches changed files to components, resolves triggers transitively,
+// orders the components into waves by depends_on and looks up UUIDs.
+func (s *SmartDeployService) BuildDeployPlan(ctx context.Context, files []string, cfg *models.SmartConfig) (*models.SmartDeployPlan, error) {
+	return s.BuildDeployPlanFromChanges(ctx, []ChangeSet{{Files: files}}, cfg)
 }
 
-// segmentMatch matches a single path segment against a pattern segment with * wildcards.
-func segmentMatch(pattern, s string) bool {
-	if pattern == "*" {
-		return true
-	}
-	// Simple * wildcard within segment
-	if strings.Contains(pattern, "*") {
-		parts := strings.Split(pattern, "*")
-		if len(parts) == 2 {
-			return strings.HasPrefix(s, parts[0]) && strings.HasSuffix(s, parts[1])
-		}
+// BuildDeployPlanFromChanges is BuildDeployPlan for changes measured from several commits.
+// A component is deployed when it, or a component triggering it, changed in the change
+// set it belongs to.
+func (s *SmartDeployService) BuildDeployPlanFromChanges(ctx context.Context, changes []ChangeSet, cfg *models.SmartConfig) (*models.SmartDeployPlan, error) {
+	if err := ValidateGraph(cfg); err != nil {
+		return nil, err
 	}
-	return pattern == s
-}
 
-// BuildDeployPlan matches changed files to components, resolves triggers, and looks up UUIDs.
-func (s *SmartDeployService) BuildDeployPlan(ctx context.Context, files []string, cfg *models.SmartConfig) (*models.SmartDeployPlan, error) {
 	// Fetch resources for UUID lookup
 	resources, err := s.resourceSvc.List(ctx)
 	if err != nil {
@@ -251,70 +296,88 @@ func (s *SmartDeployService) BuildDeployPlan(ctx context.Context, files []string
 
 	plan := &models.SmartDeployPlan{
 		BaseBranch: cfg.BaseBranch,
-		FilesTotal: len(files),
-	}
-
-	// Track which components have direct changes
-	directMatches := make(map[string][]string) // component name -> matched files
-
-	for name, comp := range cfg.Components {
-		for _, file := range files {
-			if GlobMatch(comp.Path, file) {
-				directMatches[name] = append(directMatches[name], file)
-			}
-		}
 	}
 
-	// Build components from direct matches
+	var components []models.SmartDeployComponent
 	added := make(map[string]bool)
-
-	for name, matchedFiles := range directMatches {
-		comp := cfg.Components[name]
-		dc := models.SmartDeployComponent{
-			Name:         name,
-			ResourceName: comp.Resource,
-			FilesChanged: len(matchedFiles),
-			Reason:       "direct",
-			Files:        matchedFiles,
+	changedFiles := make(map[string]bool)
+	for _, set := range changes {
+		for _, f := range set.Files {
+			changedFiles[f] = true
 		}
-
-		if r, ok := nameToResource[comp.Resource]; ok {
-			dc.ResourceUUID = r.UUID
+		concerned := make(map[string]bool, len(set.Components))
+		for _, name := range set.Components {
+			concerned[name] = true
+		}
+		include := func(name string) bool {
+			return !added[name] && (set.Components == nil || concerned[name])
+		}
+		newComponent := func(name string) models.SmartDeployComponent {
+			comp := cfg.Components[name]
+			dc := models.SmartDeployComponent{Name: name, ResourceName: comp.Resource, Since: set.Commit}
+			if r, ok := nameToResource[comp.Resource]; ok {
+				dc.ResourceUUID = r.UUID
+			}
+			added[name] = true
+			return dc
 		}
 
-		plan.Components = append(plan.Components, dc)
-		added[name] = true
-	}
+		// Components with direct changes
+		directMatches := MatchComponents(cfg, set.Files)
+		direct := make([]string, 0, len(directMatches))
+		for name := range directMatches {
+			direct = append(direct, name)
+		}
+		sort.Strings(direct)
 
-	// Resolve triggers: if component X has triggers: [Y, Z], and X has direct changes,
-	// then Y and Z should also be deployed
-	for name := range directMatches {
-		comp := cfg.Components[name]
-		for _, triggerTarget := range comp.Triggers {
-			if added[triggerTarget] {
+		for _, name := range direct {
+			if !include(name) {
 				continue
 			}
+			dc := newComponent(name)
+			dc.Reason = "direct"
+			dc.Files = directMatches[name]
+			dc.FilesChanged = len(dc.Files)
+			components = append(components, dc)
+		}
 
-			targetComp, exists := cfg.Components[triggerTarget]
-			if !exists {
+		// Resolve triggers: if component X has triggers: [Y], and X is deployed, Y is
+		// deployed as well, and so are the components Y triggers. X may be outside the
+		// change set: Y deploys when X changed since the commit Y is compared against.
+		for _, t := range resolveTriggers(cfg, direct) {
+			if !include(t.name) {
 				continue
 			}
+			dc := newComponent(t.name)
+			dc.Reason = "triggered"
+			dc.TriggerBy = t.by
+			components = append(components, dc)
+		}
+	}
+	plan.FilesTotal = len(changedFiles)
 
-			dc := models.SmartDeployComponent{
-				Name:         triggerTarget,
-				ResourceName: targetComp.Resource,
-				Reason:       "triggered",
-				TriggerBy:    name,
-			}
-
-			if r, ok := nameToResource[targetComp.Resource]; ok {
-				dc.ResourceUUID = r.UUID
-			}
+	names := make([]string, len(components))
+	for i, c := range components {
+		names[i] = c.Name
+	}
+	plan.Waves, err = planWaves(cfg, names)
+	if err != nil {
+		return nil, err
+	}
 
-			plan.Components = append(plan.Components, dc)
-			added[triggerTarget] = true
+	wave := make(map[string]int, len(names))
+	for i, w := range plan.Waves {
+		for _, name := range w {
+			wave[name] = i + 1
 		}
 	}
+	for i := range components {
+		components[i].Wave = wave[components[i].Name]
+	}
+	sort.SliceStable(components, func(i, j int) bool {
+		return components[i].Wave < components[j].Wave
+	})
+	plan.Components = components
 
 	return plan, nil
 }
@@ -352,56 +415,89 @@ func (s *SmartDeployService) AutoDetect(ctx context.Context) (*models.SmartConfi
 
 // --- Execution ---
 
-// ExecuteSmartDeploy deploys all components in the plan.
+// maxConcurrentDeploys bounds how many deployments of a wave are triggered at once
+const maxConcurrentDeploys = 8
+
+// ExecuteSmartDeploy deploys all components in the plan at once, regardless of waves.
 func (s *SmartDeployService) ExecuteSmartDeploy(ctx context.Context, plan *models.SmartDeployPlan, force bool) ([]models.SmartDeployResult, []string) {
-	var results []models.SmartDeployResult
+	return s.deployComponents(ctx, plan.Components, force)
+}
+
+// ExecuteWave deploys the components of one wave of the plan (1-based) in parallel.
+// Waiting for the deployments to finish before the next wave is up to the caller.
+func (s *SmartDeployService) ExecuteWave(ctx context.Context, plan *models.SmartDeployPlan, wave int, force bool) ([]models.SmartDeployResult, []string) {
+	var components []models.SmartDeployComponent
+	for _, c := range plan.Components {
+		if c.Wave == wave {
+			components = append(components, c)
+		}
+	}
+	return s.deployComponents(ctx, components, force)
+}
+
+// deployComponents triggers a deployment of every component concurrently. Results are in
+// the order of components.
+func (s *SmartDeployService) deployComponents(ctx context.Context, components []models.SmartDeployComponent, force bool) ([]models.SmartDeployResult, []string) {
+	results := make([]models.SmartDeployResult, len(components))
+	sem := make(chan struct{}, maxConcurrentDeploys)
+	var wg sync.WaitGroup
+	for i, comp := range components {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = s.deployComponent(ctx, comp, force)
+		}()
+	}
+	wg.Wait()
+
 	var allDeploymentUUIDs []string
+	for _, r := range results {
+		allDeploymentUUIDs = append(allDeploymentUUIDs, r.DeploymentUUIDs...)
+	}
+	return results, allDeploymentUUIDs
+}
 
-	for _, comp := range plan.Components {
-		if comp.ResourceUUID == "" {
-			results = append(results, models.SmartDeployResult{
-				Name:         comp.Name,
-				ResourceName: comp.ResourceName,
-				Success:      false,
-				Error:        "resource UUID not found",
-			})
-			continue
+// deployComponent triggers the deployment of one component of the plan
+func (s *SmartDeployService) deployComponent(ctx context.Context, comp models.SmartDeployComponent, force bool) models.SmartDeployResult {
+	if comp.ResourceUUID == "" {
+		return models.SmartDeployResult{
+			Name:         comp.Name,
+			ResourceName: comp.ResourceName,
+			Success:      false,
+			Error:        "resource UUID not found",
 		}
+	}
 
-		res, err := s.deploySvc.Deploy(ctx, comp.ResourceUUID, force)
-		if err != nil {
-			results = append(results, models.SmartDeployResult{
-				Name:         comp.Name,
-				ResourceName: comp.ResourceName,
-				ResourceUUID: comp.ResourceUUID,
-				Success:      false,
-				Error:        err.Error(),
-			})
-			continue
+	res, err := s.deploySvc.Deploy(ctx, comp.ResourceUUID, force)
+	if err != nil {
+		return models.SmartDeployResult{
+			Name:         comp.Name,
+			ResourceName: comp.ResourceName,
+			ResourceUUID: comp.ResourceUUID,
+			Success:      false,
+			Error:        err.Error(),
 		}
+	}
 
-		var uuids []string
-		message := ""
-		if len(res.Deployments) > 0 {
-			message = res.Deployments[0].Message
-			for _, d := range res.Deployments {
-				if d.DeploymentUUID != "" {
-					uuids = append(uuids, d.DeploymentUUID)
-				}
+	var uuids []string
+	message := ""
+	if len(res.Deployments) > 0 {
+		message = res.Deployments[0].Message
+		for _, d := range res.Deployments {
+			if d.DeploymentUUID != "" {
+				uuids = append(uuids, d.DeploymentUUID)
 			}
 		}
-
-		allDeploymentUUIDs = append(allDeploymentUUIDs, uuids...)
-
-		results = append(results, models.SmartDeployResult{
-			Name:            comp.Name,
-			ResourceName:    comp.ResourceName,
-			ResourceUUID:    comp.ResourceUUID,
-			Success:         true,
-			Message:         message,
-			DeploymentUUIDs: uuids,
-		})
 	}
 
-	return results, allDeploymentUUIDs
+	return models.SmartDeployResult{
+		Name:            comp.Name,
+		ResourceName:    comp.ResourceName,
+		ResourceUUID:    comp.ResourceUUID,
+		Success:         true,
+		Message:         message,
+		DeploymentUUIDs: uuids,
+	}
 }
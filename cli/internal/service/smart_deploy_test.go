@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -173,6 +175,108 @@ func TestGlobMatch(t *testing.T) {
 			path:    "apps/api",
 			match:   true,
 		},
+		{
+			name:    "several * in a segment",
+			pattern: "**/*.test.*",
+			path:    "apps/web/src/button.test.tsx",
+			match:   true,
+		},
+		{
+			name:    "several * in a segment no match",
+			pattern: "**/*.test.*",
+			path:    "apps/web/src/button.tsx",
+			match:   false,
+		},
+		{
+			name:    "* does not cross segments",
+			pattern: "apps/*.go",
+			path:    "apps/api/main.go",
+			match:   false,
+		},
+		{
+			name:    "? matches one character",
+			pattern: "v?/schema.sql",
+			path:    "v2/schema.sql",
+			match:   true,
+		},
+		{
+			name:    "? does not match two characters",
+			pattern: "v?/schema.sql",
+			path:    "v10/schema.sql",
+			match:   false,
+		},
+		{
+			name:    "character class",
+			pattern: "[abc]pi/**",
+			path:    "api/main.go",
+			match:   true,
+		},
+		{
+			name:    "character range",
+			pattern: "migrations/[0-9]*.sql",
+			path:    "migrations/0042_users.sql",
+			match:   true,
+		},
+		{
+			name:    "negated class with !",
+			pattern: "[!a]pi/**",
+			path:    "api/main.go",
+			match:   false,
+		},
+		{
+			name:    "negated class with ^",
+			pattern: "[^a]pi/**",
+			path:    "xpi/main.go",
+			match:   true,
+		},
+		{
+			name:    "alternatives",
+			pattern: "apps/{api,web}/**",
+			path:    "apps/web/index.ts",
+			match:   true,
+		},
+		{
+			name:    "alternatives no match",
+			pattern: "apps/{api,web}/**",
+			path:    "apps/admin/index.ts",
+			match:   false,
+		},
+		{
+			name:    "alternatives across segments",
+			pattern: "{go.mod,cmd/**}",
+			path:    "cmd/saturn/main.go",
+			match:   true,
+		},
+		{
+			name:    "nested alternatives",
+			pattern: "**/*.{go,{ts,tsx}}",
+			path:    "web/app.tsx",
+			match:   true,
+		},
+		{
+			name:    "escaped wildcard",
+			pattern: `docs/\*.md`,
+			path:    "docs/*.md",
+			match:   true,
+		},
+		{
+			name:    "escaped wildcard is literal",
+			pattern: `docs/\*.md`,
+			path:    "docs/index.md",
+			match:   false,
+		},
+		{
+			name:    "** in the middle matching no segment",
+			pattern: "apps/**/__tests__/**",
+			path:    "apps/__tests__/api.test.ts",
+			match:   true,
+		},
+		{
+			name:    "malformed pattern matches nothing",
+			pattern: "apps/[api/**",
+			path:    "apps/[api/main.go",
+			match:   false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -183,6 +287,13 @@ func TestGlobMatch(t *testing.T) {
 	}
 }
 
+func TestValidateGlob(t *testing.T) {
+	assert.NoError(t, ValidateGlob("apps/{api,web}/**/*.[jt]s"))
+	assert.Error(t, ValidateGlob(""))
+	assert.Error(t, ValidateGlob("apps/{api,web/**"))
+	assert.Error(t, ValidateGlob("apps/[api/**"))
+}
+
 // --- LoadConfig ---
 
 func TestLoadConfig(t *testing.T) {
@@ -213,11 +324,51 @@ components:
 		assert.Equal(t, 1, cfg.Version)
 		assert.Equal(t, "develop", cfg.BaseBranch)
 		assert.Len(t, cfg.Components, 3)
-		assert.Equal(t, "apps/api/**", cfg.Components["api"].Path)
+		assert.Equal(t, models.Patterns{"apps/api/**"}, cfg.Components["api"].Path)
 		assert.Equal(t, "my-api", cfg.Components["api"].Resource)
 		assert.Equal(t, []string{"api", "web"}, cfg.Components["shared"].Triggers)
 	})
 
+	t.Run("path lists and ignore patterns", func(t *testing.T) {
+		dir := t.TempDir()
+		configContent := `version: 1
+base_branch: main
+ignore:
+  - "**/*.md"
+components:
+  api:
+    path:
+      - "apps/api/**"
+      - "go.{mod,sum}"
+    ignore: "apps/api/**/__tests__/**"
+    resource: "my-api"
+`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".saturn.yml"), []byte(configContent), 0600))
+
+		cfg, err := LoadConfig(dir)
+		require.NoError(t, err)
+		assert.Equal(t, models.Patterns{"**/*.md"}, cfg.Ignore)
+		assert.Equal(t, models.Patterns{"apps/api/**", "go.{mod,sum}"}, cfg.Components["api"].Path)
+		assert.Equal(t, models.Patterns{"apps/api/**/__tests__/**"}, cfg.Components["api"].Ignore)
+
+		// A single pattern is written back as a string
+		require.NoError(t, WriteConfig(dir, cfg))
+		data, err := os.ReadFile(filepath.Join(dir, ".saturn.yml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "ignore: apps/api/**/__tests__/**\n")
+		assert.Contains(t, string(data), "- go.{mod,sum}\n")
+	})
+
+	t.Run("malformed pattern", func(t *testing.T) {
+		dir := t.TempDir()
+		configContent := "version: 1\ncomponents:\n  api:\n    path: \"apps/{api/**\"\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".saturn.yml"), []byte(configContent), 0600))
+
+		_, err := LoadConfig(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "component api: path: unclosed {")
+	})
+
 	t.Run("missing file returns nil", func(t *testing.T) {
 		dir := t.TempDir()
 		cfg, err := LoadConfig(dir)
@@ -257,11 +408,11 @@ func TestWriteConfig(t *testing.T) {
 		BaseBranch: "main",
 		Components: map[string]models.SmartComponent{
 			"api": {
-				Path:     "apps/api/**",
+				Path:     models.Patterns{"apps/api/**"},
 				Resource: "my-api",
 			},
 			"shared": {
-				Path:     "libs/**",
+				Path:     models.Patterns{"libs/**"},
 				Triggers: []string{"api"},
 			},
 		},
@@ -277,7 +428,7 @@ func TestWriteConfig(t *testing.T) {
 
 	assert.Equal(t, 1, readCfg.Version)
 	assert.Equal(t, "main", readCfg.BaseBranch)
-	assert.Equal(t, "apps/api/**", readCfg.Components["api"].Path)
+	assert.Equal(t, models.Patterns{"apps/api/**"}, readCfg.Components["api"].Path)
 	assert.Equal(t, "my-api", readCfg.Components["api"].Resource)
 	assert.Equal(t, []string{"api"}, readCfg.Components["shared"].Triggers)
 }
@@ -302,7 +453,7 @@ func TestGenerateConfig(t *testing.T) {
 		assert.Len(t, cfg.Components, 1)
 
 		comp := cfg.Components["my-api"]
-		assert.Equal(t, "apps/api/**", comp.Path)
+		assert.Equal(t, models.Patterns{"apps/api/**"}, comp.Path)
 		assert.Equal(t, "My API", comp.Resource)
 	})
 
@@ -317,7 +468,7 @@ func TestGenerateConfig(t *testing.T) {
 
 		cfg := GenerateConfig(resources)
 		comp := cfg.Components["full-repo-app"]
-		assert.Equal(t, "**", comp.Path)
+		assert.Equal(t, models.Patterns{"**"}, comp.Path)
 	})
 
 	t.Run("nil git_repository filtered", func(t *testing.T) {
@@ -352,8 +503,8 @@ func TestBuildDeployPlan_DirectMatch(t *testing.T) {
 		Version:    1,
 		BaseBranch: "main",
 		Components: map[string]models.SmartComponent{
-			"api": {Path: "apps/api/**", Resource: "my-api"},
-			"web": {Path: "apps/web/**", Resource: "my-web"},
+			"api": {Path: models.Patterns{"apps/api/**"}, Resource: "my-api"},
+			"web": {Path: models.Patterns{"apps/web/**"}, Resource: "my-web"},
 		},
 	}
 
@@ -395,9 +546,9 @@ func TestBuildDeployPlan_TriggerChain(t *testing.T) {
 		Version:    1,
 		BaseBranch: "main",
 		Components: map[string]models.SmartComponent{
-			"api":    {Path: "apps/api/**", Resource: "my-api"},
-			"web":    {Path: "apps/web/**", Resource: "my-web"},
-			"shared": {Path: "packages/shared/**", Triggers: []string{"api", "web"}},
+			"api":    {Path: models.Patterns{"apps/api/**"}, Resource: "my-api"},
+			"web":    {Path: models.Patterns{"apps/web/**"}, Resource: "my-web"},
+			"shared": {Path: models.Patterns{"packages/shared/**"}, Triggers: []string{"api", "web"}},
 		},
 	}
 
@@ -443,7 +594,7 @@ func TestBuildDeployPlan_NoMatch(t *testing.T) {
 		Version:    1,
 		BaseBranch: "main",
 		Components: map[string]models.SmartComponent{
-			"api": {Path: "apps/api/**", Resource: "my-api"},
+			"api": {Path: models.Patterns{"apps/api/**"}, Resource: "my-api"},
 		},
 	}
 
@@ -452,6 +603,97 @@ func TestBuildDeployPlan_NoMatch(t *testing.T) {
 	assert.Empty(t, plan.Components)
 }
 
+func TestMatchComponents(t *testing.T) {
+	cfg := &models.SmartConfig{
+		Components: map[string]models.SmartComponent{
+			"api":    {Path: models.Patterns{"apps/api/**"}},
+			"web":    {Path: models.Patterns{"apps/web/**"}},
+			"shared": {Path: models.Patterns{"packages/shared/**"}},
+		},
+	}
+
+	matches := MatchComponents(cfg, []string{"apps/api/main.go", "apps/api/go.mod", "packages/shared/x.ts", "README.md"})
+	assert.Equal(t, map[string][]string{
+		"api":    {"apps/api/main.go", "apps/api/go.mod"},
+		"shared": {"packages/shared/x.ts"},
+	}, matches)
+}
+
+func TestMatchComponents_Ignore(t *testing.T) {
+	cfg := &models.SmartConfig{
+		Ignore: models.Patterns{"**/*.md"},
+		Components: map[string]models.SmartComponent{
+			"api": {Path: models.Patterns{"apps/api/**", "go.mod"}, Ignore: models.Patterns{"**/__tests__/**"}},
+			"web": {Path: models.Patterns{"apps/web/**"}},
+		},
+	}
+
+	matches := MatchComponents(cfg, []string{
+		"apps/api/README.md",
+		"apps/api/__tests__/handler.test.ts",
+		"apps/web/__tests__/page.test.ts",
+		"go.mod",
+	})
+	assert.Equal(t, map[string][]string{
+		"api": {"go.mod"},
+		"web": {"apps/web/__tests__/page.test.ts"},
+	}, matches)
+}
+
+// --- Git history ---
+
+// gitRepo creates a repository in a temporary directory, makes it the working directory
+// and returns a function committing files to it
+func gitRepo(t *testing.T) func(files map[string]string, subject string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Dev", "GIT_AUTHOR_EMAIL=dev@example.com", "GIT_COMMITTER_NAME=Dev", "GIT_COMMITTER_EMAIL=dev@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-q")
+	return func(files map[string]string, subject string) string {
+		for name, content := range files {
+			require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+			require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+			run("add", name)
+		}
+		run("commit", "-q", "-m", subject)
+		return run("rev-parse", "HEAD")
+	}
+}
+
+func TestGitHistory(t *testing.T) {
+	commit := gitRepo(t)
+	first := commit(map[string]string{"README.md": "hello"}, "Initial commit")
+	commit(map[string]string{"apps/api/main.go": "package main"}, "Add api")
+	last := commit(map[string]string{"apps/web/index.html": "<html>", "README.md": "hello again"}, "Add web")
+
+	ctx := context.Background()
+	assert.True(t, GitCommitExists(ctx, first))
+	assert.False(t, GitCommitExists(ctx, "0123456789abcdef0123456789abcdef01234567"))
+
+	commits, err := GetCommitLog(ctx, first, last)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, last, commits[0].SHA)
+	assert.Equal(t, "Add web", commits[0].Subject)
+	assert.Equal(t, "Dev", commits[0].Author)
+	assert.Equal(t, "Add api", commits[1].Subject)
+
+	files, err := GetChangedFilesBetween(ctx, first, last)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "apps/api/main.go", "apps/web/index.html"}, files)
+}
+
 // --- AutoDetect ---
 
 func TestAutoDetect(t *testing.T) {
@@ -480,9 +722,9 @@ func TestAutoDetect(t *testing.T) {
 
 	assert.Len(t, cfg.Components, 2)
 	assert.Equal(t, "api", cfg.Components["api"].Resource)
-	assert.Equal(t, "apps/api/**", cfg.Components["api"].Path)
+	assert.Equal(t, models.Patterns{"apps/api/**"}, cfg.Components["api"].Path)
 	assert.Equal(t, "web", cfg.Components["web"].Resource)
-	assert.Equal(t, "**", cfg.Components["web"].Path)
+	assert.Equal(t, models.Patterns{"**"}, cfg.Components["web"].Path)
 }
 
 // --- ExecuteSmartDeploy ---
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// ValidateGraph checks that the triggers and depends_on of every component name other
+// components, and that neither forms a cycle
+func ValidateGraph(cfg *models.SmartConfig) error {
+	names := componentNames(cfg)
+	for _, name := range names {
+		comp := cfg.Components[name]
+		for _, target := range comp.Triggers {
+			if _, ok := cfg.Components[target]; !ok {
+				return fmt.Errorf("component %s triggers unknown component %s", name, target)
+			}
+		}
+		for _, dep := range comp.DependsOn {
+			if _, ok := cfg.Components[dep]; !ok {
+				return fmt.Errorf("component %s depends on unknown component %s", name, dep)
+			}
+		}
+	}
+
+	triggers := func(name string) []string { return cfg.Components[name].Triggers }
+	if cycle := findCycle(names, triggers); cycle != nil {
+		return fmt.Errorf("trigger cycle: %s", strings.Join(cycle, " -> "))
+	}
+	dependsOn := func(name string) []string { return cfg.Components[name].DependsOn }
+	if cycle := findCycle(names, dependsOn); cycle != nil {
+		return fmt.Errorf("depends_on cycle: %s", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
+
+// componentNames returns the component names of cfg in sorted order
+func componentNames(cfg *models.SmartConfig) []string {
+	names := make([]string, 0, len(cfg.Components))
+	for name := range cfg.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// findCycle returns the first cycle among the edges of names, starting and ending with
+// the same component, or nil when there is none
+func findCycle(names []string, edges func(string) []string) []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(names))
+	var stack []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, next := range edges(name) {
+			switch state[next] {
+			case visiting:
+				for i, n := range stack {
+					if n == next {
+						return append(append([]string{}, stack[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, name := range names {
+		if state[name] == unvisited {
+			if cycle := visit(name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// triggeredComponent is a component deployed because another one changed
+type triggeredComponent struct {
+	name string
+	by   string
+}
+
+// resolveTriggers follows triggers transitively from the directly changed components,
+// breadth first so that every component is attributed to its closest trigger
+func resolveTriggers(cfg *models.SmartConfig, direct []string) []triggeredComponent {
+	seen := make(map[string]bool, len(direct))
+	for _, name := range direct {
+		seen[name] = true
+	}
+
+	var triggered []triggeredComponent
+	queue := append([]string{}, direct...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, target := range cfg.Components[name].Triggers {
+			if seen[target] {
+				continue
+			}
+			if _, ok := cfg.Components[target]; !ok {
+				continue
+			}
+			seen[target] = true
+			triggered = append(triggered, triggeredComponent{name: target, by: name})
+			queue = append(queue, target)
+		}
+	}
+	return triggered
+}
+
+// planWaves orders the components in names into waves: every component comes in a later
+// wave than the components it depends on. Dependencies that are not being deployed are
+// already in place and do not count. Components within a wave are sorted by name.
+func planWaves(cfg *models.SmartConfig, names []string) ([][]string, error) {
+	inPlan := make(map[string]bool, len(names))
+	for _, name := range names {
+		inPlan[name] = true
+	}
+
+	remaining := make(map[string]int, len(names)) // component -> dependencies not yet deployed
+	dependents := make(map[string][]string)
+	for _, name := range names {
+		for _, dep := range cfg.Components[name].DependsOn {
+			if inPlan[dep] && dep != name {
+				remaining[name]++
+				dependents[dep] = append(dependents[dep], name)
+			}
+		}
+	}
+
+	var current []string
+	for _, name := range names {
+		if remaining[name] == 0 {
+			current = append(current, name)
+		}
+	}
+
+	var waves [][]string
+	placed := 0
+	for len(current) > 0 {
+		sort.Strings(current)
+		waves = append(waves, current)
+		placed += len(current)
+
+		var next []string
+		for _, name := range current {
+			for _, dependent := range dependents[name] {
+				remaining[dependent]--
+				if remaining[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		current = next
+	}
+
+	if placed < len(names) {
+		var stuck []string
+		for _, name := range names {
+			if remaining[name] > 0 {
+				stuck = append(stuck, name)
+			}
+		}
+		sort.Strings(stuck)
+		return nil, fmt.Errorf("depends_on cycle among %s", strings.Join(stuck, ", "))
+	}
+	return waves, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+func TestValidateGraph(t *testing.T) {
+	tests := []struct {
+		name       string
+		components map[string]models.SmartComponent
+		err        string
+	}{
+		{
+			name: "valid",
+			components: map[string]models.SmartComponent{
+				"shared": {Triggers: []string{"lib"}},
+				"lib":    {Triggers: []string{"api"}},
+				"api":    {DependsOn: []string{"lib"}},
+			},
+		},
+		{
+			name:       "unknown trigger",
+			components: map[string]models.SmartComponent{"shared": {Triggers: []string{"ap"}}},
+			err:        "component shared triggers unknown component ap",
+		},
+		{
+			name:       "unknown dependency",
+			components: map[string]models.SmartComponent{"api": {DependsOn: []string{"db"}}},
+			err:        "component api depends on unknown component db",
+		},
+		{
+			name: "trigger cycle",
+			components: map[string]models.SmartComponent{
+				"api":    {Triggers: []string{"web"}},
+				"shared": {Triggers: []string{"api"}},
+				"web":    {Triggers: []string{"shared"}},
+			},
+			err: "trigger cycle: api -> web -> shared -> api",
+		},
+		{
+			name:       "component triggering itself",
+			components: map[string]models.SmartComponent{"api": {Triggers: []string{"api"}}},
+			err:        "trigger cycle: api -> api",
+		},
+		{
+			name: "depends_on cycle",
+			components: map[string]models.SmartComponent{
+				"api":    {DependsOn: []string{"worker"}},
+				"worker": {DependsOn: []string{"api"}},
+			},
+			err: "depends_on cycle: api -> worker -> api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGraph(&models.SmartConfig{Components: tt.components})
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.err, err.Error())
+		})
+	}
+}
+
+func resourceServer(t *testing.T, resources []models.Resource) *SmartDeployService {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resources)
+	}))
+	t.Cleanup(server.Close)
+	return NewSmartDeployService(api.NewClient(server.URL, "test-token"))
+}
+
+func TestBuildDeployPlan_TransitiveTriggers(t *testing.T) {
+	svc := resourceServer(t, []models.Resource{
+		{UUID: "uuid-lib", Name: "my-lib"},
+		{UUID: "uuid-api", Name: "my-api"},
+		{UUID: "uuid-web", Name: "my-web"},
+		{UUID: "uuid-worker", Name: "my-worker"},
+	})
+
+	cfg := &models.SmartConfig{
+		Components: map[string]models.SmartComponent{
+			"shared": {Path: models.Patterns{"packages/shared/**"}, Triggers: []string{"lib"}},
+			"lib":    {Path: models.Patterns{"packages/lib/**"}, Resource: "my-lib", Triggers: []string{"api", "web", "worker"}},
+			"api":    {Path: models.Patterns{"apps/api/**"}, Resource: "my-api", DependsOn: []string{"lib"}},
+			"web":    {Path: models.Patterns{"apps/web/**"}, Resource: "my-web", DependsOn: []string{"api"}},
+			"worker": {Path: models.Patterns{"apps/worker/**"}, Resource: "my-worker", DependsOn: []string{"lib"}},
+		},
+	}
+
+	plan, err := svc.BuildDeployPlan(context.Background(), []string{"packages/shared/index.ts"}, cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"lib", "shared"}, {"api", "worker"}, {"web"}}, plan.Waves)
+
+	byName := make(map[string]models.SmartDeployComponent)
+	for _, c := range plan.Components {
+		byName[c.Name] = c
+	}
+	require.Len(t, byName, 5)
+	assert.Equal(t, "direct", byName["shared"].Reason)
+	assert.Equal(t, "shared", byName["lib"].TriggerBy)
+	assert.Equal(t, "lib", byName["api"].TriggerBy)
+	assert.Equal(t, "lib", byName["web"].TriggerBy)
+	assert.Equal(t, "lib", byName["worker"].TriggerBy)
+	assert.Equal(t, "uuid-worker", byName["worker"].ResourceUUID)
+	assert.Equal(t, 3, byName["web"].Wave)
+
+	// Components are listed wave by wave
+	for i := 1; i < len(plan.Components); i++ {
+		assert.LessOrEqual(t, plan.Components[i-1].Wave, plan.Components[i].Wave)
+	}
+}
+
+func TestBuildDeployPlan_DependencyNotDeployed(t *testing.T) {
+	svc := resourceServer(t, nil)
+	cfg := &models.SmartConfig{
+		Components: map[string]models.SmartComponent{
+			"db":  {Path: models.Patterns{"db/**"}},
+			"api": {Path: models.Patterns{"apps/api/**"}, DependsOn: []string{"db"}},
+		},
+	}
+
+	// db did not change, so api does not wait for it
+	plan, err := svc.BuildDeployPlan(context.Background(), []string{"apps/api/main.go"}, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"api"}}, plan.Waves)
+	assert.Equal(t, 1, plan.Components[0].Wave)
+}
+
+func TestBuildDeployPlan_Cycle(t *testing.T) {
+	svc := resourceServer(t, nil)
+	cfg := &models.SmartConfig{
+		Components: map[string]models.SmartComponent{
+			"api": {Path: models.Patterns{"apps/api/**"}, Triggers: []string{"web"}},
+			"web": {Path: models.Patterns{"apps/web/**"}, Triggers: []string{"api"}},
+		},
+	}
+
+	_, err := svc.BuildDeployPlan(context.Background(), []string{"apps/api/main.go"}, cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trigger cycle: api -> web -> api")
+}
+
+func TestExecuteWave(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		uuid := r.URL.Query().Get("uuid")
+		_ = json.NewEncoder(w).Encode(DeployResponse{
+			Deployments: []DeploymentInfo{{Message: "Deployment started", ResourceUUID: uuid, DeploymentUUID: "dep-" + uuid}},
+		})
+	}))
+	defer server.Close()
+
+	svc := NewSmartDeployService(api.NewClient(server.URL, "test-token"))
+	plan := &models.SmartDeployPlan{
+		Components: []models.SmartDeployComponent{
+			{Name: "lib", ResourceUUID: "lib", Wave: 1},
+			{Name: "api", ResourceUUID: "api", Wave: 2},
+			{Name: "worker", ResourceUUID: "worker", Wave: 2},
+		},
+		Waves: [][]string{{"lib"}, {"api", "worker"}},
+	}
+
+	results, uuids := svc.ExecuteWave(context.Background(), plan, 2, false)
+	require.Len(t, results, 2)
+	assert.Equal(t, "api", results[0].Name)
+	assert.Equal(t, "worker", results[1].Name)
+	assert.Equal(t, []string{"dep-api", "dep-worker"}, uuids)
+}
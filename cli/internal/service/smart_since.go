@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// What the changed files of a smart deploy are compared against (--since)
+const (
+	// SinceMergeBase compares against the commit the branch forked from the base branch
+	SinceMergeBase = "merge-base"
+	// SinceLastDeploy compares every component against the commit of its resource's
+	// latest finished deployment
+	SinceLastDeploy = "last-deploy"
+)
+
+// shallowFetchDepth is how much history is fetched for a base branch missing from a
+// shallow clone, and deepenSteps how far the history is then deepened, in turn, until
+// the merge-base turns up
+const shallowFetchDepth = 50
+
+var deepenSteps = []int{100, 1000}
+
+// ChangeOptions select the changes a smart deploy considers
+type ChangeOptions struct {
+	Since string
+	// Base is the base branch, for SinceMergeBase and the components without a
+	// finished deployment under SinceLastDeploy
+	Base string
+	// IncludeUncommitted compares against the working tree, untracked files included,
+	// instead of HEAD
+	IncludeUncommitted bool
+	// OnFetch is called before history missing from the local clone is fetched
+	OnFetch func(what string)
+}
+
+// ChangeSet is the files changed since a commit. They concern the listed components only,
+// or every component when Components is nil.
+type ChangeSet struct {
+	Commit     string
+	Label      string
+	Files      []string
+	Components []string
+}
+
+// ParseSince validates a --since value
+func ParseSince(since string) error {
+	if since != SinceMergeBase && since != SinceLastDeploy {
+		return fmt.Errorf("invalid --since %q: expected %s or %s", since, SinceMergeBase, SinceLastDeploy)
+	}
+	return nil
+}
+
+// GetChanges returns the files changed in the local repository, as selected by opts
+func (s *SmartDeployService) GetChanges(ctx context.Context, cfg *models.SmartConfig, opts ChangeOptions) ([]ChangeSet, error) {
+	if err := ParseSince(opts.Since); err != nil {
+		return nil, err
+	}
+	if opts.Since == SinceMergeBase {
+		set, err := mergeBaseChanges(ctx, opts, nil)
+		if err != nil {
+			return nil, err
+		}
+		return []ChangeSet{set}, nil
+	}
+
+	commits, err := s.LastDeployedCommits(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	byCommit := make(map[string][]string)
+	var undeployed []string
+	for _, name := range componentNames(cfg) {
+		if commit, ok := commits[name]; ok {
+			byCommit[commit] = append(byCommit[commit], name)
+		} else {
+			undeployed = append(undeployed, name)
+		}
+	}
+
+	var sets []ChangeSet
+	for commit, names := range byCommit {
+		if err := ensureCommit(ctx, commit, opts.OnFetch); err != nil {
+			return nil, err
+		}
+		files, err := GetChangedFilesSince(ctx, commit, opts.IncludeUncommitted)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, ChangeSet{
+			Commit:     commit,
+			Label:      fmt.Sprintf("last deploy %s of %s", models.ShortSHA(commit), strings.Join(names, ", ")),
+			Files:      files,
+			Components: names,
+		})
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i].Label < sets[j].Label })
+
+	// Components that were never deployed fall back to the merge-base
+	if len(undeployed) > 0 {
+		set, err := mergeBaseChanges(ctx, opts, undeployed)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// LastDeployedCommits returns, per component, the commit of the latest finished deployment
+// of its resource. Components without an application resource, or whose application has
+// no finished deployment with a commit SHA, are left out.
+func (s *SmartDeployService) LastDeployedCommits(ctx context.Context, cfg *models.SmartConfig) (map[string]string, error) {
+	resources, err := s.resourceSvc.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+	nameToResource := make(map[string]models.Resource, len(resources))
+	for _, r := range resources {
+		nameToResource[r.Name] = r
+	}
+
+	commits := make(map[string]string)
+	for _, name := range componentNames(cfg) {
+		r, ok := nameToResource[cfg.Components[name].Resource]
+		if !ok || r.Type != models.ResourceTypeApplication {
+			continue
+		}
+		d, err := s.deploySvc.LatestFinished(ctx, r.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get last deployment of %s: %w", r.Name, err)
+		}
+		if d != nil && d.Commit != nil && CommitSHAPattern.MatchString(*d.Commit) {
+			commits[name] = *d.Commit
+		}
+	}
+	return commits, nil
+}
+
+// mergeBaseChanges returns the files changed since HEAD forked from the base branch
+func mergeBaseChanges(ctx context.Context, opts ChangeOptions, components []string) (ChangeSet, error) {
+	ref, err := ResolveBaseRef(ctx, opts.Base, opts.OnFetch)
+	if err != nil {
+		return ChangeSet{}, err
+	}
+	commit, err := MergeBase(ctx, ref, opts.OnFetch)
+	if err != nil {
+		return ChangeSet{}, err
+	}
+	files, err := GetChangedFilesSince(ctx, commit, opts.IncludeUncommitted)
+	if err != nil {
+		return ChangeSet{}, err
+	}
+	return ChangeSet{
+		Commit:     commit,
+		Label:      fmt.Sprintf("merge-base %s with %s", models.ShortSHA(commit), opts.Base),
+		Files:      files,
+		Components: components,
+	}, nil
+}
+
+// --- Git operations ---
+
+// git runs a git command and returns its trimmed output, with git's own message in the error
+func git(ctx context.Context, args ...string) (string, error) {
+	// #nosec G204 - refs and commits are passed as arguments, never through a shell
+	cmd := exec.CommandContext(ctx, "git", args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s failed: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// gitRefExists reports whether ref names a commit in the local repository
+func gitRefExists(ctx context.Context, ref string) bool {
+	_, err := git(ctx, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return err == nil
+}
+
+// isShallowClone reports whether the local repository is a shallow clone
+func isShallowClone(ctx context.Context) bool {
+	out, err := git(ctx, "rev-parse", "--is-shallow-repository")
+	return err == nil && out == "true"
+}
+
+// ResolveBaseRef returns a local ref for the base branch, preferring the branch itself,
+// then its origin remote-tracking branch. When neither exists, as in the single-branch
+// clones CI checkouts make, the branch is fetched from origin.
+func ResolveBaseRef(ctx context.Context, base string, onFetch func(string)) (string, error) {
+	if gitRefExists(ctx, base) {
+		return base, nil
+	}
+	branch := strings.TrimPrefix(base, "origin/")
+	remote := "origin/" + branch
+	if gitRefExists(ctx, remote) {
+		return remote, nil
+	}
+
+	if onFetch != nil {
+		onFetch(remote)
+	}
+	args := []string{"fetch", "--no-tags"}
+	if isShallowClone(ctx) {
+		args = append(args, fmt.Sprintf("--depth=%d", shallowFetchDepth))
+	}
+	args = append(args, "origin", baseRefspec(branch))
+	if _, err := git(ctx, args...); err != nil {
+		return "", fmt.Errorf("base branch %s not found locally and could not be fetched: %w", base, err)
+	}
+	return remote, nil
+}
+
+// MergeBase returns the commit HEAD forked from ref. In a shallow clone whose history
+// does not reach it, the history is deepened from origin until it does.
+func MergeBase(ctx context.Context, ref string, onFetch func(string)) (string, error) {
+	commit, err := git(ctx, "merge-base", ref, "HEAD")
+	if err == nil {
+		return commit, nil
+	}
+	if !isShallowClone(ctx) {
+		return "", fmt.Errorf("no merge-base between %s and HEAD: %w", ref, err)
+	}
+
+	refspec := baseRefspec(strings.TrimPrefix(ref, "origin/"))
+	fetches := make([][]string, 0, len(deepenSteps)+1)
+	for _, n := range deepenSteps {
+		fetches = append(fetches, []string{"fetch", "--no-tags", fmt.Sprintf("--deepen=%d", n), "origin", refspec})
+	}
+	fetches = append(fetches, []string{"fetch", "--no-tags", "--unshallow", "origin", refspec})
+
+	for _, args := range fetches {
+		if onFetch != nil {
+			onFetch(fmt.Sprintf("more history of %s (%s)", ref, args[2]))
+		}
+		if _, err := git(ctx, args...); err != nil {
+			return "", fmt.Errorf("failed to deepen shallow clone to find the merge-base with %s: %w", ref, err)
+		}
+		if commit, err := git(ctx, "merge-base", ref, "HEAD"); err == nil {
+			return commit, nil
+		}
+		if !isShallowClone(ctx) {
+			break
+		}
+	}
+	return "", fmt.Errorf("no merge-base between %s and HEAD", ref)
+}
+
+// ensureCommit fetches a commit from origin when the local repository lacks it. Diffing
+// against a commit needs only its tree, so a shallow clone fetches just that commit.
+func ensureCommit(ctx context.Context, commit string, onFetch func(string)) error {
+	if gitRefExists(ctx, commit) {
+		return nil
+	}
+	if onFetch != nil {
+		onFetch("commit " + models.ShortSHA(commit))
+	}
+	args := []string{"fetch", "--no-tags"}
+	if isShallowClone(ctx) {
+		args = append(args, "--depth=1")
+	}
+	if _, err := git(ctx, append(args, "origin", commit)...); err != nil {
+		return fmt.Errorf("commit %s is not in the local repository and could not be fetched: %w", models.ShortSHA(commit), err)
+	}
+	return nil
+}
+
+// GetChangedFilesSince returns the files that differ between commit and HEAD or, with
+// uncommitted, the working tree, staged, unstaged and untracked files included
+func GetChangedFilesSince(ctx context.Context, commit string, uncommitted bool) ([]string, error) {
+	args := []string{"diff", "--name-only", commit}
+	if !uncommitted {
+		args = append(args, "HEAD")
+	}
+	out, err := git(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	files := splitLines(out)
+	if !uncommitted {
+		return files, nil
+	}
+
+	untracked, err := git(ctx, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, splitLines(untracked)...)
+	sort.Strings(files)
+	return files, nil
+}
+
+// baseRefspec fetches a branch into its origin remote-tracking branch
+func baseRefspec(branch string) string {
+	return "+refs/heads/" + branch + ":refs/remotes/origin/" + branch
+}
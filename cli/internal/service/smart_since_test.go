@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/api"
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// runGit runs git in dir and returns its trimmed output
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Dev", "GIT_AUTHOR_EMAIL=dev@example.com", "GIT_COMMITTER_NAME=Dev", "GIT_COMMITTER_EMAIL=dev@example.com")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// commitFile writes a file in the repository at dir and commits it
+func commitFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-q", "-m", "Update "+name)
+	return runGit(t, dir, "rev-parse", "HEAD")
+}
+
+// branchedRepo creates a repository whose feature branch forked from main, after which
+// main moved on, and returns its directory and the fork point
+func branchedRepo(t *testing.T) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	fork := commitFile(t, dir, "README.md", "hello")
+	runGit(t, dir, "branch", "-M", "main")
+
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+	commitFile(t, dir, "apps/api/main.go", "package main")
+
+	runGit(t, dir, "checkout", "-q", "main")
+	commitFile(t, dir, "apps/web/index.html", "<html>")
+	runGit(t, dir, "checkout", "-q", "feature")
+	return dir, fork
+}
+
+func TestGetChanges_MergeBase(t *testing.T) {
+	dir, fork := branchedRepo(t)
+	t.Chdir(dir)
+	svc := NewSmartDeployService(api.NewClient("http://unused", "test-token"))
+
+	changes, err := svc.GetChanges(context.Background(), &models.SmartConfig{}, ChangeOptions{Since: SinceMergeBase, Base: "main"})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	// The web change made on main after the fork is not part of the branch
+	assert.Equal(t, []string{"apps/api/main.go"}, changes[0].Files)
+	assert.Equal(t, fork, changes[0].Commit)
+	assert.Nil(t, changes[0].Components)
+	assert.Equal(t, "merge-base "+fork[:7]+" with main", changes[0].Label)
+}
+
+func TestGetChanges_IncludeUncommitted(t *testing.T) {
+	dir, _ := branchedRepo(t)
+	t.Chdir(dir)
+	require.NoError(t, os.WriteFile("README.md", []byte("changed"), 0o600))
+	require.NoError(t, os.MkdirAll("apps/worker", 0o755))
+	require.NoError(t, os.WriteFile("apps/worker/main.go", []byte("package main"), 0o600))
+	svc := NewSmartDeployService(api.NewClient("http://unused", "test-token"))
+
+	changes, err := svc.GetChanges(context.Background(), &models.SmartConfig{}, ChangeOptions{Since: SinceMergeBase, Base: "main"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apps/api/main.go"}, changes[0].Files)
+
+	changes, err = svc.GetChanges(context.Background(), &models.SmartConfig{}, ChangeOptions{Since: SinceMergeBase, Base: "main", IncludeUncommitted: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "apps/api/main.go", "apps/worker/main.go"}, changes[0].Files)
+}
+
+func TestMergeBase_ShallowClone(t *testing.T) {
+	origin, fork := branchedRepo(t)
+	// Push the fork point past the depth a missing base branch is fetched with
+	runGit(t, origin, "checkout", "-q", "main")
+	for i := 0; i < shallowFetchDepth+5; i++ {
+		runGit(t, origin, "commit", "-q", "--allow-empty", "-m", fmt.Sprintf("Commit %d", i))
+	}
+	runGit(t, origin, "checkout", "-q", "feature")
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	runGit(t, origin, "clone", "-q", "--depth=1", "--single-branch", "--branch=feature", "file://"+origin, clone)
+	t.Chdir(clone)
+	ctx := context.Background()
+
+	var fetched []string
+	onFetch := func(what string) { fetched = append(fetched, what) }
+
+	ref, err := ResolveBaseRef(ctx, "main", onFetch)
+	require.NoError(t, err)
+	assert.Equal(t, "origin/main", ref)
+
+	commit, err := MergeBase(ctx, ref, onFetch)
+	require.NoError(t, err)
+	assert.Equal(t, fork, commit)
+	assert.Equal(t, []string{"origin/main", "more history of origin/main (--deepen=100)"}, fetched)
+
+	files, err := GetChangedFilesSince(ctx, commit, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apps/api/main.go"}, files)
+}
+
+func TestResolveBaseRef_Missing(t *testing.T) {
+	dir, _ := branchedRepo(t)
+	t.Chdir(dir)
+
+	_, err := ResolveBaseRef(context.Background(), "develop", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "base branch develop not found locally and could not be fetched")
+}
+
+func TestGetChanges_LastDeploy(t *testing.T) {
+	dir, fork := branchedRepo(t)
+	t.Chdir(dir)
+	apiCommit := runGit(t, dir, "rev-parse", "HEAD")
+	commitFile(t, dir, "packages/shared/index.ts", "export {}")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/resources":
+			_ = json.NewEncoder(w).Encode([]models.Resource{
+				{UUID: "uuid-api", Name: "my-api", Type: models.ResourceTypeApplication},
+				{UUID: "uuid-web", Name: "my-web", Type: models.ResourceTypeApplication},
+				{UUID: "uuid-db", Name: "my-db", Type: "postgresql"},
+			})
+		case "/api/v1/deployments/applications/uuid-api":
+			_ = json.NewEncoder(w).Encode(DeploymentsListResponse{Deployments: []models.Deployment{
+				{ID: 2, UUID: "dep-2", Status: "failed", Commit: &fork},
+				{ID: 1, UUID: "dep-1", Status: "finished", Commit: &apiCommit},
+			}})
+		case "/api/v1/deployments/applications/uuid-web":
+			_ = json.NewEncoder(w).Encode(DeploymentsListResponse{})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	svc := NewSmartDeployService(api.NewClient(server.URL, "test-token"))
+
+	cfg := &models.SmartConfig{
+		Components: map[string]models.SmartComponent{
+			"api":    {Path: models.Patterns{"apps/api/**"}, Resource: "my-api"},
+			"web":    {Path: models.Patterns{"apps/web/**"}, Resource: "my-web"},
+			"db":     {Path: models.Patterns{"db/**"}, Resource: "my-db"},
+			"shared": {Path: models.Patterns{"packages/shared/**"}, Triggers: []string{"api"}},
+		},
+	}
+
+	commits, err := svc.LastDeployedCommits(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"api": apiCommit}, commits)
+
+	changes, err := svc.GetChanges(context.Background(), cfg, ChangeOptions{Since: SinceLastDeploy, Base: "main"})
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	assert.Equal(t, apiCommit, changes[0].Commit)
+	assert.Equal(t, []string{"api"}, changes[0].Components)
+	assert.Equal(t, []string{"packages/shared/index.ts"}, changes[0].Files)
+	assert.Equal(t, "last deploy "+apiCommit[:7]+" of api", changes[0].Label)
+
+	// Components never deployed fall back to the merge-base
+	assert.Equal(t, fork, changes[1].Commit)
+	assert.Equal(t, []string{"db", "shared", "web"}, changes[1].Components)
+	assert.Equal(t, []string{"apps/api/main.go", "packages/shared/index.ts"}, changes[1].Files)
+
+	plan, err := svc.BuildDeployPlanFromChanges(context.Background(), changes, cfg)
+	require.NoError(t, err)
+	require.Len(t, plan.Components, 2)
+	// api's own change is already deployed, but shared changed since
+	assert.Equal(t, "api", plan.Components[0].Name)
+	assert.Equal(t, "triggered", plan.Components[0].Reason)
+	assert.Equal(t, apiCommit, plan.Components[0].Since)
+	assert.Equal(t, "shared", plan.Components[1].Name)
+	assert.Equal(t, fork, plan.Components[1].Since)
+	assert.Equal(t, 2, plan.FilesTotal)
+}
+
+func TestParseSince(t *testing.T) {
+	assert.NoError(t, ParseSince(SinceMergeBase))
+	assert.NoError(t, ParseSince(SinceLastDeploy))
+	assert.EqualError(t, ParseSince("yesterday"), `invalid --since "yesterday": expected merge-base or last-deploy`)
+}
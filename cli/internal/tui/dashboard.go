@@ -0,0 +1,497 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+// Action is an operation the dashboard can run on the selected resource
+type Action string
+
+// Actions bound to keys in the dashboard
+const (
+	ActionDeploy   Action = "deploy"
+	ActionRestart  Action = "restart"
+	ActionStop     Action = "stop"
+	ActionRollback Action = "rollback"
+)
+
+// actionKeys maps key bindings to actions
+var actionKeys = map[rune]Action{
+	'd': ActionDeploy,
+	'r': ActionRestart,
+	's': ActionStop,
+	'b': ActionRollback,
+}
+
+// Backend provides the data and operations behind the dashboard
+type Backend interface {
+	Projects(ctx context.Context) ([]models.Project, error)
+	Project(ctx context.Context, uuid string) (*models.Project, error)
+	Environment(ctx context.Context, projectUUID, environmentUUID string) (*models.EnvironmentResources, error)
+	Deployments(ctx context.Context) ([]models.Deployment, error)
+	Logs(ctx context.Context, resource models.EnvironmentResource) (string, error)
+	Run(ctx context.Context, action Action, resource models.EnvironmentResource) (string, error)
+}
+
+type projectNode struct {
+	project  models.Project
+	expanded bool
+	loaded   bool
+	loading  bool
+	envs     []*environmentNode
+}
+
+type environmentNode struct {
+	project   *projectNode
+	env       models.Environment
+	expanded  bool
+	loaded    bool
+	loading   bool
+	resources []models.EnvironmentResource
+}
+
+// treeRow is one visible line of the project tree
+type treeRow struct {
+	depth    int
+	project  *projectNode
+	env      *environmentNode
+	resource *models.EnvironmentResource
+}
+
+// update applies the result of a backend call to the dashboard. It runs on the
+// key loop, so it is the only place background results touch dashboard state.
+type update func(d *Dashboard)
+
+// task is a backend call. It must not touch dashboard state; it returns the update
+// to apply once it is done.
+type task func(ctx context.Context) update
+
+// Dashboard holds the state of the terminal dashboard. It is driven by
+// HandleKey and Refresh and rendered with View; it does no terminal I/O itself.
+// Backend calls run inline unless Run hands them to goroutines, so a slow API
+// never blocks key handling.
+type Dashboard struct {
+	backend Backend
+	title   string
+	spawn   func(ctx context.Context, t task)
+	busy    int
+
+	projects       []*projectNode
+	projectsLoaded bool
+	cursor         int
+	deployments    []models.Deployment
+	refreshing     bool
+	logTarget      *models.EnvironmentResource
+	logLines       []string
+	logScroll      int
+
+	pending         *Action
+	pendingResource models.EnvironmentResource
+	status          string
+	isError         bool
+	quit            bool
+	updated         time.Time
+	now             func() time.Time
+}
+
+// NewDashboard creates a dashboard backed by backend. title is shown in the header.
+func NewDashboard(backend Backend, title string) *Dashboard {
+	return &Dashboard{
+		backend: backend,
+		title:   title,
+		now:     time.Now,
+	}
+}
+
+// Quit reports whether the user asked to leave the dashboard
+func (d *Dashboard) Quit() bool {
+	return d.quit
+}
+
+// Busy reports whether backend calls are still running
+func (d *Dashboard) Busy() bool {
+	return d.busy > 0
+}
+
+// Load fetches the project list, then refreshes the live data
+func (d *Dashboard) Load(ctx context.Context) {
+	backend := d.backend
+	d.start(ctx, func(ctx context.Context) update {
+		projects, err := backend.Projects(ctx)
+		return func(d *Dashboard) {
+			if err != nil {
+				d.setError(fmt.Sprintf("failed to load projects: %v (press R to retry)", err))
+				return
+			}
+			d.projects = make([]*projectNode, len(projects))
+			for i, p := range projects {
+				d.projects[i] = &projectNode{project: p}
+			}
+			d.projectsLoaded = true
+			d.Refresh(ctx)
+		}
+	})
+}
+
+// Refresh reloads live data: deployments, expanded environments and the log pane.
+// It does nothing while a previous refresh is still running.
+func (d *Dashboard) Refresh(ctx context.Context) {
+	d.refresh(ctx, "")
+}
+
+// refresh reloads live data and shows done in the status line once it is loaded
+func (d *Dashboard) refresh(ctx context.Context, done string) {
+	if d.refreshing {
+		return
+	}
+	d.refreshing = true
+
+	var envs []*environmentNode
+	for _, p := range d.projects {
+		for _, e := range p.envs {
+			if e.expanded {
+				envs = append(envs, e)
+			}
+		}
+	}
+	var logTarget *models.EnvironmentResource
+	if d.logTarget != nil {
+		target := *d.logTarget
+		logTarget = &target
+	}
+
+	backend := d.backend
+	d.start(ctx, func(ctx context.Context) update {
+		deployments, err := backend.Deployments(ctx)
+		updates := make([]update, 0, len(envs)+1)
+		for _, e := range envs {
+			updates = append(updates, fetchEnvironment(ctx, backend, e))
+		}
+		if logTarget != nil {
+			updates = append(updates, fetchLogs(ctx, backend, *logTarget))
+		}
+
+		return func(d *Dashboard) {
+			d.refreshing = false
+			if err != nil {
+				d.setError(fmt.Sprintf("failed to load deployments: %v", err))
+			} else {
+				d.deployments = deployments
+			}
+			for _, u := range updates {
+				u(d)
+			}
+			d.updated = d.now()
+			if done != "" && !d.isError {
+				d.setStatus(done)
+			}
+		}
+	})
+}
+
+// start runs t, inline or through spawn, and applies its update when it is done
+func (d *Dashboard) start(ctx context.Context, t task) {
+	d.busy++
+	tracked := func(ctx context.Context) update {
+		apply := t(ctx)
+		return func(d *Dashboard) {
+			d.busy--
+			apply(d)
+		}
+	}
+	if d.spawn == nil {
+		tracked(ctx)(d)
+		return
+	}
+	d.spawn(ctx, tracked)
+}
+
+// HandleKey applies a key press to the dashboard
+func (d *Dashboard) HandleKey(ctx context.Context, key Key) {
+	if d.pending != nil {
+		d.handleConfirm(ctx, key)
+		return
+	}
+
+	rows := d.rows()
+	switch key.Code {
+	case KeyCtrlC:
+		d.quit = true
+	case KeyUp:
+		d.move(-1, len(rows))
+	case KeyDown:
+		d.move(1, len(rows))
+	case KeyPageUp:
+		d.logScroll += 10
+	case KeyPageDown:
+		d.logScroll = max(0, d.logScroll-10)
+	case KeyRight, KeyEnter:
+		d.open(ctx, rows)
+	case KeyLeft:
+		d.collapse(rows)
+	case KeyRune:
+		d.handleRune(ctx, key.Rune, rows)
+	}
+}
+
+func (d *Dashboard) handleRune(ctx context.Context, r rune, rows []treeRow) {
+	switch r {
+	case 'q':
+		d.quit = true
+	case 'k':
+		d.move(-1, len(rows))
+	case 'j':
+		d.move(1, len(rows))
+	case 'l':
+		d.open(ctx, rows)
+	case 'h':
+		d.collapse(rows)
+	case 'R':
+		if !d.projectsLoaded {
+			d.setStatus("Loading projects…")
+			d.Load(ctx)
+			return
+		}
+		d.setStatus("Refreshing…")
+		d.refresh(ctx, "Refreshed")
+	default:
+		action, ok := actionKeys[r]
+		if !ok {
+			return
+		}
+		res := d.selectedResource(rows)
+		if res == nil {
+			d.setError("Select a resource first")
+			return
+		}
+		if (action == ActionDeploy || action == ActionRollback) && res.Type != models.ResourceTypeApplication {
+			d.setError(fmt.Sprintf("%s is only available for applications", action))
+			return
+		}
+		d.pending = &action
+		d.pendingResource = *res
+		d.setStatus(fmt.Sprintf("%s %s? [y/N]", capitalize(string(action)), res.Name))
+	}
+}
+
+// handleConfirm runs the pending action on "y" and cancels it on any other key
+func (d *Dashboard) handleConfirm(ctx context.Context, key Key) {
+	action, res := *d.pending, d.pendingResource
+	d.pending = nil
+
+	if key.Code != KeyRune || (key.Rune != 'y' && key.Rune != 'Y') {
+		d.setStatus("Cancelled")
+		return
+	}
+
+	d.setStatus(fmt.Sprintf("%s %s…", capitalize(string(action)), res.Name))
+	backend := d.backend
+	d.start(ctx, func(ctx context.Context) update {
+		message, err := backend.Run(ctx, action, res)
+		return func(d *Dashboard) {
+			if err != nil {
+				d.setError(fmt.Sprintf("%s %s failed: %v", action, res.Name, err))
+				return
+			}
+			if message == "" {
+				message = fmt.Sprintf("%s %s requested", capitalize(string(action)), res.Name)
+			}
+			d.setStatus(message)
+			d.Refresh(ctx)
+		}
+	})
+}
+
+func (d *Dashboard) move(delta, total int) {
+	if total == 0 {
+		return
+	}
+	d.cursor = min(max(d.cursor+delta, 0), total-1)
+}
+
+// open expands the selected project or environment, or shows the logs of the selected resource
+func (d *Dashboard) open(ctx context.Context, rows []treeRow) {
+	if d.cursor >= len(rows) {
+		return
+	}
+	row := rows[d.cursor]
+
+	switch {
+	case row.resource != nil:
+		res := *row.resource
+		d.logTarget = &res
+		d.logScroll = 0
+		d.logLines = []string{"Loading logs…"}
+		backend := d.backend
+		d.start(ctx, func(ctx context.Context) update {
+			return fetchLogs(ctx, backend, res)
+		})
+	case row.env != nil:
+		row.env.expanded = !row.env.expanded
+		if row.env.expanded && !row.env.loaded && !row.env.loading {
+			env, backend := row.env, d.backend
+			env.loading = true
+			d.start(ctx, func(ctx context.Context) update {
+				return fetchEnvironment(ctx, backend, env)
+			})
+		}
+	case row.project != nil:
+		row.project.expanded = !row.project.expanded
+		if row.project.expanded && !row.project.loaded && !row.project.loading {
+			project, backend := row.project, d.backend
+			project.loading = true
+			d.start(ctx, func(ctx context.Context) update {
+				return fetchProject(ctx, backend, project)
+			})
+		}
+	}
+}
+
+// collapse closes the selected node, or moves to its parent
+func (d *Dashboard) collapse(rows []treeRow) {
+	if d.cursor >= len(rows) {
+		return
+	}
+	row := rows[d.cursor]
+
+	switch {
+	case row.resource != nil:
+		d.cursor = d.indexOf(rows, func(r treeRow) bool { return r.env == row.env && r.resource == nil })
+	case row.env != nil && row.env.expanded:
+		row.env.expanded = false
+	case row.env != nil:
+		d.cursor = d.indexOf(rows, func(r treeRow) bool { return r.project == row.project && r.env == nil })
+	case row.project != nil:
+		row.project.expanded = false
+	}
+}
+
+func (d *Dashboard) indexOf(rows []treeRow, match func(treeRow) bool) int {
+	for i, r := range rows {
+		if match(r) {
+			return i
+		}
+	}
+	return d.cursor
+}
+
+// fetchProject loads the environments of a project
+func fetchProject(ctx context.Context, backend Backend, p *projectNode) update {
+	project, err := backend.Project(ctx, p.project.UUID)
+	return func(d *Dashboard) {
+		p.loading = false
+		if err != nil {
+			d.setError(fmt.Sprintf("failed to load project %s: %v", p.project.Name, err))
+			return
+		}
+		p.envs = make([]*environmentNode, len(project.Environments))
+		for i, env := range project.Environments {
+			p.envs[i] = &environmentNode{project: p, env: env}
+		}
+		p.loaded = true
+	}
+}
+
+// fetchEnvironment loads the resources of an environment
+func fetchEnvironment(ctx context.Context, backend Backend, e *environmentNode) update {
+	env, err := backend.Environment(ctx, e.project.project.UUID, e.env.UUID)
+	return func(d *Dashboard) {
+		e.loading = false
+		if err != nil {
+			d.setError(fmt.Sprintf("failed to load environment %s: %v", e.env.Name, err))
+			return
+		}
+		e.resources = env.All()
+		e.loaded = true
+	}
+}
+
+// fetchLogs loads the logs of target. They are dropped if another resource was opened meanwhile.
+func fetchLogs(ctx context.Context, backend Backend, target models.EnvironmentResource) update {
+	logs, err := backend.Logs(ctx, target)
+	return func(d *Dashboard) {
+		if d.logTarget == nil || d.logTarget.UUID != target.UUID {
+			return
+		}
+		if err != nil {
+			d.logLines = []string{fmt.Sprintf("failed to load logs: %v", err)}
+			return
+		}
+		d.logLines = splitLogLines(logs)
+	}
+}
+
+func (d *Dashboard) selectedResource(rows []treeRow) *models.EnvironmentResource {
+	if d.cursor < len(rows) {
+		return rows[d.cursor].resource
+	}
+	return nil
+}
+
+// rows flattens the expanded part of the project tree
+func (d *Dashboard) rows() []treeRow {
+	var rows []treeRow
+	for _, p := range d.projects {
+		rows = append(rows, treeRow{depth: 0, project: p})
+		if !p.expanded {
+			continue
+		}
+		for _, e := range p.envs {
+			rows = append(rows, treeRow{depth: 1, project: p, env: e})
+			if !e.expanded {
+				continue
+			}
+			for i := range e.resources {
+				rows = append(rows, treeRow{depth: 2, project: p, env: e, resource: &e.resources[i]})
+			}
+		}
+	}
+	return rows
+}
+
+func (d *Dashboard) setStatus(msg string) {
+	d.status = msg
+	d.isError = false
+}
+
+func (d *Dashboard) setError(msg string) {
+	d.status = msg
+	d.isError = true
+}
+
+// ansiSequence matches terminal escape sequences embedded in log output
+var ansiSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// splitLogLines splits logs into lines, expanding tabs and dropping control characters
+// that would corrupt the screen layout
+func splitLogLines(logs string) []string {
+	logs = strings.TrimRight(strings.ReplaceAll(logs, "\r\n", "\n"), "\n")
+	if logs == "" {
+		return nil
+	}
+	lines := strings.Split(logs, "\n")
+	for i, line := range lines {
+		line = ansiSequence.ReplaceAllString(line, "")
+		line = strings.ReplaceAll(line, "\t", "    ")
+		lines[i] = strings.Map(func(r rune) rune {
+			if r < 32 || r == 127 {
+				return -1
+			}
+			return r
+		}, line)
+	}
+	return lines
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,199 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+)
+
+type runCall struct {
+	action   Action
+	resource string
+}
+
+type fakeBackend struct {
+	logs  string
+	runs  []runCall
+	fails bool
+}
+
+func (f *fakeBackend) Projects(_ context.Context) ([]models.Project, error) {
+	return []models.Project{{UUID: "proj-1", Name: "shop"}, {UUID: "proj-2", Name: "blog"}}, nil
+}
+
+func (f *fakeBackend) Project(_ context.Context, uuid string) (*models.Project, error) {
+	return &models.Project{UUID: uuid, Environments: []models.Environment{
+		{UUID: "env-1", Name: "production"},
+		{UUID: "env-2", Name: "staging"},
+	}}, nil
+}
+
+func (f *fakeBackend) Environment(_ context.Context, _, _ string) (*models.EnvironmentResources, error) {
+	return &models.EnvironmentResources{
+		Applications: []models.EnvironmentResource{{UUID: "app-1", Name: "api", Status: "running:healthy"}},
+		Postgresqls:  []models.EnvironmentResource{{UUID: "db-1", Name: "main-db", Status: "exited"}},
+	}, nil
+}
+
+func (f *fakeBackend) Deployments(_ context.Context) ([]models.Deployment, error) {
+	name := "api"
+	return []models.Deployment{{UUID: "dep-1", ApplicationName: &name, Status: "in_progress"}}, nil
+}
+
+func (f *fakeBackend) Logs(_ context.Context, res models.EnvironmentResource) (string, error) {
+	return f.logs, nil
+}
+
+func (f *fakeBackend) Run(_ context.Context, action Action, res models.EnvironmentResource) (string, error) {
+	f.runs = append(f.runs, runCall{action: action, resource: res.UUID})
+	if f.fails {
+		return "", errors.New("boom")
+	}
+	return "", nil
+}
+
+func runeKey(r rune) Key {
+	return Key{Code: KeyRune, Rune: r}
+}
+
+// newLoadedDashboard returns a dashboard with shop/production expanded and the cursor on the "api" app
+func newLoadedDashboard(t *testing.T, backend *fakeBackend) *Dashboard {
+	t.Helper()
+	ctx := context.Background()
+	d := NewDashboard(backend, "prod")
+	d.now = func() time.Time { return time.Date(2026, 1, 2, 10, 30, 0, 0, time.UTC) }
+	d.Load(ctx)
+
+	d.HandleKey(ctx, Key{Code: KeyEnter}) // expand shop
+	d.HandleKey(ctx, Key{Code: KeyDown})  // production
+	d.HandleKey(ctx, Key{Code: KeyEnter}) // expand production
+	d.HandleKey(ctx, Key{Code: KeyDown})  // api
+	return d
+}
+
+func TestDashboard_Tree(t *testing.T) {
+	d := newLoadedDashboard(t, &fakeBackend{})
+
+	rows := d.rows()
+	require.Len(t, rows, 6) // shop, production, api, main-db, staging, blog
+	require.NotNil(t, d.selectedResource(rows))
+	assert.Equal(t, "api", d.selectedResource(rows).Name)
+	assert.Equal(t, models.ResourceTypeApplication, d.selectedResource(rows).Type)
+
+	// Left from a resource jumps to its environment, left again collapses it
+	ctx := context.Background()
+	d.HandleKey(ctx, Key{Code: KeyLeft})
+	assert.Equal(t, 1, d.cursor)
+	d.HandleKey(ctx, Key{Code: KeyLeft})
+	assert.Len(t, d.rows(), 4)
+}
+
+func TestDashboard_View(t *testing.T) {
+	d := newLoadedDashboard(t, &fakeBackend{logs: "starting\n\x1b[32mready\x1b[0m\tok\n"})
+	d.HandleKey(context.Background(), Key{Code: KeyEnter}) // show api logs
+
+	lines := d.View(100, 20)
+	require.Len(t, lines, 20)
+	screen := strings.Join(lines, "\n")
+
+	assert.Contains(t, lines[0], "Saturn Dashboard · prod")
+	assert.Contains(t, lines[0], "updated 10:30:00")
+	assert.Contains(t, screen, "▾ shop")
+	assert.Contains(t, screen, "api [app]")
+	assert.Contains(t, screen, "main-db [db]")
+	assert.Contains(t, screen, "DEPLOYMENTS (1 active)")
+	assert.Contains(t, screen, "dep-1")
+	assert.Contains(t, screen, "LOGS · api")
+	assert.Contains(t, screen, "ready    ok")
+	assert.Contains(t, lines[19], "q quit")
+}
+
+func TestDashboard_ViewTooSmall(t *testing.T) {
+	d := NewDashboard(&fakeBackend{}, "")
+	lines := d.View(20, 5)
+	assert.Len(t, lines, 5)
+	assert.Contains(t, lines[0], "too small")
+}
+
+func TestDashboard_ActionRequiresConfirmation(t *testing.T) {
+	ctx := context.Background()
+	backend := &fakeBackend{}
+	d := newLoadedDashboard(t, backend)
+
+	d.HandleKey(ctx, runeKey('r'))
+	assert.Equal(t, "Restart api? [y/N]", d.status)
+	d.HandleKey(ctx, runeKey('n'))
+	assert.Empty(t, backend.runs)
+	assert.Equal(t, "Cancelled", d.status)
+
+	d.HandleKey(ctx, runeKey('d'))
+	d.HandleKey(ctx, runeKey('y'))
+	assert.Equal(t, []runCall{{action: ActionDeploy, resource: "app-1"}}, backend.runs)
+	assert.Equal(t, "Deploy api requested", d.status)
+	assert.False(t, d.isError)
+}
+
+func TestDashboard_ActionErrors(t *testing.T) {
+	ctx := context.Background()
+	backend := &fakeBackend{fails: true}
+	d := newLoadedDashboard(t, backend)
+
+	d.HandleKey(ctx, runeKey('s'))
+	d.HandleKey(ctx, runeKey('y'))
+	assert.True(t, d.isError)
+	assert.Contains(t, d.status, "stop api failed: boom")
+
+	// Rollback is not offered for databases
+	d.HandleKey(ctx, Key{Code: KeyDown})
+	d.HandleKey(ctx, runeKey('b'))
+	assert.Nil(t, d.pending)
+	assert.Contains(t, d.status, "only available for applications")
+
+	// Actions need a resource
+	d.HandleKey(ctx, Key{Code: KeyUp})
+	d.HandleKey(ctx, Key{Code: KeyUp})
+	d.HandleKey(ctx, runeKey('r'))
+	assert.Equal(t, "Select a resource first", d.status)
+}
+
+func TestDashboard_Quit(t *testing.T) {
+	d := NewDashboard(&fakeBackend{}, "")
+	d.HandleKey(context.Background(), runeKey('q'))
+	assert.True(t, d.Quit())
+}
+
+func TestDashboard_KeysWhileBackendIsBusy(t *testing.T) {
+	ctx := context.Background()
+	backend := &fakeBackend{}
+	d := newLoadedDashboard(t, backend)
+
+	// Hold tasks back as if the API were slow
+	var queued []task
+	d.spawn = func(_ context.Context, t task) {
+		queued = append(queued, t)
+	}
+
+	d.HandleKey(ctx, runeKey('R'))
+	d.HandleKey(ctx, runeKey('R')) // a running refresh is not started twice
+	d.HandleKey(ctx, Key{Code: KeyEnter})
+	require.Len(t, queued, 2)
+	assert.True(t, d.Busy())
+	assert.Equal(t, []string{"Loading logs…"}, d.logLines)
+	assert.Contains(t, d.View(100, 20)[0], "loading…")
+
+	d.HandleKey(ctx, runeKey('q'))
+	assert.True(t, d.Quit())
+
+	for _, task := range queued {
+		task(ctx)(d)
+	}
+	assert.False(t, d.Busy())
+	assert.Equal(t, "Refreshed", d.status)
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"bufio"
+)
+
+// KeyCode identifies a special key; printable characters use KeyRune
+type KeyCode int
+
+// Keys understood by the dashboard
+const (
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyEnter
+	KeyTab
+	KeyEscape
+	KeyBackspace
+	KeyCtrlC
+	KeyPageUp
+	KeyPageDown
+	KeyUnknown
+)
+
+// Key is a single key press
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// ReadKey reads one key press from a terminal in raw mode, decoding arrow-key escape sequences
+func ReadKey(r *bufio.Reader) (Key, error) {
+	ch, _, err := r.ReadRune()
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch ch {
+	case '\r', '\n':
+		return Key{Code: KeyEnter}, nil
+	case '\t':
+		return Key{Code: KeyTab}, nil
+	case 3:
+		return Key{Code: KeyCtrlC}, nil
+	case 127, 8:
+		return Key{Code: KeyBackspace}, nil
+	case 27:
+		return readEscape(r)
+	}
+	return Key{Code: KeyRune, Rune: ch}, nil
+}
+
+// readEscape decodes the rest of an escape sequence. A lone ESC is reported
+// as KeyEscape: terminals send sequences in a single write, so if nothing
+// else is buffered the user pressed Escape itself.
+func readEscape(r *bufio.Reader) (Key, error) {
+	if r.Buffered() == 0 {
+		return Key{Code: KeyEscape}, nil
+	}
+	next, err := r.ReadByte()
+	if err != nil {
+		return Key{}, err
+	}
+	if next != '[' && next != 'O' {
+		return Key{Code: KeyEscape}, nil
+	}
+
+	// Read parameter bytes up to the final byte of the sequence
+	var params []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return Key{}, err
+		}
+		if b >= 0x40 && b <= 0x7e {
+			return decodeEscape(b, string(params)), nil
+		}
+		params = append(params, b)
+	}
+}
+
+func decodeEscape(final byte, params string) Key {
+	switch final {
+	case 'A':
+		return Key{Code: KeyUp}
+	case 'B':
+		return Key{Code: KeyDown}
+	case 'C':
+		return Key{Code: KeyRight}
+	case 'D':
+		return Key{Code: KeyLeft}
+	case '~':
+		switch params {
+		case "5":
+			return Key{Code: KeyPageUp}
+		case "6":
+			return Key{Code: KeyPageDown}
+		}
+	}
+	return Key{Code: KeyUnknown}
+}
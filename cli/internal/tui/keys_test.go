@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadKey(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []Key
+	}{
+		{"q", []Key{{Code: KeyRune, Rune: 'q'}}},
+		{"\r", []Key{{Code: KeyEnter}}},
+		{"\x03", []Key{{Code: KeyCtrlC}}},
+		{"\x1b[A\x1b[B", []Key{{Code: KeyUp}, {Code: KeyDown}}},
+		{"\x1bOC\x1b[D", []Key{{Code: KeyRight}, {Code: KeyLeft}}},
+		{"\x1b[5~\x1b[6~", []Key{{Code: KeyPageUp}, {Code: KeyPageDown}}},
+		{"\x1b", []Key{{Code: KeyEscape}}},
+		{"\x1b[1;5A", []Key{{Code: KeyUp}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.ReplaceAll(tt.input, "\x1b", "ESC"), func(t *testing.T) {
+			r := bufio.NewReader(strings.NewReader(tt.input))
+			for _, want := range tt.want {
+				got, err := ReadKey(r)
+				require.NoError(t, err)
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"github.com/saturn-platform/saturn-cli/internal/output"
+)
+
+const pickerHelpLine = "↑↓ move  ⏎ select  q cancel"
+
+// Picker is a full-screen list the user selects one entry from. Like Dashboard it only
+// holds state; RunPicker drives it on a terminal.
+type Picker struct {
+	title    string
+	items    []string
+	cursor   int
+	selected bool
+	done     bool
+}
+
+// NewPicker creates a picker showing items under title
+func NewPicker(title string, items []string) *Picker {
+	return &Picker{title: title, items: items}
+}
+
+// Done reports whether the user selected an entry or cancelled
+func (p *Picker) Done() bool {
+	return p.done
+}
+
+// Selected returns the index of the chosen entry, reporting false when the user cancelled
+func (p *Picker) Selected() (int, bool) {
+	if !p.selected {
+		return 0, false
+	}
+	return p.cursor, true
+}
+
+// HandleKey moves the cursor, selects the entry under it or cancels
+func (p *Picker) HandleKey(key Key) {
+	switch key.Code {
+	case KeyUp:
+		p.move(-1)
+	case KeyDown:
+		p.move(1)
+	case KeyPageUp:
+		p.move(-10)
+	case KeyPageDown:
+		p.move(10)
+	case KeyEnter:
+		if len(p.items) > 0 {
+			p.selected = true
+			p.done = true
+		}
+	case KeyEscape, KeyCtrlC:
+		p.done = true
+	case KeyRune:
+		switch key.Rune {
+		case 'k':
+			p.move(-1)
+		case 'j':
+			p.move(1)
+		case 'q':
+			p.done = true
+		}
+	}
+}
+
+func (p *Picker) move(delta int) {
+	p.cursor = min(max(p.cursor+delta, 0), max(len(p.items)-1, 0))
+}
+
+// View renders the picker as exactly height lines of at most width columns
+func (p *Picker) View(width, height int) []string {
+	if width < 20 || height < 4 {
+		return fitLines([]string{"Terminal too small"}, height)
+	}
+
+	listHeight := height - 2
+	// Scroll so the cursor stays on screen
+	offset := max(p.cursor-listHeight+1, 0)
+
+	lines := make([]string, 0, height)
+	lines = append(lines, output.ColorReverse+output.ColorBold+pad(fit(" "+p.title, width), width)+output.ColorReset)
+	for i := offset; i < len(p.items) && len(lines) <= listHeight; i++ {
+		if i == p.cursor {
+			lines = append(lines, output.ColorReverse+pad(fit("› "+p.items[i], width), width)+output.ColorReset)
+			continue
+		}
+		lines = append(lines, fit("  "+p.items[i], width))
+	}
+	lines = fitLines(lines, listHeight+1)
+	lines = append(lines, output.Colorize(fit(pickerHelpLine, width), output.ColorGray, true))
+	return lines
+}
+
+// RunPicker draws p on term until the user selects an entry or cancels, and returns
+// the index of the chosen entry, reporting false when the user cancelled
+func RunPicker(p *Picker, term *Terminal) (int, bool, error) {
+	for {
+		width, height := term.Size()
+		if err := term.Draw(p.View(width, height)); err != nil {
+			return 0, false, err
+		}
+		if p.Done() {
+			index, ok := p.Selected()
+			return index, ok, nil
+		}
+		key, err := term.ReadKey()
+		if err != nil {
+			return 0, false, err
+		}
+		p.HandleKey(key)
+	}
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPicker_Select(t *testing.T) {
+	p := NewPicker("Roll back api", []string{"abc1234", "def5678", "0123abc"})
+
+	p.HandleKey(Key{Code: KeyDown})
+	p.HandleKey(Key{Code: KeyRune, Rune: 'j'})
+	p.HandleKey(Key{Code: KeyDown})
+	p.HandleKey(Key{Code: KeyRune, Rune: 'k'})
+	assert.False(t, p.Done())
+
+	p.HandleKey(Key{Code: KeyEnter})
+	require.True(t, p.Done())
+	index, ok := p.Selected()
+	assert.True(t, ok)
+	assert.Equal(t, 1, index)
+}
+
+func TestPicker_Cancel(t *testing.T) {
+	for _, key := range []Key{{Code: KeyEscape}, {Code: KeyCtrlC}, {Code: KeyRune, Rune: 'q'}} {
+		p := NewPicker("title", []string{"a", "b"})
+		p.HandleKey(Key{Code: KeyDown})
+		p.HandleKey(key)
+		assert.True(t, p.Done())
+		_, ok := p.Selected()
+		assert.False(t, ok)
+	}
+}
+
+func TestPicker_EnterWithoutItems(t *testing.T) {
+	p := NewPicker("title", nil)
+	p.HandleKey(Key{Code: KeyEnter})
+	assert.False(t, p.Done())
+}
+
+func TestPicker_View(t *testing.T) {
+	items := make([]string, 30)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%02d", i)
+	}
+	p := NewPicker("Pick one", items)
+
+	lines := p.View(40, 10)
+	require.Len(t, lines, 10)
+	assert.Contains(t, lines[0], "Pick one")
+	assert.Contains(t, lines[1], "› item-00")
+	assert.Contains(t, lines[9], "q cancel")
+
+	// The list scrolls to keep the cursor visible
+	p.HandleKey(Key{Code: KeyPageDown})
+	p.HandleKey(Key{Code: KeyPageDown})
+	lines = p.View(40, 10)
+	require.Len(t, lines, 10)
+	assert.Contains(t, lines[8], "› item-20")
+	assert.NotContains(t, strings.Join(lines, "\n"), "item-00")
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultRefreshInterval is how often live data is reloaded
+	DefaultRefreshInterval = 5 * time.Second
+	// MinRefreshInterval keeps the dashboard from hammering the API
+	MinRefreshInterval = time.Second
+
+	// resizeCheckInterval is how often the terminal size is checked for changes
+	resizeCheckInterval = 250 * time.Millisecond
+)
+
+// Run draws the dashboard on term and processes key presses until the user
+// quits or ctx is cancelled. Live data is refreshed every refresh interval.
+// Backend calls run in goroutines that post their results back to the loop,
+// so the screen keeps redrawing and quitting works while the API is slow.
+func Run(ctx context.Context, d *Dashboard, term *Terminal, refresh time.Duration) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	updates := make(chan update)
+	d.spawn = func(ctx context.Context, t task) {
+		go func() {
+			u := t(ctx)
+			select {
+			case updates <- u:
+			case <-ctx.Done():
+			}
+		}()
+	}
+	d.Load(ctx)
+
+	keys := make(chan Key)
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			key, err := term.ReadKey()
+			if err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case keys <- key:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	refreshTicker := time.NewTicker(refresh)
+	defer refreshTicker.Stop()
+	resizeTicker := time.NewTicker(resizeCheckInterval)
+	defer resizeTicker.Stop()
+
+	width, height := term.Size()
+	for {
+		if err := term.Draw(d.View(width, height)); err != nil {
+			return err
+		}
+		if d.Quit() {
+			return nil
+		}
+
+	wait:
+		for {
+			select {
+			case key := <-keys:
+				d.HandleKey(ctx, key)
+				break wait
+			case u := <-updates:
+				u(d)
+				break wait
+			case <-refreshTicker.C:
+				d.Refresh(ctx)
+				break wait
+			case <-resizeTicker.C:
+				if w, h := term.Size(); w != width || h != height {
+					width, height = w, h
+					break wait
+				}
+			case err := <-readErr:
+				return err
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
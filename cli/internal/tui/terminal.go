@@ -0,0 +1,98 @@
+// Package tui implements the full-screen terminal dashboard
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/saturn-platform/saturn-cli/internal/output"
+)
+
+// Escape sequences for full-screen mode
+const (
+	enterAltScreen = "\033[?1049h"
+	exitAltScreen  = "\033[?1049l"
+	hideCursor     = "\033[?25l"
+	showCursor     = "\033[?25h"
+	cursorHome     = "\033[H"
+	clearToEOL     = "\033[K"
+	clearToEOS     = "\033[J"
+)
+
+// ErrNotTerminal is returned when the dashboard is started without an interactive terminal
+var ErrNotTerminal = errors.New("the dashboard requires an interactive terminal")
+
+// Terminal is an interactive terminal switched to raw mode and the alternate screen
+type Terminal struct {
+	in      *os.File
+	out     *os.File
+	reader  *bufio.Reader
+	restore func() error
+}
+
+// OpenTerminal puts the terminal on in/out into raw mode and switches to the alternate screen.
+// Close must be called to restore the terminal.
+func OpenTerminal(in, out *os.File) (*Terminal, error) {
+	if !term.IsTerminal(int(in.Fd())) || !output.IsTerminal(out) {
+		return nil, ErrNotTerminal
+	}
+
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to switch terminal to raw mode: %w", err)
+	}
+
+	t := &Terminal{
+		in:     in,
+		out:    out,
+		reader: bufio.NewReader(in),
+		restore: func() error {
+			return term.Restore(int(in.Fd()), state)
+		},
+	}
+	enableVirtualTerminal(out)
+	fmt.Fprint(out, enterAltScreen+hideCursor)
+	return t, nil
+}
+
+// Close leaves the alternate screen and restores the original terminal mode
+func (t *Terminal) Close() error {
+	fmt.Fprint(t.out, showCursor+exitAltScreen)
+	return t.restore()
+}
+
+// Size returns the terminal width and height, falling back to 80x24
+func (t *Terminal) Size() (int, int) {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// ReadKey blocks until the next key press
+func (t *Terminal) ReadKey() (Key, error) {
+	return ReadKey(t.reader)
+}
+
+// Draw replaces the screen content with lines
+func (t *Terminal) Draw(lines []string) error {
+	var b strings.Builder
+	b.WriteString(cursorHome)
+	for i, line := range lines {
+		if i > 0 {
+			// Raw mode disables output post-processing, so a newline needs an explicit carriage return
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString(clearToEOL)
+	}
+	b.WriteString(clearToEOS)
+	_, err := t.out.WriteString(b.String())
+	return err
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package tui
+
+import "os"
+
+// enableVirtualTerminal is a no-op: Unix terminals interpret escape sequences natively
+func enableVirtualTerminal(_ *os.File) {}
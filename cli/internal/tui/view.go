@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/saturn-platform/saturn-cli/internal/models"
+	"github.com/saturn-platform/saturn-cli/internal/output"
+)
+
+const helpLine = "↑↓ move  ⏎ open  ← back  d deploy  r restart  s stop  b rollback  PgUp/PgDn logs  R refresh  q quit"
+
+// resourceTypeLabels are the short type tags shown in the tree
+var resourceTypeLabels = map[string]string{
+	models.ResourceTypeApplication: "app",
+	models.ResourceTypeService:     "svc",
+	models.ResourceTypeDatabase:    "db",
+}
+
+// View renders the dashboard as exactly height lines of at most width columns
+func (d *Dashboard) View(width, height int) []string {
+	if width < 40 || height < 10 {
+		return fitLines([]string{"Terminal too small for the dashboard"}, height)
+	}
+
+	bodyHeight := height - 3
+	leftWidth := min(max(width/3, 28), 48)
+	rightWidth := width - leftWidth - 3
+
+	left := d.viewTree(leftWidth, bodyHeight)
+	right := d.viewRight(rightWidth, bodyHeight)
+
+	lines := make([]string, 0, height)
+	lines = append(lines, d.viewHeader(width))
+	for i := 0; i < bodyHeight; i++ {
+		lines = append(lines, left[i]+" "+output.Colorize("│", output.ColorGray, true)+" "+right[i])
+	}
+	lines = append(lines, d.viewStatus(width))
+	lines = append(lines, output.Colorize(fit(helpLine, width), output.ColorGray, true))
+	return lines
+}
+
+func (d *Dashboard) viewHeader(width int) string {
+	left := " Saturn Dashboard"
+	if d.title != "" {
+		left += " · " + d.title
+	}
+	right := ""
+	if d.Busy() {
+		right = "loading… "
+	}
+	if !d.updated.IsZero() {
+		right += "updated " + d.updated.Format("15:04:05") + " "
+	}
+	gap := max(width-textWidth(left)-textWidth(right), 1)
+	return output.ColorReverse + output.ColorBold + fit(left+strings.Repeat(" ", gap)+right, width) + output.ColorReset
+}
+
+func (d *Dashboard) viewStatus(width int) string {
+	if d.status == "" {
+		return ""
+	}
+	color := output.ColorCyan
+	if d.isError {
+		color = output.ColorRed
+	} else if d.pending != nil {
+		color = output.ColorYellow + output.ColorBold
+	}
+	return output.Colorize(fit(d.status, width), color, true)
+}
+
+// viewTree renders the project → environment → resource tree, scrolled to keep the cursor visible
+func (d *Dashboard) viewTree(width, height int) []string {
+	rows := d.rows()
+	d.cursor = min(d.cursor, max(len(rows)-1, 0))
+
+	lines := []string{sectionTitle("PROJECTS", width)}
+	visible := height - 1
+	offset := 0
+	if d.cursor >= visible {
+		offset = d.cursor - visible + 1
+	}
+
+	if len(rows) == 0 {
+		lines = append(lines, pad("No projects", width))
+	}
+	for i := offset; i < len(rows) && i < offset+visible; i++ {
+		lines = append(lines, d.viewTreeRow(rows[i], i == d.cursor, width))
+	}
+	return padLines(lines, width, height)
+}
+
+func (d *Dashboard) viewTreeRow(row treeRow, selected bool, width int) string {
+	indent := strings.Repeat("  ", row.depth)
+
+	var text, status string
+	switch {
+	case row.resource != nil:
+		text = fmt.Sprintf("%s%s [%s]", indent, row.resource.Name, resourceTypeLabels[row.resource.Type])
+		status = row.resource.Status
+	case row.env != nil:
+		text = indent + expander(row.env.expanded) + " " + row.env.env.Name
+	default:
+		text = indent + expander(row.project.expanded) + " " + row.project.project.Name
+	}
+
+	if status == "" {
+		line := pad(fit(text, width), width)
+		if selected {
+			return output.ColorReverse + line + output.ColorReset
+		}
+		return line
+	}
+
+	// Keep the status right-aligned and colored, truncating the name if needed
+	statusWidth := min(textWidth(status), width/2)
+	status = fit(status, statusWidth)
+	name := pad(fit(text, width-statusWidth-1), width-statusWidth-1)
+	if selected {
+		return output.ColorReverse + name + " " + status + output.ColorReset
+	}
+	return name + " " + output.Colorize(status, output.StatusColor(status), true)
+}
+
+// viewRight renders the deployments pane above the log pane
+func (d *Dashboard) viewRight(width, height int) []string {
+	deployHeight := min(max(len(d.deployments)+1, 3), height/3)
+	lines := padLines(d.viewDeployments(width, deployHeight), width, deployHeight)
+	return append(lines, d.viewLogs(width, height-deployHeight)...)
+}
+
+func (d *Dashboard) viewDeployments(width, height int) []string {
+	lines := []string{sectionTitle(fmt.Sprintf("DEPLOYMENTS (%d active)", len(d.deployments)), width)}
+	if len(d.deployments) == 0 {
+		return append(lines, pad("No deployments in progress", width))
+	}
+
+	for _, dep := range d.deployments {
+		if len(lines) == height {
+			break
+		}
+		name := ""
+		if dep.ApplicationName != nil {
+			name = *dep.ApplicationName
+		}
+		commit := ""
+		if dep.Commit != nil {
+			commit = models.ShortSHA(*dep.Commit)
+		}
+		status := fit(dep.Status, 14)
+		rest := fit(fmt.Sprintf("%-24s %-8s %s", name, commit, dep.UUID), width-15)
+		lines = append(lines, output.Colorize(pad(status, 14), output.StatusColor(dep.Status), true)+" "+pad(rest, width-15))
+	}
+	return lines
+}
+
+func (d *Dashboard) viewLogs(width, height int) []string {
+	if d.logTarget == nil {
+		return padLines([]string{
+			sectionTitle("LOGS", width),
+			pad("Select a resource and press Enter to show its logs", width),
+		}, width, height)
+	}
+
+	title := fmt.Sprintf("LOGS · %s", d.logTarget.Name)
+	if d.logScroll > 0 {
+		title += fmt.Sprintf(" (scrolled %d)", d.logScroll)
+	}
+	lines := []string{sectionTitle(title, width)}
+
+	// Show the tail of the logs, shifted up by the scroll offset
+	visible := height - 1
+	d.logScroll = min(d.logScroll, max(len(d.logLines)-visible, 0))
+	end := len(d.logLines) - d.logScroll
+	start := max(end-visible, 0)
+	for _, line := range d.logLines[start:end] {
+		lines = append(lines, pad(fit(line, width), width))
+	}
+	return padLines(lines, width, height)
+}
+
+func sectionTitle(title string, width int) string {
+	return output.ColorBold + pad(fit(title, width), width) + output.ColorReset
+}
+
+func expander(expanded bool) string {
+	if expanded {
+		return "▾"
+	}
+	return "▸"
+}
+
+// textWidth counts runes, which is a good enough approximation of display width here
+func textWidth(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// fit truncates s to width runes, marking truncation with an ellipsis
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if textWidth(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+// pad right-pads plain text with spaces to width runes
+func pad(s string, width int) string {
+	if n := width - textWidth(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// padLines fills lines up to height with blank lines of the given width
+func padLines(lines []string, width, height int) []string {
+	for len(lines) < height {
+		lines = append(lines, strings.Repeat(" ", width))
+	}
+	return lines[:height]
+}
+
+func fitLines(lines []string, height int) []string {
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return lines[:height]
+}